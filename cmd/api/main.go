@@ -13,6 +13,7 @@ import (
 	"github.com/eugene-twix/amber-bot/internal/config"
 	"github.com/eugene-twix/amber-bot/internal/migrations"
 	bunrepo "github.com/eugene-twix/amber-bot/internal/repository/bun"
+	"github.com/eugene-twix/amber-bot/internal/repository/cached"
 )
 
 func main() {
@@ -35,13 +36,24 @@ func main() {
 	}
 	defer c.Close()
 
-	// Initialize repositories
+	// Initialize repositories (reads cached, writes invalidate by tag)
 	repos := &api.Repositories{
-		User:       bunrepo.NewUserRepo(db),
-		Team:       bunrepo.NewTeamRepo(db),
-		Member:     bunrepo.NewMemberRepo(db),
-		Tournament: bunrepo.NewTournamentRepo(db),
-		Result:     bunrepo.NewResultRepo(db),
+		User:        bunrepo.NewUserRepo(db),
+		Team:        cached.NewTeamRepo(bunrepo.NewTeamRepo(db), c),
+		Member:      cached.NewMemberRepo(bunrepo.NewMemberRepo(db), c),
+		Tournament:  cached.NewTournamentRepo(bunrepo.NewTournamentRepo(db), c),
+		Result:      cached.NewResultRepo(bunrepo.NewResultRepo(db, cfg.Location.String()), c),
+		Audit:       bunrepo.NewAuditRepo(db),
+		Stats:       cached.NewStatsRepo(bunrepo.NewStatsRepo(db), c),
+		Badges:      bunrepo.NewBadgeRepo(db),
+		Webhooks:    bunrepo.NewWebhookRepo(db),
+		Venues:      cached.NewVenueRepo(bunrepo.NewVenueRepo(db), c),
+		Disciplines: cached.NewDisciplineRepo(bunrepo.NewDisciplineRepo(db), c),
+		Players:     cached.NewPlayerRepo(bunrepo.NewPlayerRepo(db), c),
+		Tokens:      bunrepo.NewAPITokenRepo(db),
+		Roles:       cached.NewRoleRepo(bunrepo.NewRoleRepo(db), c),
+		Invites:     bunrepo.NewInviteRepo(db),
+		Events:      bunrepo.NewEventRepo(db),
 	}
 
 	// Create API server
@@ -51,6 +63,13 @@ func main() {
 		FrontendPath: cfg.FrontendPath,
 		DevMode:      cfg.DevMode,
 		DevUserID:    cfg.DevUserID,
+
+		SessionSecret:  cfg.SessionSecret,
+		CalendarSecret: cfg.CalendarSecret,
+		MiniAppLink:    cfg.MiniAppLink,
+		BotUsername:    cfg.BotUsername,
+		LockedAdminIDs: cfg.LockedAdminIDs(),
+		Timezone:       cfg.Location,
 	}, repos, c)
 
 	if cfg.DevMode {
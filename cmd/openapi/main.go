@@ -0,0 +1,25 @@
+// cmd/openapi/main.go
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/eugene-twix/amber-bot/internal/api"
+)
+
+// Writes the OpenAPI document and frontend types; run from the repository root
+func main() {
+	files, err := api.SpecFiles()
+	if err != nil {
+		log.Fatalf("Failed to render spec: %v", err)
+	}
+
+	for path, data := range files {
+		if err := os.WriteFile(filepath.FromSlash(path), data, 0o644); err != nil {
+			log.Fatalf("Failed to write %s: %v", path, err)
+		}
+		log.Printf("Wrote %s", path)
+	}
+}
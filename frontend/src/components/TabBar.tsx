@@ -6,7 +6,7 @@ const tabs = [
   { path: '/', label: 'Рейтинг', icon: '🏆' },
   { path: '/teams', label: 'Команды', icon: '📋' },
   { path: '/tournaments', label: 'Турниры', icon: '🎯' },
-  { path: '/manage', label: 'Управление', icon: '⚙️', roles: ['organizer', 'admin'] },
+  { path: '/manage', label: 'Управление', icon: '⚙️', manage: true },
 ];
 
 export function TabBar() {
@@ -14,8 +14,8 @@ export function TabBar() {
   const { data: user } = useMe();
 
   const visibleTabs = tabs.filter((tab) => {
-    if (!tab.roles) return true;
-    return user && tab.roles.includes(user.role);
+    if (!tab.manage) return true;
+    return !!user?.permissions?.length;
   });
 
   return (
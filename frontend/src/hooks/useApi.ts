@@ -1,5 +1,6 @@
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
 import { api } from '@/services/api';
+import type { TournamentSchedule } from '@/services/api';
 
 // Query keys
 export const queryKeys = {
@@ -80,11 +81,11 @@ export function useTournamentResults(id: number) {
   });
 }
 
-// Rating
-export function useRating() {
+// Rating of a discipline: the default one unless given, 'all' - every discipline
+export function useRating(disciplineId?: number | 'all') {
   return useQuery({
-    queryKey: queryKeys.rating,
-    queryFn: api.getRating,
+    queryKey: [...queryKeys.rating, disciplineId ?? 'default'],
+    queryFn: () => api.getRating(disciplineId),
   });
 }
 
@@ -143,8 +144,8 @@ export function useCreateMember() {
 export function useUpdateUserRole() {
   const queryClient = useQueryClient();
   return useMutation({
-    mutationFn: (data: { telegramId: number; role: string }) =>
-      api.updateUserRole(data.telegramId, data.role),
+    mutationFn: (data: { telegramId: number; role: string; confirm?: boolean }) =>
+      api.updateUserRole(data.telegramId, data.role, data.confirm),
     onSuccess: () => {
       queryClient.invalidateQueries({ queryKey: queryKeys.users });
     },
@@ -203,8 +204,8 @@ export function useDeleteMember() {
 export function useUpdateTournament() {
   const queryClient = useQueryClient();
   return useMutation({
-    mutationFn: (data: { id: number; name: string; date: string; location: string; version: number }) =>
-      api.updateTournament(data.id, data.name, data.date, data.location, data.version),
+    mutationFn: (data: { id: number; name: string; schedule: TournamentSchedule; location: string; version: number }) =>
+      api.updateTournament(data.id, data.name, data.schedule, data.location, data.version),
     onSuccess: (_, variables) => {
       queryClient.invalidateQueries({ queryKey: queryKeys.tournaments });
       queryClient.invalidateQueries({ queryKey: queryKeys.tournament(variables.id) });
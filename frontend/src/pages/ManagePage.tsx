@@ -32,8 +32,8 @@ export function ManagePage() {
   const [selectedTeamForResult, setSelectedTeamForResult] = useState('');
   const [resultPlace, setResultPlace] = useState('');
 
-  const isOrgOrAdmin = user?.role === 'organizer' || user?.role === 'admin';
-  const isAdmin = user?.role === 'admin';
+  const isOrgOrAdmin = !!user?.permissions?.length;
+  const isAdmin = !!user?.permissions?.includes('users.manage');
 
   if (!isOrgOrAdmin) {
     return (
@@ -340,10 +340,17 @@ export function ManagePage() {
                         key={`${u.telegram_id}-${u.role}`}
                         value={u.role}
                         onValueChange={async (newRole) => {
+                          if (
+                            newRole === 'admin' &&
+                            !window.confirm('Назначить админом? Админ получит все права.')
+                          ) {
+                            return;
+                          }
                           try {
                             await updateUserRole.mutateAsync({
                               telegramId: u.telegram_id,
                               role: newRole,
+                              confirm: newRole === 'admin',
                             });
                             toast.success('Роль обновлена');
                           } catch {
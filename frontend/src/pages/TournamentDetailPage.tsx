@@ -43,6 +43,7 @@ export function TournamentDetailPage() {
   const [deleteDialogOpen, setDeleteDialogOpen] = useState(false);
   const [editName, setEditName] = useState('');
   const [editDate, setEditDate] = useState('');
+  const [editTime, setEditTime] = useState(''); // HH:MM in tournament timezone, empty - all day
   const [editLocation, setEditLocation] = useState('');
   const [resultToDelete, setResultToDelete] = useState<{ id: number; teamName: string; version: number } | null>(null);
 
@@ -51,6 +52,8 @@ export function TournamentDetailPage() {
   const handleOpenEdit = () => {
     setEditName(tournament?.name || '');
     setEditDate(tournament?.date || '');
+    // starts_at is RFC3339 in the tournament timezone: the clock is as is
+    setEditTime(tournament && !tournament.all_day ? tournament.starts_at.slice(11, 16) : '');
     setEditLocation(tournament?.location || '');
     setEditDialogOpen(true);
   };
@@ -61,7 +64,11 @@ export function TournamentDetailPage() {
       await updateTournament.mutateAsync({
         id: tournament.id,
         name: editName.trim(),
-        date: editDate,
+        schedule: {
+          starts_at: editTime ? `${editDate}T${editTime}` : editDate,
+          duration_minutes: tournament.duration_minutes,
+          timezone: tournament.timezone,
+        },
         location: editLocation.trim(),
         version: tournament.version,
       });
@@ -233,6 +240,15 @@ export function TournamentDetailPage() {
                 onChange={(e) => setEditDate(e.target.value)}
               />
             </div>
+            <div className="space-y-2">
+              <Label htmlFor="editTournamentTime">Время начала</Label>
+              <Input
+                id="editTournamentTime"
+                type="time"
+                value={editTime}
+                onChange={(e) => setEditTime(e.target.value)}
+              />
+            </div>
             <div className="space-y-2">
               <Label htmlFor="editTournamentLocation">Место проведения</Label>
               <Input
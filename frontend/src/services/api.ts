@@ -1,66 +1,37 @@
 // API client for Amber Bot Mini App
 
+import type {
+  DeletedResponse,
+  ListMeta,
+  MeResponse,
+  MemberResponse,
+  RatingResponse,
+  ResultResponse,
+  SessionResponse,
+  TeamResponse,
+  TeamResultResponse,
+  TournamentResponse,
+  TournamentResultResponse,
+  UserResponse,
+} from './schema.gen';
+
 const API_BASE = import.meta.env.VITE_API_URL || '/api/v1';
 
+// Response types are generated from the API DTOs: go run ./cmd/openapi
 interface ListResponse<T> {
   items: T[];
-  meta: {
-    limit: number;
-    offset: number;
-    total: number;
-  };
-}
-
-interface Team {
-  id: number;
-  name: string;
-  created_at: string;
-  version: number;
-}
-
-interface Member {
-  id: number;
-  name: string;
-  team_id: number;
-  joined_at: string;
-  version: number;
-}
-
-interface Tournament {
-  id: number;
-  name: string;
-  date: string;
-  location: string;
-  created_at: string;
-  version: number;
+  meta: ListMeta;
 }
 
-interface Result {
-  id: number;
-  team_id: number;
-  tournament_id: number;
-  place: number;
-  recorded_at: string;
-  version: number;
-  team_name?: string;
-  tournament_name?: string;
-  tournament_date?: string;
-}
+type Team = TeamResponse;
+type Member = MemberResponse;
+type Tournament = TournamentResponse;
+type Result = ResultResponse;
+type Rating = RatingResponse;
+type User = UserResponse;
 
-interface Rating {
-  team_id: number;
-  team_name: string;
-  top_places: number;
-  total_games: number;
-  avg_place: number;
-}
-
-interface User {
-  telegram_id: number;
-  username: string;
-  role: 'viewer' | 'organizer' | 'admin';
-  created_at: string;
-}
+// starts_at: 2006-01-02 (all-day) or 2006-01-02T15:04 in timezone
+type TournamentSchedule = Pick<Tournament, 'starts_at' | 'duration_minutes' | 'timezone'>;
 
 class ApiError extends Error {
   status: number;
@@ -79,20 +50,103 @@ function getInitData(): string {
   return tg?.initData || '';
 }
 
-async function request<T>(path: string, options: RequestInit = {}): Promise<T> {
-  const initData = getInitData();
+type Session = SessionResponse;
+
+// Session token from POST /auth/session: initData is validated once,
+// so writes keep working when the Mini App stays open for long
+let session: Session | null = null;
 
+async function authFetch(path: string, body: unknown, authorization?: string): Promise<Session | null> {
   const response = await fetch(`${API_BASE}${path}`, {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+      ...(authorization ? { 'Authorization': authorization } : {}),
+    },
+    body: JSON.stringify(body),
+  });
+  return response.ok ? response.json() : null;
+}
+
+async function getSession(): Promise<Session | null> {
+  // Refresh a minute before expiry
+  if (session && Date.parse(session.expires_at) - Date.now() > 60_000) {
+    return session;
+  }
+  if (session) {
+    session = await authFetch('/auth/refresh', { refresh_token: session.refresh_token });
+  }
+  if (!session && getInitData()) {
+    session = await authFetch('/auth/session', {}, `TMA ${getInitData()}`);
+  }
+  return session;
+}
+
+// User object from Telegram Login Widget (browser, outside Telegram)
+export interface LoginWidgetUser {
+  id: number;
+  first_name?: string;
+  last_name?: string;
+  username?: string;
+  photo_url?: string;
+  auth_date: number;
+  hash: string;
+}
+
+// Sign in from a desktop browser: pass the Login Widget onauth payload
+export async function signInWithTelegram(user: LoginWidgetUser): Promise<boolean> {
+  session = await authFetch('/auth/telegram', user);
+  return session !== null;
+}
+
+async function request<T>(path: string, options: RequestInit = {}): Promise<T> {
+  const current = await getSession();
+  // Fall back to initData if the session exchange failed
+  const authorization = current ? `Bearer ${current.access_token}` : `TMA ${getInitData()}`;
+
+  // Writes carry an Idempotency-Key: a retry after a dropped connection
+  // gets the first response back instead of repeating the write
+  const method = (options.method || 'GET').toUpperCase();
+  const idempotencyKey = method === 'GET' ? undefined : crypto.randomUUID();
+  const send = () => fetch(`${API_BASE}${path}`, {
     ...options,
     headers: {
       'Content-Type': 'application/json',
-      'Authorization': `TMA ${initData}`,
+      'Authorization': authorization,
+      ...(idempotencyKey ? { 'Idempotency-Key': idempotencyKey } : {}),
       ...options.headers,
     },
   });
 
+  const wait = () => new Promise((resolve) => setTimeout(resolve, 1000));
+
+  let response: Response;
+  try {
+    response = await send();
+  } catch (err) {
+    if (!idempotencyKey) {
+      throw err;
+    }
+    await wait();
+    response = await send();
+  }
+
+  // The first attempt may still be running: wait for its stored response
+  for (let attempt = 0; idempotencyKey && response.status === 409 && attempt < 10; attempt++) {
+    const error = await response.clone().json().catch(() => ({}));
+    if (error.error !== 'idempotency_key_in_use') {
+      break;
+    }
+    await wait();
+    response = await send();
+  }
+
   if (!response.ok) {
     const error = await response.json().catch(() => ({ error: 'unknown_error' }));
+    // Role changed or session revoked: start over on the next request
+    if (response.status === 401) {
+      session = null;
+    }
     throw new ApiError(response.status, error.error || 'unknown_error');
   }
 
@@ -102,21 +156,34 @@ async function request<T>(path: string, options: RequestInit = {}): Promise<T> {
 // Public API
 export const api = {
   // User
-  getMe: () => request<User>('/public/me'),
+  getMe: () => request<MeResponse>('/public/me'),
 
   // Teams
   getTeams: () => request<ListResponse<Team>>('/public/teams'),
   getTeam: (id: number) => request<Team>(`/public/teams/${id}`),
   getTeamMembers: (id: number) => request<ListResponse<Member>>(`/public/teams/${id}/members`),
-  getTeamResults: (id: number) => request<ListResponse<Result>>(`/public/teams/${id}/results`),
+  getTeamResults: (id: number) => request<ListResponse<TeamResultResponse>>(`/public/teams/${id}/results`),
 
   // Tournaments
   getTournaments: () => request<ListResponse<Tournament>>('/public/tournaments'),
   getTournament: (id: number) => request<Tournament>(`/public/tournaments/${id}`),
-  getTournamentResults: (id: number) => request<ListResponse<Result>>(`/public/tournaments/${id}/results`),
-
-  // Rating
-  getRating: () => request<ListResponse<Rating>>('/public/rating'),
+  getTournamentResults: (id: number) => request<ListResponse<TournamentResultResponse>>(`/public/tournaments/${id}/results`),
+  // Standings changes as Server-Sent Events. EventSource can't send headers,
+  // so the session token goes in the URL; it expires within an hour, so on
+  // an error close the source and call this again for a fresh token.
+  liveTournament: async (id: number): Promise<EventSource> => {
+    const current = await getSession();
+    if (!current) {
+      throw new ApiError(401, 'missing_authorization');
+    }
+    const token = encodeURIComponent(current.access_token);
+    return new EventSource(`${API_BASE}/public/tournaments/${id}/live?access_token=${token}`);
+  },
+
+  // Rating of a discipline; by default the default one, 'all' mixes every discipline
+  getRating: (disciplineId?: number | 'all') => request<ListResponse<Rating>>(
+    disciplineId === undefined ? '/public/rating' : `/public/rating?discipline_id=${disciplineId}`,
+  ),
 
   // Private - Teams
   createTeam: (name: string) => request<Team>('/private/teams', {
@@ -127,7 +194,7 @@ export const api = {
     method: 'PATCH',
     body: JSON.stringify({ name, version }),
   }),
-  deleteTeam: (id: number, version: number) => request<{ deleted: boolean }>(`/private/teams/${id}`, {
+  deleteTeam: (id: number, version: number) => request<DeletedResponse>(`/private/teams/${id}`, {
     method: 'DELETE',
     body: JSON.stringify({ version }),
   }),
@@ -141,7 +208,7 @@ export const api = {
     method: 'PATCH',
     body: JSON.stringify({ name, version }),
   }),
-  deleteMember: (teamId: number, memberId: number, version: number) => request<{ deleted: boolean }>(`/private/teams/${teamId}/members/${memberId}`, {
+  deleteMember: (teamId: number, memberId: number, version: number) => request<DeletedResponse>(`/private/teams/${teamId}/members/${memberId}`, {
     method: 'DELETE',
     body: JSON.stringify({ version }),
   }),
@@ -151,11 +218,12 @@ export const api = {
     method: 'POST',
     body: JSON.stringify({ name, date, location }),
   }),
-  updateTournament: (id: number, name: string, date: string, location: string, version: number) => request<Tournament>(`/private/tournaments/${id}`, {
+  // Schedule fields are sent as is: omitted ones keep current values
+  updateTournament: (id: number, name: string, schedule: TournamentSchedule, location: string, version: number) => request<Tournament>(`/private/tournaments/${id}`, {
     method: 'PATCH',
-    body: JSON.stringify({ name, date, location, version }),
+    body: JSON.stringify({ name, ...schedule, location, version }),
   }),
-  deleteTournament: (id: number, version: number) => request<{ deleted: boolean }>(`/private/tournaments/${id}`, {
+  deleteTournament: (id: number, version: number) => request<DeletedResponse>(`/private/tournaments/${id}`, {
     method: 'DELETE',
     body: JSON.stringify({ version }),
   }),
@@ -169,18 +237,19 @@ export const api = {
     method: 'PATCH',
     body: JSON.stringify({ place, version }),
   }),
-  deleteResult: (tournamentId: number, resultId: number, version: number) => request<{ deleted: boolean }>(`/private/tournaments/${tournamentId}/results/${resultId}`, {
+  deleteResult: (tournamentId: number, resultId: number, version: number) => request<DeletedResponse>(`/private/tournaments/${tournamentId}/results/${resultId}`, {
     method: 'DELETE',
     body: JSON.stringify({ version }),
   }),
 
   // Admin - Users
   getUsers: () => request<ListResponse<User>>('/private/users'),
-  updateUserRole: (telegramId: number, role: string) => request<User>(`/private/users/${telegramId}/role`, {
+  // confirm is required to promote to admin
+  updateUserRole: (telegramId: number, role: string, confirm = false) => request<User>(`/private/users/${telegramId}/role`, {
     method: 'PUT',
-    body: JSON.stringify({ role }),
+    body: JSON.stringify({ role, confirm }),
   }),
 };
 
-export type { Team, Member, Tournament, Result, Rating, User, ListResponse };
+export type { Team, Member, Tournament, TournamentSchedule, Result, Rating, User, ListResponse };
 export { ApiError };
@@ -0,0 +1,730 @@
+// Code generated by cmd/openapi from the API spec. DO NOT EDIT.
+// Regenerate: go run ./cmd/openapi
+
+export interface AuditEntryResponse {
+  id: number;
+  actor_id: number;
+  action: string;
+  entity_type: string;
+  entity_id: number;
+  details: Record<string, unknown>;
+  created_at: string;
+}
+
+export interface AuditEntryResponseList {
+  items: AuditEntryResponse[];
+  meta: ListMeta;
+}
+
+export interface BadgeResponse {
+  code: string;
+  team_id: number;
+  result_id: number | null;
+  awarded_at: string;
+  title?: string;
+  emoji?: string;
+}
+
+export interface BadgeResponseList {
+  items: BadgeResponse[];
+  meta: ListMeta;
+}
+
+export interface BlockUserRequest {
+  reason: string;
+  until?: string | null;
+}
+
+export interface CalendarLinksResponse {
+  url: string;
+  team_url?: string;
+}
+
+export interface CreateInviteRequest {
+  role: string;
+  max_uses?: number;
+  expires_at?: string | null;
+  confirm?: boolean;
+}
+
+export interface CreateMemberRequest {
+  name: string;
+}
+
+export interface CreateResultRequest {
+  team_id?: number;
+  player_id?: number;
+  place: number;
+}
+
+export interface CreateTeamRequest {
+  name: string;
+}
+
+export interface CreateTokenRequest {
+  name: string;
+  scopes: string[];
+  expires_at?: string | null;
+}
+
+export interface CreateTournamentRequest {
+  name: string;
+  date?: string;
+  starts_at?: string;
+  duration_minutes?: number;
+  timezone?: string;
+  location?: string;
+  venue_id?: number | null;
+  discipline_id?: number;
+  participant_mode?: 'team' | 'individual';
+}
+
+export interface CreateWebhookRequest {
+  url: string;
+  events: string[];
+}
+
+export interface CreatedInviteResponse {
+  id: number;
+  prefix: string;
+  role: string;
+  max_uses: number;
+  uses: number;
+  active: boolean;
+  expires_at: string;
+  revoked_at: string | null;
+  created_by: number;
+  created_at: string;
+  token: string;
+  link?: string;
+}
+
+export interface CreatedTokenResponse {
+  id: number;
+  name: string;
+  prefix: string;
+  scopes: string[];
+  expires_at: string | null;
+  last_used_at: string | null;
+  revoked_at: string | null;
+  created_by: number;
+  created_at: string;
+  token: string;
+}
+
+export interface CreatedWebhookResponse {
+  id: number;
+  url: string;
+  events: string[];
+  active: boolean;
+  created_at: string;
+  version: number;
+  secret: string;
+}
+
+export interface DeleteRequest {
+  version: number;
+}
+
+export interface DeletedResponse {
+  deleted: boolean;
+}
+
+export interface DisciplineRequest {
+  name: string;
+}
+
+export interface DisciplineResponse {
+  id: number;
+  name: string;
+  version: number;
+}
+
+export interface DisciplineResponseList {
+  items: DisciplineResponse[];
+  meta: ListMeta;
+}
+
+export interface ErrorResponse {
+  error: string;
+  details?: string;
+  current_version?: number;
+  permission?: string;
+  reason?: string;
+  blocked_until?: string;
+  retry_after?: number;
+}
+
+export interface EvaluateBadgesResponse {
+  awarded: number;
+}
+
+export interface HeadToHeadGameResponse {
+  tournament_id: number;
+  tournament_name: string;
+  tournament_date: string;
+  place: number;
+  other_place: number;
+  winner_team_id?: number;
+}
+
+export interface HeadToHeadResponse {
+  team: TeamRef;
+  other: TeamRef;
+  games_count: number;
+  wins: number;
+  losses: number;
+  draws: number;
+  avg_place_diff: number;
+  games: HeadToHeadGameResponse[];
+}
+
+export interface InviteResponse {
+  id: number;
+  prefix: string;
+  role: string;
+  max_uses: number;
+  uses: number;
+  active: boolean;
+  expires_at: string;
+  revoked_at: string | null;
+  created_by: number;
+  created_at: string;
+}
+
+export interface InviteResponseList {
+  items: InviteResponse[];
+  meta: ListMeta;
+}
+
+export interface ListMeta {
+  limit: number;
+  offset: number;
+  total: number;
+}
+
+export interface LoginWidgetData {
+  id: number;
+  first_name?: string;
+  last_name?: string;
+  username?: string;
+  photo_url?: string;
+  auth_date: number;
+  hash: string;
+}
+
+export interface MeResponse {
+  telegram_id: number;
+  username: string;
+  role: string;
+  permissions: string[];
+  created_at: string;
+}
+
+export interface MemberResponse {
+  id: number;
+  name: string;
+  team_id: number;
+  joined_at: string;
+  version: number;
+}
+
+export interface MemberResponseList {
+  items: MemberResponse[];
+  meta: ListMeta;
+}
+
+export interface MergeConflict {
+  tournament_id: number;
+  source_result_id: number;
+  target_result_id: number;
+  source_place: number;
+  target_place: number;
+}
+
+export interface MergeTeamRequest {
+  target_team_id: number;
+}
+
+export interface MergeVenueRequest {
+  target_venue_id: number;
+}
+
+export interface MonthlyPlaceResponse {
+  month: string;
+  games: number;
+  avg_place: number;
+}
+
+export interface OpponentResponse {
+  team_id: number;
+  team_name: string;
+  games: number;
+}
+
+export interface PermissionsResponse {
+  permissions: string[];
+}
+
+export interface PlayerRatingResponse {
+  player_id: number;
+  player_name: string;
+  top_places: number;
+  total_games: number;
+  avg_place: number;
+}
+
+export interface PlayerRatingResponseList {
+  items: PlayerRatingResponse[];
+  meta: ListMeta;
+}
+
+export interface PlayerRequest {
+  name: string;
+  member_id?: number | null;
+  telegram_id?: number | null;
+}
+
+export interface PlayerResponse {
+  id: number;
+  name: string;
+  member_id: number | null;
+  telegram_id: number | null;
+  created_at: string;
+  version: number;
+}
+
+export interface PlayerResponseList {
+  items: PlayerResponse[];
+  meta: ListMeta;
+}
+
+export interface PlayerResultResponse {
+  id: number;
+  player_id: number;
+  tournament_id: number;
+  place: number;
+  recorded_at: string;
+  version: number;
+  tournament_name?: string;
+  tournament_date?: string;
+}
+
+export interface PlayerResultResponseList {
+  items: PlayerResultResponse[];
+  meta: ListMeta;
+}
+
+export interface PodiumsResponse {
+  first: number;
+  second: number;
+  third: number;
+}
+
+export interface RatingResponse {
+  team_id: number;
+  team_name: string;
+  top_places: number;
+  total_games: number;
+  avg_place: number;
+}
+
+export interface RatingResponseList {
+  items: RatingResponse[];
+  meta: ListMeta;
+}
+
+export interface RefreshSessionRequest {
+  refresh_token: string;
+}
+
+export interface RegisterTeamRequest {
+  team_id: number;
+}
+
+export interface RegistrationResponse {
+  tournament_id: number;
+  team_id: number;
+  team_name?: string;
+  registered_at?: string;
+}
+
+export interface RegistrationResponseList {
+  items: RegistrationResponse[];
+  meta: ListMeta;
+}
+
+export interface ResultResponse {
+  id: number;
+  tournament_id: number;
+  team_id: number;
+  player_id: number;
+  place: number;
+  recorded_at: string;
+  version: number;
+}
+
+export interface RevokedResponse {
+  revoked: boolean;
+}
+
+export interface RoleRequest {
+  name: string;
+  description?: string;
+  permissions: string[];
+}
+
+export interface RoleResponse {
+  id: number;
+  name: string;
+  description: string;
+  permissions: string[];
+  builtin: boolean;
+  version: number;
+  created_at: string;
+}
+
+export interface RoleResponseList {
+  items: RoleResponse[];
+  meta: ListMeta;
+}
+
+export interface SessionResponse {
+  access_token: string;
+  refresh_token: string;
+  token_type: 'Bearer';
+  expires_at: string;
+}
+
+export interface SetTournamentStatusRequest {
+  status: 'planned' | 'in_progress' | 'finalized';
+  version: number;
+}
+
+export interface TeamDetailsResponse {
+  id: number;
+  name: string;
+  created_at: string;
+  created_by: number;
+  updated_at?: string;
+  version: number;
+  badges: BadgeResponse[];
+}
+
+export interface TeamMergeResponse {
+  id: number;
+  source_team_id: number;
+  target_team_id: number;
+  source_name: string;
+  moved_member_ids: number[];
+  moved_result_ids: number[];
+  conflicts: MergeConflict[];
+  merged_by: number;
+  merged_at: string;
+  reverted_at?: string;
+  reverted_by?: number;
+}
+
+export interface TeamNameResponse {
+  name: string;
+  valid_from?: string;
+  valid_to?: string;
+}
+
+export interface TeamNameResponseList {
+  items: TeamNameResponse[];
+  meta: ListMeta;
+}
+
+export interface TeamRef {
+  id: number;
+  name: string;
+}
+
+export interface TeamResponse {
+  id: number;
+  name: string;
+  created_at: string;
+  created_by: number;
+  updated_at?: string;
+  version: number;
+}
+
+export interface TeamResponseList {
+  items: TeamResponse[];
+  meta: ListMeta;
+}
+
+export interface TeamResultResponse {
+  id: number;
+  team_id: number;
+  tournament_id: number;
+  place: number;
+  recorded_at: string;
+  version: number;
+  tournament_name?: string;
+  tournament_date?: string;
+  tournament_starts_at?: string;
+  team_name_at_date?: string;
+}
+
+export interface TeamResultResponseList {
+  items: TeamResultResponse[];
+  meta: ListMeta;
+}
+
+export interface TeamStatsResponse {
+  team_id: number;
+  total_games: number;
+  podiums: PodiumsResponse;
+  best_place: number;
+  worst_place: number;
+  avg_place: number;
+  monthly_trend: MonthlyPlaceResponse[];
+  current_streak: number;
+  longest_streak: number;
+  rank: number;
+  rated_teams: number;
+  percentile: number;
+  frequent_opponents: OpponentResponse[];
+  frequent_teammates: TeammateResponse[];
+}
+
+export interface TeammateResponse {
+  member_id: number;
+  name: string;
+  games: number;
+}
+
+export interface TokenResponse {
+  id: number;
+  name: string;
+  prefix: string;
+  scopes: string[];
+  expires_at: string | null;
+  last_used_at: string | null;
+  revoked_at: string | null;
+  created_by: number;
+  created_at: string;
+}
+
+export interface TokenResponseList {
+  items: TokenResponse[];
+  meta: ListMeta;
+}
+
+export interface TournamentResponse {
+  id: number;
+  name: string;
+  date: string;
+  location: string;
+  created_at: string;
+  created_by: number;
+  version: number;
+  venue_id: number | null;
+  discipline_id: number;
+  participant_mode: 'team' | 'individual';
+  status: 'planned' | 'in_progress' | 'finalized';
+  phase: 'upcoming' | 'ongoing' | 'finished';
+  finalized_at?: string;
+  starts_at: string;
+  ends_at: string;
+  all_day: boolean;
+  duration_minutes: number;
+  timezone: string;
+}
+
+export interface TournamentResponseList {
+  items: TournamentResponse[];
+  meta: ListMeta;
+}
+
+export interface TournamentResultResponse {
+  id: number;
+  team_id: number;
+  tournament_id: number;
+  place: number;
+  recorded_at: string;
+  version: number;
+  team_name?: string;
+  current_team_name?: string;
+  player_id?: number;
+  player_name?: string;
+}
+
+export interface TournamentResultResponseList {
+  items: TournamentResultResponse[];
+  meta: ListMeta;
+}
+
+export interface UpdateDisciplineRequest {
+  name: string;
+  version: number;
+}
+
+export interface UpdateMemberRequest {
+  name: string;
+  version: number;
+}
+
+export interface UpdatePlayerRequest {
+  name: string;
+  member_id?: number | null;
+  telegram_id?: number | null;
+  version: number;
+}
+
+export interface UpdateResultRequest {
+  place: number;
+  version: number;
+}
+
+export interface UpdateRoleRequest {
+  name: string;
+  description?: string;
+  permissions: string[];
+  version: number;
+}
+
+export interface UpdateTeamRequest {
+  name: string;
+  effective_from?: string;
+  version: number;
+}
+
+export interface UpdateTournamentRequest {
+  name: string;
+  date?: string;
+  starts_at?: string;
+  duration_minutes?: number | null;
+  timezone?: string;
+  location?: string;
+  venue_id?: number | null;
+  discipline_id?: number;
+  participant_mode?: 'team' | 'individual';
+  version: number;
+}
+
+export interface UpdateUserRoleRequest {
+  role: string;
+  confirm?: boolean;
+}
+
+export interface UpdateVenueRequest {
+  name: string;
+  address?: string;
+  latitude?: number | null;
+  longitude?: number | null;
+  notes?: string;
+  version: number;
+}
+
+export interface UpdateWebhookRequest {
+  url: string;
+  events: string[];
+  active: boolean;
+  version: number;
+}
+
+export interface UserActivityResponse {
+  results_recorded: number;
+  teams_created: number;
+  members_created: number;
+  tournaments_created: number;
+  venues_created: number;
+  players_created: number;
+}
+
+export interface UserDetailsResponse {
+  telegram_id: number;
+  username: string;
+  first_name: string;
+  last_name: string;
+  role: string;
+  blocked: boolean;
+  last_seen_at: string | null;
+  created_at: string;
+  blocked_reason?: string;
+  blocked_until?: string;
+  blocked_by?: number;
+  activity: UserActivityResponse;
+}
+
+export interface UserResponse {
+  telegram_id: number;
+  username: string;
+  first_name: string;
+  last_name: string;
+  role: string;
+  blocked: boolean;
+  last_seen_at: string | null;
+  created_at: string;
+  blocked_reason?: string;
+  blocked_until?: string;
+  blocked_by?: number;
+}
+
+export interface UserResponseList {
+  items: UserResponse[];
+  meta: ListMeta;
+}
+
+export interface VenueRequest {
+  name: string;
+  address?: string;
+  latitude?: number | null;
+  longitude?: number | null;
+  notes?: string;
+}
+
+export interface VenueResponse {
+  id: number;
+  name: string;
+  address: string;
+  latitude: number | null;
+  longitude: number | null;
+  notes: string;
+  created_at: string;
+  version: number;
+}
+
+export interface VenueResponseList {
+  items: VenueResponse[];
+  meta: ListMeta;
+}
+
+export interface VenueStatsResponse {
+  venue_id: number;
+  tournaments_hosted: number;
+  avg_attendance: number;
+  last_tournament?: string;
+}
+
+export interface WebhookDeliveryResponse {
+  id: number;
+  job_id: number;
+  attempt: number;
+  status_code: number | null;
+  error: string | null;
+  duration_ms: number;
+  created_at: string;
+}
+
+export interface WebhookDeliveryResponseList {
+  items: WebhookDeliveryResponse[];
+  meta: ListMeta;
+}
+
+export interface WebhookResponse {
+  id: number;
+  url: string;
+  events: string[];
+  active: boolean;
+  created_at: string;
+  version: number;
+}
+
+export interface WebhookResponseList {
+  items: WebhookResponse[];
+  meta: ListMeta;
+}
@@ -0,0 +1,78 @@
+// internal/achievements/engine.go
+package achievements
+
+import (
+	"context"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+// Engine evaluates rules against team history and awards badges.
+// Badges are never taken back, even if a later edit breaks the rule.
+type Engine struct {
+	badgeRepo repository.BadgeRepository
+}
+
+func NewEngine(badgeRepo repository.BadgeRepository) *Engine {
+	return &Engine{badgeRepo: badgeRepo}
+}
+
+// EvaluateTeam checks all rules for a team and returns newly awarded badges
+func (e *Engine) EvaluateTeam(ctx context.Context, teamID int64) ([]*domain.Badge, error) {
+	return e.evaluateTeam(ctx, teamID, nil)
+}
+
+// evaluateTeam awards badges with announcedAt set (nil - to be announced)
+func (e *Engine) evaluateTeam(ctx context.Context, teamID int64, announcedAt *time.Time) ([]*domain.Badge, error) {
+	history, err := e.badgeRepo.GetTeamHistory(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	var awarded []*domain.Badge
+	for _, rule := range Rules {
+		idx, ok := rule.Match(history)
+		if !ok {
+			continue
+		}
+
+		resultID := history[idx].ResultID
+		badge := &domain.Badge{
+			TeamID:      teamID,
+			Code:        rule.Code,
+			ResultID:    &resultID,
+			AnnouncedAt: announcedAt,
+		}
+		isNew, err := e.badgeRepo.Award(ctx, badge)
+		if err != nil {
+			return awarded, err
+		}
+		if isNew {
+			awarded = append(awarded, badge)
+		}
+	}
+	return awarded, nil
+}
+
+// EvaluateAll re-evaluates every team with results (e.g. after adding a rule)
+// and returns the number of newly awarded badges. Badges earned in the past
+// are stored as announced, so the backfill doesn't flood the chat.
+func (e *Engine) EvaluateAll(ctx context.Context) (int, error) {
+	teamIDs, err := e.badgeRepo.TeamIDsWithResults(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	total := 0
+	for _, id := range teamIDs {
+		awarded, err := e.evaluateTeam(ctx, id, &now)
+		total += len(awarded)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
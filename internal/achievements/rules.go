@@ -0,0 +1,99 @@
+// internal/achievements/rules.go
+package achievements
+
+import (
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+// Rule awards a badge when Match finds the result that earned it.
+// Match gets team history oldest first and returns the index of that result.
+type Rule struct {
+	Code  string
+	Title string
+	Emoji string
+	Match func(history []repository.TeamHistoryEntry) (int, bool)
+}
+
+// Rules is the list of all achievements
+var Rules = []Rule{
+	{
+		Code:  domain.BadgeFirstWin,
+		Title: "Первая победа",
+		Emoji: "🥇",
+		Match: firstWhere(func(e repository.TeamHistoryEntry) bool { return e.Place == 1 }),
+	},
+	{
+		Code:  domain.BadgePodiumStreak5,
+		Title: "5 призовых мест подряд",
+		Emoji: "🔥",
+		Match: podiumStreak(5),
+	},
+	{
+		Code:  domain.BadgeTournaments10,
+		Title: "10 турниров",
+		Emoji: "🎯",
+		Match: played(10),
+	},
+	{
+		Code:  domain.BadgeTournaments50,
+		Title: "50 турниров",
+		Emoji: "🏛",
+		Match: played(50),
+	},
+	{
+		Code:  domain.BadgeBeatLeader,
+		Title: "Обыграли лидера рейтинга",
+		Emoji: "⚔️",
+		Match: firstWhere(func(e repository.TeamHistoryEntry) bool {
+			return e.LeaderPlace != nil && e.Place < *e.LeaderPlace
+		}),
+	},
+}
+
+// Find returns rule by badge code
+func Find(code string) (Rule, bool) {
+	for _, r := range Rules {
+		if r.Code == code {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+func firstWhere(pred func(repository.TeamHistoryEntry) bool) func([]repository.TeamHistoryEntry) (int, bool) {
+	return func(history []repository.TeamHistoryEntry) (int, bool) {
+		for i, e := range history {
+			if pred(e) {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+}
+
+func podiumStreak(n int) func([]repository.TeamHistoryEntry) (int, bool) {
+	return func(history []repository.TeamHistoryEntry) (int, bool) {
+		run := 0
+		for i, e := range history {
+			if e.Place <= 3 {
+				run++
+				if run == n {
+					return i, true
+				}
+			} else {
+				run = 0
+			}
+		}
+		return 0, false
+	}
+}
+
+func played(n int) func([]repository.TeamHistoryEntry) (int, bool) {
+	return func(history []repository.TeamHistoryEntry) (int, bool) {
+		if len(history) < n {
+			return 0, false
+		}
+		return n - 1, true
+	}
+}
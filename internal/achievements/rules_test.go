@@ -0,0 +1,70 @@
+// internal/achievements/rules_test.go
+package achievements
+
+import (
+	"testing"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+func places(p ...int) []repository.TeamHistoryEntry {
+	history := make([]repository.TeamHistoryEntry, len(p))
+	for i, place := range p {
+		history[i] = repository.TeamHistoryEntry{ResultID: int64(i + 1), Place: place}
+	}
+	return history
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		history []repository.TeamHistoryEntry
+		wantIdx int
+		wantOK  bool
+	}{
+		{"no games", domain.BadgeFirstWin, nil, 0, false},
+		{"first win", domain.BadgeFirstWin, places(4, 2, 1, 1), 2, true},
+		{"no win", domain.BadgeFirstWin, places(2, 3, 5), 0, false},
+		{"podium streak", domain.BadgePodiumStreak5, places(1, 2, 3, 1, 2), 4, true},
+		{"podium streak broken", domain.BadgePodiumStreak5, places(1, 2, 4, 1, 2, 3, 3), 0, false},
+		{"podium streak after break", domain.BadgePodiumStreak5, places(5, 1, 1, 1, 1, 1), 5, true},
+		{"played 10", domain.BadgeTournaments10, places(5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5), 9, true},
+		{"played 9", domain.BadgeTournaments10, places(5, 5, 5, 5, 5, 5, 5, 5, 5), 0, false},
+		{
+			"beat leader",
+			domain.BadgeBeatLeader,
+			[]repository.TeamHistoryEntry{
+				{Place: 3, LeaderPlace: intPtr(1)},
+				{Place: 5},
+				{Place: 2, LeaderPlace: intPtr(4)},
+			},
+			2, true,
+		},
+		{
+			"never beat leader",
+			domain.BadgeBeatLeader,
+			[]repository.TeamHistoryEntry{{Place: 1}, {Place: 2, LeaderPlace: intPtr(1)}},
+			0, false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := Find(tt.code)
+			if !ok {
+				t.Fatalf("rule %s not found", tt.code)
+			}
+			idx, ok := rule.Match(tt.history)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok=%v, got %v", tt.wantOK, ok)
+			}
+			if ok && idx != tt.wantIdx {
+				t.Errorf("expected index %d, got %d", tt.wantIdx, idx)
+			}
+		})
+	}
+}
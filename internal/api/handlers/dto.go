@@ -0,0 +1,436 @@
+// internal/api/handlers/dto.go
+package handlers
+
+import (
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+)
+
+// Response bodies of the handlers. Formatted times are strings; the format
+// and enum tags are picked up by the OpenAPI spec (internal/api/openapi.go).
+
+// ErrorResponse is the body of every error; fields besides error depend on the code
+type ErrorResponse struct {
+	Error          string `json:"error"`
+	Details        string `json:"details,omitempty"`         // validation_error
+	CurrentVersion int    `json:"current_version,omitempty"` // version_conflict
+	Permission     string `json:"permission,omitempty"`      // unknown_permission
+	Reason         string `json:"reason,omitempty"`          // user_blocked
+	BlockedUntil   string `json:"blocked_until,omitempty" format:"date-time"`
+	RetryAfter     int    `json:"retry_after,omitempty"` // rate_limit_exceeded, seconds
+}
+
+type DeletedResponse struct {
+	Deleted bool `json:"deleted"`
+}
+
+type RevokedResponse struct {
+	Revoked bool `json:"revoked"`
+}
+
+// === AUTH ===
+
+type SessionResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type" enum:"Bearer"`
+	ExpiresAt    string `json:"expires_at" format:"date-time"`
+}
+
+type MeResponse struct {
+	TelegramID  int64               `json:"telegram_id"`
+	Username    string              `json:"username"`
+	Role        domain.Role         `json:"role"`
+	Permissions []domain.Permission `json:"permissions"`
+	CreatedAt   string              `json:"created_at" format:"date-time"`
+}
+
+// === TEAMS ===
+
+type TeamResponse struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at" format:"date-time"`
+	CreatedBy int64  `json:"created_by"`
+	UpdatedAt string `json:"updated_at,omitempty" format:"date-time"`
+	Version   int    `json:"version"`
+}
+
+type TeamDetailsResponse struct {
+	TeamResponse
+	Badges []BadgeResponse `json:"badges"`
+}
+
+type TeamNameResponse struct {
+	Name      string `json:"name"`
+	ValidFrom string `json:"valid_from,omitempty" format:"date-time"`
+	ValidTo   string `json:"valid_to,omitempty" format:"date-time"`
+}
+
+type MemberResponse struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	TeamID   int64  `json:"team_id"`
+	JoinedAt string `json:"joined_at" format:"date-time"`
+	Version  int    `json:"version"`
+}
+
+type BadgeResponse struct {
+	Code      string `json:"code"`
+	TeamID    int64  `json:"team_id"`
+	ResultID  *int64 `json:"result_id"`
+	AwardedAt string `json:"awarded_at" format:"date-time"`
+	Title     string `json:"title,omitempty"`
+	Emoji     string `json:"emoji,omitempty"`
+}
+
+// TeamResultResponse is a result in the team's history
+type TeamResultResponse struct {
+	ID                 int64  `json:"id"`
+	TeamID             int64  `json:"team_id"`
+	TournamentID       int64  `json:"tournament_id"`
+	Place              int    `json:"place"`
+	RecordedAt         string `json:"recorded_at" format:"date-time"`
+	Version            int    `json:"version"`
+	TournamentName     string `json:"tournament_name,omitempty"`
+	TournamentDate     string `json:"tournament_date,omitempty" format:"date"`
+	TournamentStartsAt string `json:"tournament_starts_at,omitempty" format:"date-time"`
+	TeamNameAtDate     string `json:"team_name_at_date,omitempty"`
+}
+
+type TeamRef struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type HeadToHeadGameResponse struct {
+	TournamentID   int64  `json:"tournament_id"`
+	TournamentName string `json:"tournament_name"`
+	TournamentDate string `json:"tournament_date" format:"date"`
+	Place          int    `json:"place"`
+	OtherPlace     int    `json:"other_place"`
+	WinnerTeamID   int64  `json:"winner_team_id,omitempty"` // absent on a draw
+}
+
+type HeadToHeadResponse struct {
+	Team         TeamRef                  `json:"team"`
+	Other        TeamRef                  `json:"other"`
+	GamesCount   int                      `json:"games_count"`
+	Wins         int                      `json:"wins"`
+	Losses       int                      `json:"losses"`
+	Draws        int                      `json:"draws"`
+	AvgPlaceDiff float64                  `json:"avg_place_diff"`
+	Games        []HeadToHeadGameResponse `json:"games"`
+}
+
+type PodiumsResponse struct {
+	First  int `json:"first"`
+	Second int `json:"second"`
+	Third  int `json:"third"`
+}
+
+type MonthlyPlaceResponse struct {
+	Month    string  `json:"month"` // 2006-01
+	Games    int     `json:"games"`
+	AvgPlace float64 `json:"avg_place"`
+}
+
+type OpponentResponse struct {
+	TeamID   int64  `json:"team_id"`
+	TeamName string `json:"team_name"`
+	Games    int    `json:"games"`
+}
+
+type TeammateResponse struct {
+	MemberID int64  `json:"member_id"`
+	Name     string `json:"name"`
+	Games    int    `json:"games"`
+}
+
+type TeamStatsResponse struct {
+	TeamID            int64                  `json:"team_id"`
+	TotalGames        int                    `json:"total_games"`
+	Podiums           PodiumsResponse        `json:"podiums"`
+	BestPlace         int                    `json:"best_place"`
+	WorstPlace        int                    `json:"worst_place"`
+	AvgPlace          float64                `json:"avg_place"`
+	MonthlyTrend      []MonthlyPlaceResponse `json:"monthly_trend"`
+	CurrentStreak     int                    `json:"current_streak"`
+	LongestStreak     int                    `json:"longest_streak"`
+	Rank              int                    `json:"rank"`
+	RatedTeams        int                    `json:"rated_teams"`
+	Percentile        float64                `json:"percentile"`
+	FrequentOpponents []OpponentResponse     `json:"frequent_opponents"`
+	FrequentTeammates []TeammateResponse     `json:"frequent_teammates"`
+}
+
+type TeamMergeResponse struct {
+	ID             int64                  `json:"id"`
+	SourceTeamID   int64                  `json:"source_team_id"`
+	TargetTeamID   int64                  `json:"target_team_id"`
+	SourceName     string                 `json:"source_name"`
+	MovedMemberIDs []int64                `json:"moved_member_ids"`
+	MovedResultIDs []int64                `json:"moved_result_ids"`
+	Conflicts      []domain.MergeConflict `json:"conflicts"`
+	MergedBy       int64                  `json:"merged_by"`
+	MergedAt       string                 `json:"merged_at" format:"date-time"`
+	RevertedAt     string                 `json:"reverted_at,omitempty" format:"date-time"`
+	RevertedBy     *int64                 `json:"reverted_by,omitempty"`
+}
+
+type RatingResponse struct {
+	TeamID     int64   `json:"team_id"`
+	TeamName   string  `json:"team_name"`
+	TopPlaces  int     `json:"top_places"`
+	TotalGames int     `json:"total_games"`
+	AvgPlace   float64 `json:"avg_place"`
+}
+
+// === TOURNAMENTS ===
+
+// TournamentResponse times are RFC3339 with the tournament's offset
+type TournamentResponse struct {
+	ID              int64                   `json:"id"`
+	Name            string                  `json:"name"`
+	Date            string                  `json:"date" format:"date"`
+	Location        string                  `json:"location"`
+	CreatedAt       string                  `json:"created_at" format:"date-time"`
+	CreatedBy       int64                   `json:"created_by"`
+	Version         int                     `json:"version"`
+	VenueID         *int64                  `json:"venue_id"`
+	DisciplineID    int64                   `json:"discipline_id"`
+	ParticipantMode domain.ParticipantMode  `json:"participant_mode" enum:"team,individual"`
+	Status          domain.TournamentStatus `json:"status" enum:"planned,in_progress,finalized"`
+	Phase           string                  `json:"phase" enum:"upcoming,ongoing,finished"`
+	FinalizedAt     string                  `json:"finalized_at,omitempty" format:"date-time"`
+	StartsAt        string                  `json:"starts_at" format:"date-time"`
+	EndsAt          string                  `json:"ends_at" format:"date-time"`
+	AllDay          bool                    `json:"all_day"`
+	DurationMinutes int                     `json:"duration_minutes"`
+	Timezone        string                  `json:"timezone"`
+}
+
+// TournamentResultResponse is a line of tournament standings; team fields
+// are set in team tournaments, player fields in individual ones
+type TournamentResultResponse struct {
+	ID              int64  `json:"id"`
+	TeamID          int64  `json:"team_id"`
+	TournamentID    int64  `json:"tournament_id"`
+	Place           int    `json:"place"`
+	RecordedAt      string `json:"recorded_at" format:"date-time"`
+	Version         int    `json:"version"`
+	TeamName        string `json:"team_name,omitempty"` // name used at the tournament
+	CurrentTeamName string `json:"current_team_name,omitempty"`
+	PlayerID        int64  `json:"player_id,omitempty"`
+	PlayerName      string `json:"player_name,omitempty"`
+}
+
+// ResultResponse is a recorded or updated result
+type ResultResponse struct {
+	ID           int64  `json:"id"`
+	TournamentID int64  `json:"tournament_id"`
+	TeamID       int64  `json:"team_id"`
+	PlayerID     int64  `json:"player_id"`
+	Place        int    `json:"place"`
+	RecordedAt   string `json:"recorded_at" format:"date-time"`
+	Version      int    `json:"version"`
+}
+
+type RegistrationResponse struct {
+	TournamentID int64  `json:"tournament_id"`
+	TeamID       int64  `json:"team_id"`
+	TeamName     string `json:"team_name,omitempty"`
+	RegisteredAt string `json:"registered_at,omitempty" format:"date-time"`
+}
+
+type CalendarLinksResponse struct {
+	URL     string `json:"url" format:"uri"`
+	TeamURL string `json:"team_url,omitempty" format:"uri"`
+}
+
+// === VENUES, DISCIPLINES, PLAYERS ===
+
+type VenueResponse struct {
+	ID        int64    `json:"id"`
+	Name      string   `json:"name"`
+	Address   string   `json:"address"`
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+	Notes     string   `json:"notes"`
+	CreatedAt string   `json:"created_at" format:"date-time"`
+	Version   int      `json:"version"`
+}
+
+type VenueStatsResponse struct {
+	VenueID           int64   `json:"venue_id"`
+	TournamentsHosted int     `json:"tournaments_hosted"`
+	AvgAttendance     float64 `json:"avg_attendance"`
+	LastTournament    string  `json:"last_tournament,omitempty" format:"date"`
+}
+
+type DisciplineResponse struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+}
+
+type PlayerResponse struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	MemberID   *int64 `json:"member_id"`
+	TelegramID *int64 `json:"telegram_id"`
+	CreatedAt  string `json:"created_at" format:"date-time"`
+	Version    int    `json:"version"`
+}
+
+type PlayerResultResponse struct {
+	ID             int64  `json:"id"`
+	PlayerID       int64  `json:"player_id"`
+	TournamentID   int64  `json:"tournament_id"`
+	Place          int    `json:"place"`
+	RecordedAt     string `json:"recorded_at" format:"date-time"`
+	Version        int    `json:"version"`
+	TournamentName string `json:"tournament_name,omitempty"`
+	TournamentDate string `json:"tournament_date,omitempty" format:"date"`
+}
+
+type PlayerRatingResponse struct {
+	PlayerID   int64   `json:"player_id"`
+	PlayerName string  `json:"player_name"`
+	TopPlaces  int     `json:"top_places"`
+	TotalGames int     `json:"total_games"`
+	AvgPlace   float64 `json:"avg_place"`
+}
+
+// === USERS, INVITES, ROLES ===
+
+// UserResponse block fields are set only while the user is blocked
+type UserResponse struct {
+	TelegramID    int64       `json:"telegram_id"`
+	Username      string      `json:"username"`
+	FirstName     string      `json:"first_name"`
+	LastName      string      `json:"last_name"`
+	Role          domain.Role `json:"role"`
+	Blocked       bool        `json:"blocked"`
+	LastSeenAt    *time.Time  `json:"last_seen_at"`
+	CreatedAt     string      `json:"created_at" format:"date-time"`
+	BlockedReason string      `json:"blocked_reason,omitempty"`
+	BlockedUntil  *time.Time  `json:"blocked_until,omitempty"` // absent - until unblocked
+	BlockedBy     *int64      `json:"blocked_by,omitempty"`
+}
+
+type UserActivityResponse struct {
+	ResultsRecorded    int `json:"results_recorded"`
+	TeamsCreated       int `json:"teams_created"`
+	MembersCreated     int `json:"members_created"`
+	TournamentsCreated int `json:"tournaments_created"`
+	VenuesCreated      int `json:"venues_created"`
+	PlayersCreated     int `json:"players_created"`
+}
+
+type UserDetailsResponse struct {
+	UserResponse
+	Activity UserActivityResponse `json:"activity"`
+}
+
+type InviteResponse struct {
+	ID        int64       `json:"id"`
+	Prefix    string      `json:"prefix"`
+	Role      domain.Role `json:"role"`
+	MaxUses   int         `json:"max_uses"`
+	Uses      int         `json:"uses"`
+	Active    bool        `json:"active"`
+	ExpiresAt string      `json:"expires_at" format:"date-time"`
+	RevokedAt *time.Time  `json:"revoked_at"`
+	CreatedBy int64       `json:"created_by"`
+	CreatedAt string      `json:"created_at" format:"date-time"`
+}
+
+// CreatedInviteResponse carries the token, shown only once
+type CreatedInviteResponse struct {
+	InviteResponse
+	Token string `json:"token"`
+	Link  string `json:"link,omitempty" format:"uri"` // absent without BOT_USERNAME
+}
+
+type PermissionsResponse struct {
+	Permissions []domain.Permission `json:"permissions"`
+}
+
+type RoleResponse struct {
+	ID          int64               `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Permissions []domain.Permission `json:"permissions"`
+	Builtin     bool                `json:"builtin"`
+	Version     int                 `json:"version"`
+	CreatedAt   string              `json:"created_at" format:"date-time"`
+}
+
+// === SETTINGS ===
+
+type AuditEntryResponse struct {
+	ID         int64          `json:"id"`
+	ActorID    int64          `json:"actor_id"`
+	Action     string         `json:"action"`
+	EntityType string         `json:"entity_type"`
+	EntityID   int64          `json:"entity_id"`
+	Details    map[string]any `json:"details"`
+	CreatedAt  string         `json:"created_at" format:"date-time"`
+}
+
+type EvaluateBadgesResponse struct {
+	Awarded int `json:"awarded"`
+}
+
+type TokenResponse struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedBy  int64      `json:"created_by"`
+	CreatedAt  string     `json:"created_at" format:"date-time"`
+}
+
+// CreatedTokenResponse carries the token, shown only once
+type CreatedTokenResponse struct {
+	TokenResponse
+	Token string `json:"token"`
+}
+
+type WebhookResponse struct {
+	ID        int64    `json:"id"`
+	URL       string   `json:"url" format:"uri"`
+	Events    []string `json:"events"`
+	Active    bool     `json:"active"`
+	CreatedAt string   `json:"created_at" format:"date-time"`
+	Version   int      `json:"version"`
+}
+
+// CreatedWebhookResponse carries the signing secret, shown only once
+type CreatedWebhookResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID         int64   `json:"id"`
+	JobID      int64   `json:"job_id"`
+	Attempt    int     `json:"attempt"`
+	StatusCode *int    `json:"status_code"`
+	Error      *string `json:"error"`
+	DurationMs int64   `json:"duration_ms"`
+	CreatedAt  string  `json:"created_at" format:"date-time"`
+}
+
+// formatTime formats optional time as RFC3339, "" for nil
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -2,7 +2,13 @@
 package handlers
 
 import (
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/achievements"
+	"github.com/eugene-twix/amber-bot/internal/api/middleware"
 	"github.com/eugene-twix/amber-bot/internal/cache"
+	"github.com/eugene-twix/amber-bot/internal/calendar"
+	"github.com/eugene-twix/amber-bot/internal/live"
 	"github.com/eugene-twix/amber-bot/internal/repository"
 )
 
@@ -13,6 +19,23 @@ type Handler struct {
 	memberRepo     repository.MemberRepository
 	tournamentRepo repository.TournamentRepository
 	resultRepo     repository.ResultRepository
+	auditRepo      repository.AuditRepository
+	statsRepo      repository.StatsRepository
+	badgeRepo      repository.BadgeRepository
+	achievements   *achievements.Engine
+	webhookRepo    repository.WebhookRepository
+	venueRepo      repository.VenueRepository
+	disciplineRepo repository.DisciplineRepository
+	playerRepo     repository.PlayerRepository
+	tokenRepo      repository.APITokenRepository
+	roleRepo       repository.RoleRepository
+	inviteRepo     repository.InviteRepository
+	sessions       *middleware.Sessions
+	live           *live.Hub
+	calendar       *calendar.Feeds
+	botUsername    string         // for invite links
+	lockedAdmins   []int64        // ADMIN_IDS when ADMIN_IDS_LOCKED
+	loc            *time.Location // default tournament timezone
 	cache          *cache.Cache
 }
 
@@ -22,6 +45,22 @@ func NewHandler(
 	memberRepo repository.MemberRepository,
 	tournamentRepo repository.TournamentRepository,
 	resultRepo repository.ResultRepository,
+	auditRepo repository.AuditRepository,
+	statsRepo repository.StatsRepository,
+	badgeRepo repository.BadgeRepository,
+	webhookRepo repository.WebhookRepository,
+	venueRepo repository.VenueRepository,
+	disciplineRepo repository.DisciplineRepository,
+	playerRepo repository.PlayerRepository,
+	tokenRepo repository.APITokenRepository,
+	roleRepo repository.RoleRepository,
+	inviteRepo repository.InviteRepository,
+	sessions *middleware.Sessions,
+	liveHub *live.Hub,
+	calendarFeeds *calendar.Feeds,
+	botUsername string,
+	lockedAdmins []int64,
+	loc *time.Location,
 	cache *cache.Cache,
 ) *Handler {
 	return &Handler{
@@ -30,6 +69,23 @@ func NewHandler(
 		memberRepo:     memberRepo,
 		tournamentRepo: tournamentRepo,
 		resultRepo:     resultRepo,
+		auditRepo:      auditRepo,
+		statsRepo:      statsRepo,
+		badgeRepo:      badgeRepo,
+		achievements:   achievements.NewEngine(badgeRepo),
+		webhookRepo:    webhookRepo,
+		venueRepo:      venueRepo,
+		disciplineRepo: disciplineRepo,
+		playerRepo:     playerRepo,
+		tokenRepo:      tokenRepo,
+		roleRepo:       roleRepo,
+		inviteRepo:     inviteRepo,
+		sessions:       sessions,
+		live:           liveHub,
+		calendar:       calendarFeeds,
+		botUsername:    botUsername,
+		lockedAdmins:   lockedAdmins,
+		loc:            loc,
 		cache:          cache,
 	}
 }
@@ -47,9 +103,9 @@ type SortParams struct {
 }
 
 // ListResponse is standard response for list endpoints
-type ListResponse struct {
-	Items any       `json:"items"`
-	Meta  *ListMeta `json:"meta"`
+type ListResponse[T any] struct {
+	Items []T      `json:"items"`
+	Meta  ListMeta `json:"meta"`
 }
 
 type ListMeta struct {
@@ -58,10 +114,10 @@ type ListMeta struct {
 	Total  int `json:"total"`
 }
 
-func NewListResponse(items any, limit, offset, total int) *ListResponse {
-	return &ListResponse{
+func NewListResponse[T any](items []T, limit, offset, total int) *ListResponse[T] {
+	return &ListResponse[T]{
 		Items: items,
-		Meta: &ListMeta{
+		Meta: ListMeta{
 			Limit:  limit,
 			Offset: offset,
 			Total:  total,
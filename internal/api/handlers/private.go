@@ -2,12 +2,20 @@
 package handlers
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/eugene-twix/amber-bot/internal/api/middleware"
 	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+	"github.com/eugene-twix/amber-bot/internal/schedule"
+	"github.com/eugene-twix/amber-bot/internal/webhooks"
 	"github.com/gin-gonic/gin"
 )
 
@@ -36,17 +44,13 @@ func (h *Handler) CreateTeam(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"id":         team.ID,
-		"name":       team.Name,
-		"created_at": team.CreatedAt.Format(time.RFC3339),
-		"version":    team.Version,
-	})
+	c.JSON(http.StatusCreated, teamResponse(team))
 }
 
 type UpdateTeamRequest struct {
-	Name    string `json:"name" binding:"required,min=1,max=100"`
-	Version int    `json:"version" binding:"required,min=1"`
+	Name          string `json:"name" binding:"required,min=1,max=100"`
+	EffectiveFrom string `json:"effective_from"` // Format: 2006-01-02, default: now
+	Version       int    `json:"version" binding:"required,min=1"`
 }
 
 func (h *Handler) UpdateTeam(c *gin.Context) {
@@ -79,25 +83,31 @@ func (h *Handler) UpdateTeam(c *gin.Context) {
 	user := middleware.GetUser(c)
 	now := time.Now()
 
+	// Old name goes to name history, effective_from lets organizers backdate a rename
+	effectiveFrom := now
+	if req.EffectiveFrom != "" {
+		effectiveFrom, err = time.ParseInLocation("2006-01-02", req.EffectiveFrom, h.loc)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_date_format"})
+			return
+		}
+	}
+
 	team.Name = req.Name
 	team.UpdatedAt = &now
 	team.UpdatedBy = &user.TelegramID
 	team.Version = req.Version + 1
 
-	if err := h.teamRepo.Update(c.Request.Context(), team); err != nil {
+	if err := h.teamRepo.Rename(c.Request.Context(), team, effectiveFrom); err != nil {
+		if errors.Is(err, repository.ErrInvalidEffectiveDate) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_effective_date"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 		return
 	}
 
-	// Invalidate cache
-	h.cache.Delete(c.Request.Context(), "teams:list")
-
-	c.JSON(http.StatusOK, gin.H{
-		"id":         team.ID,
-		"name":       team.Name,
-		"updated_at": team.UpdatedAt.Format(time.RFC3339),
-		"version":    team.Version,
-	})
+	c.JSON(http.StatusOK, teamResponse(team))
 }
 
 type DeleteRequest struct {
@@ -131,16 +141,12 @@ func (h *Handler) DeleteTeam(c *gin.Context) {
 		return
 	}
 
-	if err := h.teamRepo.Delete(c.Request.Context(), id); err != nil {
+	if err := h.teamRepo.Delete(c.Request.Context(), id, middleware.GetUser(c).TelegramID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 		return
 	}
 
-	// Invalidate cache
-	h.cache.Delete(c.Request.Context(), "teams:list")
-	h.cache.Delete(c.Request.Context(), ratingCacheKey)
-
-	c.JSON(http.StatusOK, gin.H{"deleted": true})
+	c.JSON(http.StatusOK, DeletedResponse{Deleted: true})
 }
 
 // === MEMBERS ===
@@ -183,13 +189,7 @@ func (h *Handler) CreateMember(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"id":        member.ID,
-		"name":      member.Name,
-		"team_id":   member.TeamID,
-		"joined_at": member.JoinedAt.Format(time.RFC3339),
-		"version":   member.Version,
-	})
+	c.JSON(http.StatusCreated, memberResponse(member))
 }
 
 type UpdateMemberRequest struct {
@@ -230,11 +230,7 @@ func (h *Handler) UpdateMember(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"id":      member.ID,
-		"name":    member.Name,
-		"version": member.Version,
-	})
+	c.JSON(http.StatusOK, memberResponse(member))
 }
 
 func (h *Handler) DeleteMember(c *gin.Context) {
@@ -262,20 +258,132 @@ func (h *Handler) DeleteMember(c *gin.Context) {
 		return
 	}
 
-	if err := h.memberRepo.Delete(c.Request.Context(), memberID); err != nil {
+	if err := h.memberRepo.Delete(c.Request.Context(), memberID, middleware.GetUser(c).TelegramID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"deleted": true})
+	c.JSON(http.StatusOK, DeletedResponse{Deleted: true})
 }
 
 // === TOURNAMENTS ===
 
 type CreateTournamentRequest struct {
-	Name     string `json:"name" binding:"required,min=1,max=200"`
-	Date     string `json:"date" binding:"required"` // Format: 2006-01-02
-	Location string `json:"location" binding:"max=200"`
+	Name            string `json:"name" binding:"required,min=1,max=200"`
+	Date            string `json:"date"`      // Format: 2006-01-02, all-day tournament
+	StartsAt        string `json:"starts_at"` // RFC3339 or 2006-01-02T15:04 in timezone; overrides date
+	DurationMinutes int    `json:"duration_minutes" binding:"min=0,max=1440"`
+	Timezone        string `json:"timezone"` // IANA name, default: TIMEZONE
+	Location        string `json:"location" binding:"max=200"`
+	VenueID         *int64 `json:"venue_id"`                                                   // overrides location with venue name
+	DisciplineID    int64  `json:"discipline_id"`                                              // default: first discipline
+	ParticipantMode string `json:"participant_mode" binding:"omitempty,oneof=team individual"` // default: team
+}
+
+// applyDiscipline sets tournament discipline if given; returns error code or ""
+func (h *Handler) applyDiscipline(ctx context.Context, t *domain.Tournament, disciplineID int64) string {
+	if disciplineID == 0 {
+		return ""
+	}
+	if _, err := h.disciplineRepo.GetByID(ctx, disciplineID); err != nil {
+		return "discipline_not_found"
+	}
+	t.DisciplineID = disciplineID
+	return ""
+}
+
+// applyParticipantMode switches tournament between team and individual
+// results; the mode is locked once results are recorded. Returns error code or "".
+func (h *Handler) applyParticipantMode(ctx context.Context, t *domain.Tournament, mode string) string {
+	if mode == "" || domain.ParticipantMode(mode) == t.ParticipantMode {
+		if t.ParticipantMode == "" {
+			t.ParticipantMode = domain.ParticipantTeams
+		}
+		return ""
+	}
+	if t.ID != 0 {
+		results, err := h.resultRepo.GetByTournamentID(ctx, t.ID)
+		if err != nil {
+			return "internal_error"
+		}
+		if len(results) > 0 {
+			return "participant_mode_locked"
+		}
+	}
+	t.ParticipantMode = domain.ParticipantMode(mode)
+	return ""
+}
+
+// applyVenue links tournament to venue: nil keeps current venue (location
+// from request if none), 0 unlinks. Returns error code or "".
+func (h *Handler) applyVenue(ctx context.Context, t *domain.Tournament, venueID *int64, location string) string {
+	if venueID != nil && *venueID == 0 {
+		t.VenueID = nil
+	}
+	if venueID != nil && *venueID > 0 {
+		venue, err := h.venueRepo.GetByID(ctx, *venueID)
+		if err != nil {
+			return "venue_not_found"
+		}
+		t.VenueID = &venue.ID
+		t.Location = venue.Name
+		return ""
+	}
+	if t.VenueID == nil {
+		t.Location = location
+	}
+	return ""
+}
+
+// applySchedule sets tournament start, duration and timezone from request
+// fields. On update absent fields keep current values: date alone moves the
+// day and keeps the start time, nil duration keeps the duration. Returns
+// error code or "".
+func (h *Handler) applySchedule(t *domain.Tournament, date, startsAt string, durationMinutes *int, timezone string) string {
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return "invalid_timezone"
+		}
+		t.Timezone = timezone
+	}
+	if t.Timezone == "" {
+		t.Timezone = h.loc.String()
+	}
+	if durationMinutes != nil {
+		t.DurationMinutes = *durationMinutes
+	}
+
+	loc := t.Zone(h.loc)
+	switch {
+	case startsAt != "":
+		start, hasTime, err := schedule.ParseStart(startsAt, loc)
+		if err != nil {
+			return "invalid_date_format"
+		}
+		t.SetStart(start, hasTime)
+	case date != "":
+		start, hasTime, err := schedule.ParseStart(date, loc)
+		if err != nil {
+			return "invalid_date_format"
+		}
+		if !hasTime && t.StartsAt != nil {
+			clock := t.StartsAt.In(loc)
+			start = time.Date(start.Year(), start.Month(), start.Day(),
+				clock.Hour(), clock.Minute(), 0, 0, loc)
+			hasTime = true
+		}
+		t.SetStart(start, hasTime)
+	case t.ID == 0:
+		return "date_required"
+	}
+	return ""
+}
+
+func participantModeStatus(code string) int {
+	if code == "participant_mode_locked" {
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
 }
 
 func (h *Handler) CreateTournament(c *gin.Context) {
@@ -285,41 +393,48 @@ func (h *Handler) CreateTournament(c *gin.Context) {
 		return
 	}
 
-	date, err := time.Parse("2006-01-02", req.Date)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_date_format"})
-		return
-	}
-
 	user := middleware.GetUser(c)
 
 	tournament := &domain.Tournament{
 		Name:      req.Name,
-		Date:      date,
-		Location:  req.Location,
 		CreatedBy: user.TelegramID,
 	}
+	if code := h.applySchedule(tournament, req.Date, req.StartsAt, &req.DurationMinutes, req.Timezone); code != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": code})
+		return
+	}
+	if code := h.applyVenue(c.Request.Context(), tournament, req.VenueID, req.Location); code != "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": code})
+		return
+	}
+	if code := h.applyDiscipline(c.Request.Context(), tournament, req.DisciplineID); code != "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": code})
+		return
+	}
+	if code := h.applyParticipantMode(c.Request.Context(), tournament, req.ParticipantMode); code != "" {
+		c.JSON(participantModeStatus(code), gin.H{"error": code})
+		return
+	}
 
 	if err := h.tournamentRepo.Create(c.Request.Context(), tournament); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"id":         tournament.ID,
-		"name":       tournament.Name,
-		"date":       tournament.Date.Format("2006-01-02"),
-		"location":   tournament.Location,
-		"created_at": tournament.CreatedAt.Format(time.RFC3339),
-		"version":    tournament.Version,
-	})
+	c.JSON(http.StatusCreated, h.tournamentResponse(tournament))
 }
 
 type UpdateTournamentRequest struct {
-	Name     string `json:"name" binding:"required,min=1,max=200"`
-	Date     string `json:"date" binding:"required"`
-	Location string `json:"location" binding:"max=200"`
-	Version  int    `json:"version" binding:"required,min=1"`
+	Name            string `json:"name" binding:"required,min=1,max=200"`
+	Date            string `json:"date"`                                                // default: keep current; keeps start time if set
+	StartsAt        string `json:"starts_at"`                                           // default: keep current
+	DurationMinutes *int   `json:"duration_minutes" binding:"omitempty,min=0,max=1440"` // default: keep current
+	Timezone        string `json:"timezone"`                                            // default: keep current
+	Location        string `json:"location" binding:"max=200"`
+	VenueID         *int64 `json:"venue_id"`                                                   // default: keep current, 0 - unlink
+	DisciplineID    int64  `json:"discipline_id"`                                              // default: keep current
+	ParticipantMode string `json:"participant_mode" binding:"omitempty,oneof=team individual"` // default: keep current
+	Version         int    `json:"version" binding:"required,min=1"`
 }
 
 func (h *Handler) UpdateTournament(c *gin.Context) {
@@ -347,18 +462,33 @@ func (h *Handler) UpdateTournament(c *gin.Context) {
 		return
 	}
 
-	date, err := time.Parse("2006-01-02", req.Date)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_date_format"})
+	user := middleware.GetUser(c)
+	if err := tournament.CheckEditable(user.IsAdmin()); err != nil {
+		tournamentStateError(c, err)
+		return
+	}
+
+	if code := h.applySchedule(tournament, req.Date, req.StartsAt, req.DurationMinutes, req.Timezone); code != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": code})
+		return
+	}
+
+	if code := h.applyVenue(c.Request.Context(), tournament, req.VenueID, req.Location); code != "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": code})
+		return
+	}
+	if code := h.applyDiscipline(c.Request.Context(), tournament, req.DisciplineID); code != "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": code})
+		return
+	}
+	if code := h.applyParticipantMode(c.Request.Context(), tournament, req.ParticipantMode); code != "" {
+		c.JSON(participantModeStatus(code), gin.H{"error": code})
 		return
 	}
 
-	user := middleware.GetUser(c)
 	now := time.Now()
 
 	tournament.Name = req.Name
-	tournament.Date = date
-	tournament.Location = req.Location
 	tournament.UpdatedAt = &now
 	tournament.UpdatedBy = &user.TelegramID
 	tournament.Version = req.Version + 1
@@ -368,16 +498,7 @@ func (h *Handler) UpdateTournament(c *gin.Context) {
 		return
 	}
 
-	// Invalidate cache
-	h.cache.Delete(c.Request.Context(), "tournaments:list")
-
-	c.JSON(http.StatusOK, gin.H{
-		"id":       tournament.ID,
-		"name":     tournament.Name,
-		"date":     tournament.Date.Format("2006-01-02"),
-		"location": tournament.Location,
-		"version":  tournament.Version,
-	})
+	c.JSON(http.StatusOK, h.tournamentResponse(tournament))
 }
 
 func (h *Handler) DeleteTournament(c *gin.Context) {
@@ -405,132 +526,219 @@ func (h *Handler) DeleteTournament(c *gin.Context) {
 		return
 	}
 
-	if err := h.tournamentRepo.Delete(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+	if err := tournament.CheckEditable(middleware.GetUser(c).IsAdmin()); err != nil {
+		tournamentStateError(c, err)
 		return
 	}
 
-	// Invalidate cache
-	h.cache.Delete(c.Request.Context(), "tournaments:list")
+	if err := h.tournamentRepo.Delete(c.Request.Context(), id, middleware.GetUser(c).TelegramID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{"deleted": true})
+	c.JSON(http.StatusOK, DeletedResponse{Deleted: true})
 }
 
-// === RESULTS ===
-
-type CreateResultRequest struct {
-	TeamID int64 `json:"team_id" binding:"required"`
-	Place  int   `json:"place" binding:"required,min=1,max=1000"`
+type SetTournamentStatusRequest struct {
+	Status  string `json:"status" binding:"required,oneof=planned in_progress finalized"`
+	Version int    `json:"version" binding:"required,min=1"`
 }
 
-func (h *Handler) CreateResult(c *gin.Context) {
-	tournamentIDStr := c.Param("id")
-	tournamentID, err := strconv.ParseInt(tournamentIDStr, 10, 64)
+// SetTournamentStatus moves tournament through planned → in_progress → finalized
+func (h *Handler) SetTournamentStatus(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_tournament_id"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
 		return
 	}
 
-	var req CreateResultRequest
+	var req SetTournamentStatusRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
 		return
 	}
 
-	// Verify tournament exists
-	_, err = h.tournamentRepo.GetByID(c.Request.Context(), tournamentID)
+	tournament, err := h.tournamentRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "tournament_not_found"})
 		return
 	}
 
-	// Verify team exists
-	_, err = h.teamRepo.GetByID(c.Request.Context(), req.TeamID)
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "team_not_found"})
+	if tournament.Version != req.Version {
+		c.JSON(http.StatusConflict, gin.H{"error": "version_conflict", "current_version": tournament.Version})
 		return
 	}
 
 	user := middleware.GetUser(c)
-
-	result := &domain.Result{
-		TournamentID: tournamentID,
-		TeamID:       req.TeamID,
-		Place:        req.Place,
-		RecordedBy:   user.TelegramID,
+	status := domain.TournamentStatus(req.Status)
+	if err := tournament.CheckTransition(status, user.IsAdmin()); err != nil {
+		tournamentStateError(c, err)
+		return
 	}
 
-	if err := h.resultRepo.Create(c.Request.Context(), result); err != nil {
+	now := time.Now()
+	tournament.SetStatus(status, now)
+	tournament.UpdatedAt = &now
+	tournament.UpdatedBy = &user.TelegramID
+	tournament.Version = req.Version + 1
+
+	if err := h.tournamentRepo.Update(c.Request.Context(), tournament); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 		return
 	}
 
-	// Invalidate rating cache
-	_ = h.cache.Delete(c.Request.Context(), ratingCacheKey)
+	c.JSON(http.StatusOK, h.tournamentResponse(tournament))
+}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"id":            result.ID,
-		"tournament_id": result.TournamentID,
-		"team_id":       result.TeamID,
-		"place":         result.Place,
-		"recorded_at":   result.RecordedAt.Format(time.RFC3339),
-		"version":       result.Version,
-	})
+// resultsEditable checks that results of the tournament may be changed,
+// writing the error response otherwise
+func (h *Handler) resultsEditable(c *gin.Context, tournamentID int64) bool {
+	tournament, err := h.tournamentRepo.GetByID(c.Request.Context(), tournamentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "tournament_not_found"})
+		return false
+	}
+	if err := tournament.CheckEditable(middleware.GetUser(c).IsAdmin()); err != nil {
+		tournamentStateError(c, err)
+		return false
+	}
+	return true
 }
 
-type UpdateResultRequest struct {
-	Place   int `json:"place" binding:"required,min=1,max=1000"`
+// tournamentStateError maps tournament lifecycle errors to responses
+func tournamentStateError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTournamentFinalized):
+		c.JSON(http.StatusConflict, gin.H{"error": "tournament_finalized"})
+	case errors.Is(err, domain.ErrTournamentNotStarted):
+		c.JSON(http.StatusConflict, gin.H{"error": "tournament_not_started"})
+	case errors.Is(err, domain.ErrStatusChangeNeedsAdmin):
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+	case errors.Is(err, domain.ErrInvalidStatusChange):
+		c.JSON(http.StatusConflict, gin.H{"error": "invalid_status_transition"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+	}
+}
+
+// === VENUES ===
+
+type VenueRequest struct {
+	Name      string   `json:"name" binding:"required,min=1,max=200"`
+	Address   string   `json:"address" binding:"max=500"`
+	Latitude  *float64 `json:"latitude" binding:"omitempty,gte=-90,lte=90"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,gte=-180,lte=180"`
+	Notes     string   `json:"notes" binding:"max=2000"`
+}
+
+type UpdateVenueRequest struct {
+	VenueRequest
 	Version int `json:"version" binding:"required,min=1"`
 }
 
-func (h *Handler) UpdateResult(c *gin.Context) {
-	resultIDStr := c.Param("result_id")
-	resultID, err := strconv.ParseInt(resultIDStr, 10, 64)
+// checkVenue validates coordinates and name uniqueness; returns status and
+// error code, or 0 and ""
+func (h *Handler) checkVenue(ctx context.Context, req VenueRequest, exceptID int64) (int, string) {
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return http.StatusBadRequest, "invalid_coordinates"
+	}
+	venues, err := h.venueRepo.List(ctx)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_result_id"})
+		return http.StatusInternalServerError, "internal_error"
+	}
+	for _, v := range venues {
+		if v.ID != exceptID && strings.EqualFold(strings.TrimSpace(v.Name), strings.TrimSpace(req.Name)) {
+			return http.StatusConflict, "venue_exists"
+		}
+	}
+	return 0, ""
+}
+
+func (h *Handler) CreateVenue(c *gin.Context) {
+	var req VenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
 		return
 	}
 
-	var req UpdateResultRequest
+	if status, code := h.checkVenue(c.Request.Context(), req, 0); code != "" {
+		c.JSON(status, gin.H{"error": code})
+		return
+	}
+
+	user := middleware.GetUser(c)
+
+	venue := &domain.Venue{
+		Name:      strings.TrimSpace(req.Name),
+		Address:   req.Address,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		Notes:     req.Notes,
+		CreatedBy: user.TelegramID,
+	}
+
+	if err := h.venueRepo.Create(c.Request.Context(), venue); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, venueResponse(venue))
+}
+
+func (h *Handler) UpdateVenue(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	var req UpdateVenueRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
 		return
 	}
 
-	result, err := h.resultRepo.GetByID(c.Request.Context(), resultID)
+	venue, err := h.venueRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "result_not_found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": "venue_not_found"})
 		return
 	}
 
-	if result.Version != req.Version {
-		c.JSON(http.StatusConflict, gin.H{"error": "version_conflict", "current_version": result.Version})
+	if venue.Version != req.Version {
+		c.JSON(http.StatusConflict, gin.H{"error": "version_conflict", "current_version": venue.Version})
 		return
 	}
 
-	result.Place = req.Place
-	result.Version = req.Version + 1
+	if status, code := h.checkVenue(c.Request.Context(), req.VenueRequest, id); code != "" {
+		c.JSON(status, gin.H{"error": code})
+		return
+	}
 
-	if err := h.resultRepo.Update(c.Request.Context(), result); err != nil {
+	user := middleware.GetUser(c)
+	now := time.Now()
+
+	venue.Name = strings.TrimSpace(req.Name)
+	venue.Address = req.Address
+	venue.Latitude = req.Latitude
+	venue.Longitude = req.Longitude
+	venue.Notes = req.Notes
+	venue.UpdatedAt = &now
+	venue.UpdatedBy = &user.TelegramID
+	venue.Version = req.Version + 1
+
+	if err := h.venueRepo.Update(c.Request.Context(), venue); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 		return
 	}
 
-	// Invalidate rating cache
-	_ = h.cache.Delete(c.Request.Context(), ratingCacheKey)
-
-	c.JSON(http.StatusOK, gin.H{
-		"id":      result.ID,
-		"place":   result.Place,
-		"version": result.Version,
-	})
+	c.JSON(http.StatusOK, venueResponse(venue))
 }
 
-func (h *Handler) DeleteResult(c *gin.Context) {
-	resultIDStr := c.Param("result_id")
-	resultID, err := strconv.ParseInt(resultIDStr, 10, 64)
+func (h *Handler) DeleteVenue(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_result_id"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
 		return
 	}
 
@@ -540,91 +748,1681 @@ func (h *Handler) DeleteResult(c *gin.Context) {
 		return
 	}
 
-	result, err := h.resultRepo.GetByID(c.Request.Context(), resultID)
+	venue, err := h.venueRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "result_not_found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": "venue_not_found"})
 		return
 	}
 
-	if result.Version != req.Version {
-		c.JSON(http.StatusConflict, gin.H{"error": "version_conflict", "current_version": result.Version})
+	if venue.Version != req.Version {
+		c.JSON(http.StatusConflict, gin.H{"error": "version_conflict", "current_version": venue.Version})
 		return
 	}
 
-	// Delete result and shift places in a transaction
-	if err := h.resultRepo.DeleteWithShift(c.Request.Context(), resultID, result.TournamentID, result.Place); err != nil {
+	if err := h.venueRepo.Delete(c.Request.Context(), id, middleware.GetUser(c).TelegramID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 		return
 	}
 
-	// Invalidate rating cache
-	_ = h.cache.Delete(c.Request.Context(), ratingCacheKey)
+	c.JSON(http.StatusOK, DeletedResponse{Deleted: true})
+}
 
-	c.JSON(http.StatusOK, gin.H{"deleted": true})
+type MergeVenueRequest struct {
+	TargetVenueID int64 `json:"target_venue_id" binding:"required"`
 }
 
-// === USERS (Admin only) ===
+// MergeVenue merges duplicate venue :id (source) into target_venue_id
+func (h *Handler) MergeVenue(c *gin.Context) {
+	sourceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	var req MergeVenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	if sourceID == req.TargetVenueID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "same_venue"})
+		return
+	}
 
-func (h *Handler) ListUsers(c *gin.Context) {
-	users, err := h.userRepo.List(c.Request.Context())
+	target, err := h.venueRepo.Merge(c.Request.Context(), sourceID, req.TargetVenueID, middleware.GetUser(c).TelegramID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "venue_not_found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 		return
 	}
 
-	items := make([]gin.H, 0, len(users))
-	for _, u := range users {
-		items = append(items, gin.H{
-			"telegram_id": u.TelegramID,
-			"username":    u.Username,
-			"role":        u.Role,
-			"created_at":  u.CreatedAt.Format(time.RFC3339),
-		})
+	c.JSON(http.StatusOK, venueResponse(target))
+}
+
+// === PLAYERS ===
+
+type PlayerRequest struct {
+	Name       string `json:"name" binding:"required,min=1,max=100"`
+	MemberID   *int64 `json:"member_id"`
+	TelegramID *int64 `json:"telegram_id"`
+}
+
+type UpdatePlayerRequest struct {
+	PlayerRequest
+	Version int `json:"version" binding:"required,min=1"`
+}
+
+func (h *Handler) CreatePlayer(c *gin.Context) {
+	var req PlayerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
 	}
 
-	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
+	user := middleware.GetUser(c)
+
+	player := &domain.Player{
+		Name:       strings.TrimSpace(req.Name),
+		MemberID:   req.MemberID,
+		TelegramID: req.TelegramID,
+		CreatedBy:  user.TelegramID,
+	}
+
+	if err := h.playerRepo.Create(c.Request.Context(), player); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, playerResponse(player))
 }
 
-type UpdateRoleRequest struct {
-	Role string `json:"role" binding:"required,oneof=viewer organizer admin"`
+func (h *Handler) UpdatePlayer(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	var req UpdatePlayerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	player, err := h.playerRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "player_not_found"})
+		return
+	}
+
+	if player.Version != req.Version {
+		c.JSON(http.StatusConflict, gin.H{"error": "version_conflict", "current_version": player.Version})
+		return
+	}
+
+	user := middleware.GetUser(c)
+	now := time.Now()
+
+	player.Name = strings.TrimSpace(req.Name)
+	player.MemberID = req.MemberID
+	player.TelegramID = req.TelegramID
+	player.UpdatedAt = &now
+	player.UpdatedBy = &user.TelegramID
+	player.Version = req.Version + 1
+
+	if err := h.playerRepo.Update(c.Request.Context(), player); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, playerResponse(player))
 }
 
-func (h *Handler) UpdateUserRole(c *gin.Context) {
-	telegramIDStr := c.Param("telegram_id")
-	telegramID, err := strconv.ParseInt(telegramIDStr, 10, 64)
+func (h *Handler) DeletePlayer(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_telegram_id"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
 		return
 	}
 
-	var req UpdateRoleRequest
+	var req DeleteRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
 		return
 	}
 
-	// Check user exists
-	user, err := h.userRepo.GetByTelegramID(c.Request.Context(), telegramID)
+	player, err := h.playerRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "user_not_found"})
+		c.JSON(http.StatusNotFound, gin.H{"error": "player_not_found"})
 		return
 	}
 
-	// Cannot change own role
-	currentUser := middleware.GetUser(c)
-	if currentUser.TelegramID == telegramID {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "cannot_change_own_role"})
+	if player.Version != req.Version {
+		c.JSON(http.StatusConflict, gin.H{"error": "version_conflict", "current_version": player.Version})
 		return
 	}
 
-	if err := h.userRepo.UpdateRole(c.Request.Context(), telegramID, domain.Role(req.Role)); err != nil {
+	if err := h.playerRepo.Delete(c.Request.Context(), id, middleware.GetUser(c).TelegramID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"telegram_id": user.TelegramID,
-		"username":    user.Username,
-		"role":        req.Role,
-	})
+	c.JSON(http.StatusOK, DeletedResponse{Deleted: true})
+}
+
+// === DISCIPLINES ===
+
+type DisciplineRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+type UpdateDisciplineRequest struct {
+	Name    string `json:"name" binding:"required,min=1,max=100"`
+	Version int    `json:"version" binding:"required,min=1"`
+}
+
+// disciplineNameTaken reports whether another discipline has the name
+func (h *Handler) disciplineNameTaken(ctx context.Context, name string, exceptID int64) (bool, error) {
+	disciplines, err := h.disciplineRepo.List(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, d := range disciplines {
+		if d.ID != exceptID && strings.EqualFold(d.Name, name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (h *Handler) CreateDiscipline(c *gin.Context) {
+	var req DisciplineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	taken, err := h.disciplineNameTaken(c.Request.Context(), name, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	if taken {
+		c.JSON(http.StatusConflict, gin.H{"error": "discipline_exists"})
+		return
+	}
+
+	discipline := &domain.Discipline{
+		Name:      name,
+		CreatedBy: middleware.GetUser(c).TelegramID,
+	}
+
+	if err := h.disciplineRepo.Create(c.Request.Context(), discipline); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, disciplineResponse(discipline))
+}
+
+func (h *Handler) UpdateDiscipline(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	var req UpdateDisciplineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	discipline, err := h.disciplineRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "discipline_not_found"})
+		return
+	}
+
+	if discipline.Version != req.Version {
+		c.JSON(http.StatusConflict, gin.H{"error": "version_conflict", "current_version": discipline.Version})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	taken, err := h.disciplineNameTaken(c.Request.Context(), name, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	if taken {
+		c.JSON(http.StatusConflict, gin.H{"error": "discipline_exists"})
+		return
+	}
+
+	user := middleware.GetUser(c)
+	now := time.Now()
+
+	discipline.Name = name
+	discipline.UpdatedAt = &now
+	discipline.UpdatedBy = &user.TelegramID
+	discipline.Version = req.Version + 1
+
+	if err := h.disciplineRepo.Update(c.Request.Context(), discipline); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, disciplineResponse(discipline))
+}
+
+func (h *Handler) DeleteDiscipline(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	var req DeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	discipline, err := h.disciplineRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "discipline_not_found"})
+		return
+	}
+
+	if discipline.Version != req.Version {
+		c.JSON(http.StatusConflict, gin.H{"error": "version_conflict", "current_version": discipline.Version})
+		return
+	}
+
+	if err := h.disciplineRepo.Delete(c.Request.Context(), id, middleware.GetUser(c).TelegramID); err != nil {
+		if errors.Is(err, domain.ErrDisciplineInUse) {
+			c.JSON(http.StatusConflict, gin.H{"error": "discipline_in_use"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, DeletedResponse{Deleted: true})
+}
+
+// === RESULTS ===
+
+type CreateResultRequest struct {
+	TeamID   int64 `json:"team_id"`   // team tournaments
+	PlayerID int64 `json:"player_id"` // individual tournaments
+	Place    int   `json:"place" binding:"required,min=1,max=1000"`
+}
+
+func (h *Handler) CreateResult(c *gin.Context) {
+	tournamentIDStr := c.Param("id")
+	tournamentID, err := strconv.ParseInt(tournamentIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_tournament_id"})
+		return
+	}
+
+	var req CreateResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	// Verify tournament exists and accepts results
+	tournament, err := h.tournamentRepo.GetByID(c.Request.Context(), tournamentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "tournament_not_found"})
+		return
+	}
+
+	user := middleware.GetUser(c)
+	if err := tournament.CheckResults(time.Now(), h.loc, user.IsAdmin()); err != nil {
+		tournamentStateError(c, err)
+		return
+	}
+
+	result := &domain.Result{
+		TournamentID: tournamentID,
+		Place:        req.Place,
+		RecordedBy:   user.TelegramID,
+	}
+
+	// Verify participant exists, by tournament mode
+	if tournament.IsIndividual() {
+		if req.PlayerID == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "player_required"})
+			return
+		}
+		if _, err := h.playerRepo.GetByID(c.Request.Context(), req.PlayerID); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "player_not_found"})
+			return
+		}
+		result.PlayerID = req.PlayerID
+	} else {
+		if req.TeamID == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "team_required"})
+			return
+		}
+		if _, err := h.teamRepo.GetByID(c.Request.Context(), req.TeamID); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "team_not_found"})
+			return
+		}
+		result.TeamID = req.TeamID
+	}
+
+	if err := h.resultRepo.Create(c.Request.Context(), result); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resultResponse(result))
+}
+
+func resultResponse(r *domain.Result) ResultResponse {
+	return ResultResponse{
+		ID:           r.ID,
+		TournamentID: r.TournamentID,
+		TeamID:       r.TeamID,
+		PlayerID:     r.PlayerID,
+		Place:        r.Place,
+		RecordedAt:   r.RecordedAt.Format(time.RFC3339),
+		Version:      r.Version,
+	}
+}
+
+type UpdateResultRequest struct {
+	Place   int `json:"place" binding:"required,min=1,max=1000"`
+	Version int `json:"version" binding:"required,min=1"`
+}
+
+func (h *Handler) UpdateResult(c *gin.Context) {
+	resultIDStr := c.Param("result_id")
+	resultID, err := strconv.ParseInt(resultIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_result_id"})
+		return
+	}
+
+	var req UpdateResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	result, err := h.resultRepo.GetByID(c.Request.Context(), resultID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "result_not_found"})
+		return
+	}
+
+	if result.Version != req.Version {
+		c.JSON(http.StatusConflict, gin.H{"error": "version_conflict", "current_version": result.Version})
+		return
+	}
+
+	if !h.resultsEditable(c, result.TournamentID) {
+		return
+	}
+
+	result.Place = req.Place
+	result.Version = req.Version + 1
+
+	if err := h.resultRepo.Update(c.Request.Context(), result); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resultResponse(result))
+}
+
+func (h *Handler) DeleteResult(c *gin.Context) {
+	resultIDStr := c.Param("result_id")
+	resultID, err := strconv.ParseInt(resultIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_result_id"})
+		return
+	}
+
+	var req DeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	result, err := h.resultRepo.GetByID(c.Request.Context(), resultID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "result_not_found"})
+		return
+	}
+
+	if result.Version != req.Version {
+		c.JSON(http.StatusConflict, gin.H{"error": "version_conflict", "current_version": result.Version})
+		return
+	}
+
+	if !h.resultsEditable(c, result.TournamentID) {
+		return
+	}
+
+	// Delete result and shift places in a transaction
+	if err := h.resultRepo.DeleteWithShift(c.Request.Context(), resultID, result.TournamentID, result.Place, middleware.GetUser(c).TelegramID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, DeletedResponse{Deleted: true})
+}
+
+// === REGISTRATIONS ===
+
+type RegisterTeamRequest struct {
+	TeamID int64 `json:"team_id" binding:"required"`
+}
+
+// RegisterTeam signs a team up for a tournament (shown in the team calendar feed)
+func (h *Handler) RegisterTeam(c *gin.Context) {
+	tournamentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_tournament_id"})
+		return
+	}
+
+	var req RegisterTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	tournament, err := h.tournamentRepo.GetByID(c.Request.Context(), tournamentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "tournament_not_found"})
+		return
+	}
+	if tournament.IsIndividual() {
+		c.JSON(http.StatusConflict, gin.H{"error": "individual_tournament"})
+		return
+	}
+	if _, err := h.teamRepo.GetByID(c.Request.Context(), req.TeamID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "team_not_found"})
+		return
+	}
+
+	user := middleware.GetUser(c)
+
+	reg := &domain.Registration{
+		TournamentID: tournamentID,
+		TeamID:       req.TeamID,
+		RegisteredBy: user.TelegramID,
+	}
+	if err := h.tournamentRepo.Register(c.Request.Context(), reg); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, RegistrationResponse{
+		TournamentID: reg.TournamentID,
+		TeamID:       reg.TeamID,
+	})
+}
+
+// UnregisterTeam removes a team registration
+func (h *Handler) UnregisterTeam(c *gin.Context) {
+	tournamentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_tournament_id"})
+		return
+	}
+	teamID, err := strconv.ParseInt(c.Param("team_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_team_id"})
+		return
+	}
+
+	if err := h.tournamentRepo.Unregister(c.Request.Context(), tournamentID, teamID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, DeletedResponse{Deleted: true})
+}
+
+// === USERS (users.manage) ===
+
+type ListUsersParams struct {
+	Q    string `form:"q"` // telegram ID, @username or part of the name
+	Role string `form:"role"`
+	PaginationParams
+}
+
+func (h *Handler) ListUsers(c *gin.Context) {
+	var params ListUsersParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+	if params.Limit <= 0 || params.Limit > 200 {
+		params.Limit = 50
+	}
+
+	users, total, err := h.userRepo.List(c.Request.Context(), repository.UserFilter{
+		Query:  params.Q,
+		Role:   domain.Role(params.Role),
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	now := time.Now()
+	items := make([]UserResponse, 0, len(users))
+	for _, u := range users {
+		items = append(items, userResponse(u, now))
+	}
+
+	c.JSON(http.StatusOK, NewListResponse(items, params.Limit, params.Offset, total))
+}
+
+// GetUser returns the user with their activity counters
+func (h *Handler) GetUser(c *gin.Context) {
+	telegramID, err := strconv.ParseInt(c.Param("telegram_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_telegram_id"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	user, err := h.userRepo.GetByTelegramID(ctx, telegramID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user_not_found"})
+		return
+	}
+
+	activity, err := h.userRepo.GetActivity(ctx, telegramID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, UserDetailsResponse{
+		UserResponse: userResponse(user, time.Now()),
+		Activity: UserActivityResponse{
+			ResultsRecorded:    activity.ResultsRecorded,
+			TeamsCreated:       activity.TeamsCreated,
+			MembersCreated:     activity.MembersCreated,
+			TournamentsCreated: activity.TournamentsCreated,
+			VenuesCreated:      activity.VenuesCreated,
+			PlayersCreated:     activity.PlayersCreated,
+		},
+	})
+}
+
+type UpdateUserRoleRequest struct {
+	Role    string `json:"role" binding:"required,max=50"`
+	Confirm bool   `json:"confirm"` // required to promote to admin
+}
+
+func (h *Handler) UpdateUserRole(c *gin.Context) {
+	telegramIDStr := c.Param("telegram_id")
+	telegramID, err := strconv.ParseInt(telegramIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_telegram_id"})
+		return
+	}
+
+	var req UpdateUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	roleDef, err := h.roleRepo.GetByName(c.Request.Context(), req.Role)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown_role"})
+		return
+	}
+
+	// Check user exists
+	user, err := h.userRepo.GetByTelegramID(c.Request.Context(), telegramID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user_not_found"})
+		return
+	}
+	current, err := h.roleRepo.GetByName(c.Request.Context(), string(user.Role))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	currentUser := middleware.GetUser(c)
+	change := domain.RoleChange{
+		Actor:        currentUser,
+		Target:       user,
+		Role:         domain.Role(req.Role),
+		Grants:       roleDef.Grants(),
+		TargetGrants: current.Grants(),
+		Locked:       h.lockedAdmins,
+		Confirmed:    req.Confirm,
+	}
+	if err := change.Check(); err != nil {
+		roleChangeError(c, err)
+		return
+	}
+
+	if err := h.userRepo.UpdateRole(c.Request.Context(), telegramID, domain.Role(req.Role), currentUser.TelegramID); err != nil {
+		roleChangeError(c, err)
+		return
+	}
+
+	// Sessions issued with the old role must not outlive it
+	if err := h.sessions.RevokeUser(c.Request.Context(), telegramID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	user.Role = domain.Role(req.Role)
+	c.JSON(http.StatusOK, userResponse(user, time.Now()))
+}
+
+// roleChangeError maps role governance errors to responses
+func roleChangeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrOwnRole):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cannot_change_own_role"})
+	case errors.Is(err, domain.ErrAdminRequired):
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin_required"})
+	case errors.Is(err, domain.ErrPermissionsExceeded):
+		c.JSON(http.StatusForbidden, gin.H{"error": "permissions_exceeded"})
+	case errors.Is(err, domain.ErrRoleLocked):
+		c.JSON(http.StatusConflict, gin.H{"error": "role_locked"})
+	case errors.Is(err, domain.ErrLastAdmin):
+		c.JSON(http.StatusConflict, gin.H{"error": "last_admin"})
+	case errors.Is(err, domain.ErrConfirmationRequired):
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "confirmation_required"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+	}
+}
+
+type BlockUserRequest struct {
+	Reason string     `json:"reason" binding:"required,min=1,max=255"`
+	Until  *time.Time `json:"until"` // RFC3339, default: until unblocked
+}
+
+// BlockUser blocks a user: bot and API reject them until unblocked or until
+func (h *Handler) BlockUser(c *gin.Context) {
+	telegramID, err := strconv.ParseInt(c.Param("telegram_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_telegram_id"})
+		return
+	}
+
+	var req BlockUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	if req.Until != nil && !req.Until.After(now) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_until"})
+		return
+	}
+
+	target, err := h.userRepo.GetByTelegramID(c.Request.Context(), telegramID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user_not_found"})
+		return
+	}
+
+	currentUser := middleware.GetUser(c)
+	if currentUser.TelegramID == telegramID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cannot_block_self"})
+		return
+	}
+	if target.IsAdmin() {
+		c.JSON(http.StatusConflict, gin.H{"error": "cannot_block_admin"})
+		return
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if err := h.userRepo.Block(c.Request.Context(), telegramID, reason, req.Until, currentUser.TelegramID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	// Blocked user's sessions end now, not when the access token expires
+	if err := h.sessions.RevokeUser(c.Request.Context(), telegramID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	target.BlockedAt = &now
+	target.BlockedUntil = req.Until
+	target.BlockedReason = reason
+	target.BlockedBy = &currentUser.TelegramID
+
+	c.JSON(http.StatusOK, userResponse(target, now))
+}
+
+func (h *Handler) UnblockUser(c *gin.Context) {
+	telegramID, err := strconv.ParseInt(c.Param("telegram_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_telegram_id"})
+		return
+	}
+
+	target, err := h.userRepo.GetByTelegramID(c.Request.Context(), telegramID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user_not_found"})
+		return
+	}
+
+	if err := h.userRepo.Unblock(c.Request.Context(), telegramID, middleware.GetUser(c).TelegramID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	target.BlockedAt = nil
+	target.BlockedUntil = nil
+	target.BlockedReason = ""
+	target.BlockedBy = nil
+
+	c.JSON(http.StatusOK, userResponse(target, time.Now()))
+}
+
+func userResponse(u *domain.User, now time.Time) UserResponse {
+	resp := UserResponse{
+		TelegramID: u.TelegramID,
+		Username:   u.Username,
+		FirstName:  u.FirstName,
+		LastName:   u.LastName,
+		Role:       u.Role,
+		Blocked:    u.IsBlocked(now),
+		LastSeenAt: u.LastSeenAt,
+		CreatedAt:  u.CreatedAt.Format(time.RFC3339),
+	}
+	if resp.Blocked {
+		resp.BlockedReason = u.BlockedReason
+		resp.BlockedUntil = u.BlockedUntil
+		resp.BlockedBy = u.BlockedBy
+	}
+	return resp
+}
+
+// === INVITES (users.manage) ===
+
+type CreateInviteRequest struct {
+	Role      string     `json:"role" binding:"required,max=50"`
+	MaxUses   int        `json:"max_uses" binding:"omitempty,min=1,max=1000"` // default: 1, always 1 for admin
+	ExpiresAt *time.Time `json:"expires_at"`                                  // RFC3339, default: in 7 days
+	Confirm   bool       `json:"confirm"`                                     // required for admin invites
+}
+
+func (h *Handler) ListInvites(c *gin.Context) {
+	var params PaginationParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+	if params.Limit <= 0 || params.Limit > 200 {
+		params.Limit = 50
+	}
+
+	invites, total, err := h.inviteRepo.List(c.Request.Context(), params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	now := time.Now()
+	items := make([]InviteResponse, 0, len(invites))
+	for _, inv := range invites {
+		items = append(items, inviteResponse(inv, now))
+	}
+
+	c.JSON(http.StatusOK, NewListResponse(items, params.Limit, params.Offset, total))
+}
+
+// CreateInvite returns the invite token and deep link once
+func (h *Handler) CreateInvite(c *gin.Context) {
+	var req CreateInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	roleDef, err := h.roleRepo.GetByName(c.Request.Context(), req.Role)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown_role"})
+		return
+	}
+	// Invites only lift viewers, a viewer invite would do nothing
+	if domain.Role(req.Role) == domain.RoleViewer {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invite_role_viewer"})
+		return
+	}
+
+	// An invite is a role change for whoever opens it: the same rules apply
+	actor := middleware.GetUser(c)
+	if domain.Role(req.Role) == domain.RoleAdmin {
+		switch {
+		case !actor.IsAdmin():
+			roleChangeError(c, domain.ErrAdminRequired)
+			return
+		case !req.Confirm:
+			roleChangeError(c, domain.ErrConfirmationRequired)
+			return
+		}
+	}
+	if !actor.CanAll(roleDef.Grants()) {
+		roleChangeError(c, domain.ErrPermissionsExceeded)
+		return
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(domain.InviteTTL)
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(now) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_expires_at"})
+			return
+		}
+		expiresAt = *req.ExpiresAt
+	}
+	// One confirmation promotes one admin
+	maxUses := req.MaxUses
+	if maxUses == 0 || domain.Role(req.Role) == domain.RoleAdmin {
+		maxUses = 1
+	}
+
+	raw, prefix, err := domain.NewInviteToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	invite := &domain.Invite{
+		Prefix:    prefix,
+		TokenHash: domain.HashInviteToken(raw),
+		Role:      domain.Role(req.Role),
+		MaxUses:   maxUses,
+		ExpiresAt: expiresAt,
+		CreatedBy: actor.TelegramID,
+	}
+
+	if err := h.inviteRepo.Create(c.Request.Context(), invite); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	// Token is shown only once
+	resp := CreatedInviteResponse{
+		InviteResponse: inviteResponse(invite, now),
+		Token:          raw,
+	}
+	if h.botUsername != "" {
+		resp.Link = domain.InviteLink(h.botUsername, raw)
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// RevokeInvite disables an invite; revoked invites stay in the list
+func (h *Handler) RevokeInvite(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	if _, err := h.inviteRepo.GetByID(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "invite_not_found"})
+		return
+	}
+
+	if err := h.inviteRepo.Revoke(c.Request.Context(), id, middleware.GetUser(c).TelegramID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, RevokedResponse{Revoked: true})
+}
+
+func inviteResponse(inv *domain.Invite, now time.Time) InviteResponse {
+	return InviteResponse{
+		ID:        inv.ID,
+		Prefix:    inv.Prefix,
+		Role:      inv.Role,
+		MaxUses:   inv.MaxUses,
+		Uses:      inv.Uses,
+		Active:    inv.Check(now) == nil,
+		ExpiresAt: inv.ExpiresAt.Format(time.RFC3339),
+		RevokedAt: inv.RevokedAt,
+		CreatedBy: inv.CreatedBy,
+		CreatedAt: inv.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// === ROLES (roles.manage) ===
+
+type RoleRequest struct {
+	Name        string   `json:"name" binding:"required,min=2,max=50"`
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions" binding:"required,dive,required"`
+}
+
+type UpdateRoleRequest struct {
+	Name        string   `json:"name" binding:"required,min=2,max=50"`
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions" binding:"required,dive,required"`
+	Version     int      `json:"version" binding:"required,min=1"`
+}
+
+// ListPermissions returns permissions that can be granted to roles
+func (h *Handler) ListPermissions(c *gin.Context) {
+	c.JSON(http.StatusOK, PermissionsResponse{Permissions: domain.AllPermissions})
+}
+
+func (h *Handler) ListRoles(c *gin.Context) {
+	roles, err := h.roleRepo.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	items := make([]RoleResponse, 0, len(roles))
+	for _, r := range roles {
+		items = append(items, roleResponse(r))
+	}
+
+	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
+}
+
+// checkPermissions returns the first unknown permission or ""
+func checkPermissions(perms []string) string {
+	for _, p := range perms {
+		if !domain.IsKnownPermission(p) {
+			return p
+		}
+	}
+	return ""
+}
+
+func permissionsOf(perms []string) []domain.Permission {
+	out := make([]domain.Permission, 0, len(perms))
+	for _, p := range perms {
+		out = append(out, domain.Permission(p))
+	}
+	return out
+}
+
+// roleNameTaken reports whether another role has the name
+func (h *Handler) roleNameTaken(ctx context.Context, name string, exceptID int64) (bool, error) {
+	role, err := h.roleRepo.GetByName(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return role.ID != exceptID, nil
+}
+
+func (h *Handler) CreateRole(c *gin.Context) {
+	var req RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	if p := checkPermissions(req.Permissions); p != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown_permission", "permission": p})
+		return
+	}
+	// A role can't carry more than its author holds
+	if !middleware.GetUser(c).CanAll(permissionsOf(req.Permissions)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "permissions_exceeded"})
+		return
+	}
+
+	name := strings.ToLower(strings.TrimSpace(req.Name))
+	taken, err := h.roleNameTaken(c.Request.Context(), name, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	if taken {
+		c.JSON(http.StatusConflict, gin.H{"error": "role_exists"})
+		return
+	}
+
+	role := &domain.RoleDef{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: req.Permissions,
+		CreatedBy:   middleware.GetUser(c).TelegramID,
+	}
+
+	if err := h.roleRepo.Create(c.Request.Context(), role); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, roleResponse(role))
+}
+
+// UpdateRole changes role name, description and permissions; built-in roles
+// keep their names and admin can't be changed
+func (h *Handler) UpdateRole(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	var req UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	if p := checkPermissions(req.Permissions); p != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown_permission", "permission": p})
+		return
+	}
+
+	role, err := h.roleRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "role_not_found"})
+		return
+	}
+
+	if role.Version != req.Version {
+		c.JSON(http.StatusConflict, gin.H{"error": "version_conflict", "current_version": role.Version})
+		return
+	}
+
+	// Only roles within the editor's own permissions, and only within them
+	user := middleware.GetUser(c)
+	if !user.CanAll(role.Grants()) || !user.CanAll(permissionsOf(req.Permissions)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "permissions_exceeded"})
+		return
+	}
+
+	name := strings.ToLower(strings.TrimSpace(req.Name))
+	if role.Builtin && (name != role.Name || domain.Role(role.Name) == domain.RoleAdmin) {
+		c.JSON(http.StatusConflict, gin.H{"error": "role_builtin"})
+		return
+	}
+
+	taken, err := h.roleNameTaken(c.Request.Context(), name, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	if taken {
+		c.JSON(http.StatusConflict, gin.H{"error": "role_exists"})
+		return
+	}
+
+	now := time.Now()
+
+	role.Name = name
+	role.Description = strings.TrimSpace(req.Description)
+	role.Permissions = req.Permissions
+	role.UpdatedAt = &now
+	role.UpdatedBy = &user.TelegramID
+	role.Version = req.Version + 1
+
+	if err := h.roleRepo.Update(c.Request.Context(), role); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, roleResponse(role))
+}
+
+func (h *Handler) DeleteRole(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	var req DeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	role, err := h.roleRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "role_not_found"})
+		return
+	}
+
+	if role.Version != req.Version {
+		c.JSON(http.StatusConflict, gin.H{"error": "version_conflict", "current_version": role.Version})
+		return
+	}
+
+	if err := h.roleRepo.Delete(c.Request.Context(), id, middleware.GetUser(c).TelegramID); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRoleBuiltin):
+			c.JSON(http.StatusConflict, gin.H{"error": "role_builtin"})
+		case errors.Is(err, domain.ErrRoleInUse):
+			c.JSON(http.StatusConflict, gin.H{"error": "role_in_use"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, DeletedResponse{Deleted: true})
+}
+
+func roleResponse(r *domain.RoleDef) RoleResponse {
+	return RoleResponse{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: r.Grants(),
+		Builtin:     r.Builtin,
+		Version:     r.Version,
+		CreatedAt:   r.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// === TEAM MERGES (teams.merge) ===
+
+type MergeTeamRequest struct {
+	TargetTeamID int64 `json:"target_team_id" binding:"required"`
+}
+
+// MergeTeam merges team :id (source) into target_team_id
+func (h *Handler) MergeTeam(c *gin.Context) {
+	idStr := c.Param("id")
+	sourceID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	var req MergeTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	if sourceID == req.TargetTeamID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "same_team"})
+		return
+	}
+
+	user := middleware.GetUser(c)
+
+	merge, err := h.teamRepo.Merge(c.Request.Context(), sourceID, req.TargetTeamID, user.TelegramID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "team_not_found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, teamMergeResponse(merge))
+}
+
+// RevertTeamMerge restores the source team of a merge
+func (h *Handler) RevertTeamMerge(c *gin.Context) {
+	mergeIDStr := c.Param("merge_id")
+	mergeID, err := strconv.ParseInt(mergeIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_merge_id"})
+		return
+	}
+
+	user := middleware.GetUser(c)
+
+	merge, err := h.teamRepo.RevertMerge(c.Request.Context(), mergeID, user.TelegramID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.JSON(http.StatusNotFound, gin.H{"error": "merge_not_found"})
+		case errors.Is(err, repository.ErrMergeAlreadyReverted):
+			c.JSON(http.StatusConflict, gin.H{"error": "merge_already_reverted"})
+		case errors.Is(err, repository.ErrMergeChained):
+			c.JSON(http.StatusConflict, gin.H{"error": "merge_chained"})
+		case errors.Is(err, repository.ErrMergeChanged):
+			c.JSON(http.StatusConflict, gin.H{"error": "merge_changed"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, teamMergeResponse(merge))
+}
+
+func teamMergeResponse(m *domain.TeamMerge) TeamMergeResponse {
+	resp := TeamMergeResponse{
+		ID:             m.ID,
+		SourceTeamID:   m.SourceTeamID,
+		TargetTeamID:   m.TargetTeamID,
+		SourceName:     m.SourceName,
+		MovedMemberIDs: m.MovedMemberIDs,
+		MovedResultIDs: m.MovedResultIDs,
+		Conflicts:      m.Conflicts,
+		MergedBy:       m.MergedBy,
+		MergedAt:       m.MergedAt.Format(time.RFC3339),
+	}
+	if m.RevertedAt != nil {
+		resp.RevertedAt = m.RevertedAt.Format(time.RFC3339)
+		resp.RevertedBy = m.RevertedBy
+	}
+	return resp
+}
+
+// === AUDIT (settings.manage) ===
+
+type ListAuditParams struct {
+	EntityType string `form:"entity_type"`
+	EntityID   int64  `form:"entity_id"`
+	ActorID    int64  `form:"actor_id"`
+	PaginationParams
+}
+
+func (h *Handler) ListAudit(c *gin.Context) {
+	var params ListAuditParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+	if params.Limit <= 0 || params.Limit > 200 {
+		params.Limit = 50
+	}
+
+	entries, total, err := h.auditRepo.List(c.Request.Context(), repository.AuditFilter{
+		EntityType: params.EntityType,
+		EntityID:   params.EntityID,
+		ActorID:    params.ActorID,
+		Limit:      params.Limit,
+		Offset:     params.Offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	items := make([]AuditEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, AuditEntryResponse{
+			ID:         e.ID,
+			ActorID:    e.ActorID,
+			Action:     e.Action,
+			EntityType: e.EntityType,
+			EntityID:   e.EntityID,
+			Details:    e.Details,
+			CreatedAt:  e.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	c.JSON(http.StatusOK, NewListResponse(items, params.Limit, params.Offset, total))
+}
+
+// EvaluateBadges re-evaluates achievement rules over the whole history
+func (h *Handler) EvaluateBadges(c *gin.Context) {
+	awarded, err := h.achievements.EvaluateAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, EvaluateBadgesResponse{Awarded: awarded})
+}
+
+// === API TOKENS (settings.manage) ===
+
+type CreateTokenRequest struct {
+	Name      string     `json:"name" binding:"required,min=1,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,dive,required"`
+	ExpiresAt *time.Time `json:"expires_at"` // RFC3339, default: never
+}
+
+func (h *Handler) ListTokens(c *gin.Context) {
+	tokens, err := h.tokenRepo.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	items := make([]TokenResponse, 0, len(tokens))
+	for _, t := range tokens {
+		items = append(items, tokenResponse(t))
+	}
+
+	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
+}
+
+func (h *Handler) CreateToken(c *gin.Context) {
+	var req CreateTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	for _, s := range req.Scopes {
+		if !domain.IsKnownScope(s) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "unknown_scope"})
+			return
+		}
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_expires_at"})
+		return
+	}
+
+	raw, prefix, err := middleware.GenerateToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	user := middleware.GetUser(c)
+
+	token := &domain.APIToken{
+		Name:      strings.TrimSpace(req.Name),
+		Prefix:    prefix,
+		TokenHash: middleware.HashToken(raw),
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+		CreatedBy: user.TelegramID,
+	}
+
+	if err := h.tokenRepo.Create(c.Request.Context(), token); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	// Token is shown only once
+	resp := CreatedTokenResponse{
+		TokenResponse: tokenResponse(token),
+		Token:         raw,
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// RevokeToken disables a token; revoked tokens stay in the list
+func (h *Handler) RevokeToken(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	if _, err := h.tokenRepo.GetByID(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "token_not_found"})
+		return
+	}
+
+	user := middleware.GetUser(c)
+
+	if err := h.tokenRepo.Revoke(c.Request.Context(), id, user.TelegramID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, RevokedResponse{Revoked: true})
+}
+
+func tokenResponse(t *domain.APIToken) TokenResponse {
+	return TokenResponse{
+		ID:         t.ID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		Scopes:     t.Scopes,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		RevokedAt:  t.RevokedAt,
+		CreatedBy:  t.CreatedBy,
+		CreatedAt:  t.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// === WEBHOOKS (settings.manage) ===
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required,url,max=500"`
+	Events []string `json:"events" binding:"required,min=1,dive,required"`
+}
+
+type UpdateWebhookRequest struct {
+	URL     string   `json:"url" binding:"required,url,max=500"`
+	Events  []string `json:"events" binding:"required,min=1,dive,required"`
+	Active  *bool    `json:"active" binding:"required"`
+	Version int      `json:"version" binding:"required,min=1"`
+}
+
+func (h *Handler) ListWebhooks(c *gin.Context) {
+	hooks, err := h.webhookRepo.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	items := make([]WebhookResponse, 0, len(hooks))
+	for _, w := range hooks {
+		items = append(items, webhookResponse(w))
+	}
+
+	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
+}
+
+func (h *Handler) CreateWebhook(c *gin.Context) {
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	if errCode := validateWebhook(c.Request.Context(), req.URL, req.Events); errCode != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errCode})
+		return
+	}
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	user := middleware.GetUser(c)
+
+	webhook := &domain.Webhook{
+		URL:       req.URL,
+		Secret:    secret,
+		Events:    req.Events,
+		Active:    true,
+		CreatedBy: user.TelegramID,
+	}
+
+	if err := h.webhookRepo.Create(c.Request.Context(), webhook); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	// Secret is shown only once
+	resp := CreatedWebhookResponse{
+		WebhookResponse: webhookResponse(webhook),
+		Secret:          webhook.Secret,
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h *Handler) UpdateWebhook(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	if errCode := validateWebhook(c.Request.Context(), req.URL, req.Events); errCode != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errCode})
+		return
+	}
+
+	webhook, err := h.webhookRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook_not_found"})
+		return
+	}
+
+	if webhook.Version != req.Version {
+		c.JSON(http.StatusConflict, gin.H{"error": "version_conflict", "current_version": webhook.Version})
+		return
+	}
+
+	user := middleware.GetUser(c)
+	now := time.Now()
+
+	webhook.URL = req.URL
+	webhook.Events = req.Events
+	webhook.Active = *req.Active
+	webhook.UpdatedAt = &now
+	webhook.UpdatedBy = &user.TelegramID
+	webhook.Version = req.Version + 1
+
+	if err := h.webhookRepo.Update(c.Request.Context(), webhook); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, webhookResponse(webhook))
+}
+
+func (h *Handler) DeleteWebhook(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	var req DeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	webhook, err := h.webhookRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook_not_found"})
+		return
+	}
+
+	if webhook.Version != req.Version {
+		c.JSON(http.StatusConflict, gin.H{"error": "version_conflict", "current_version": webhook.Version})
+		return
+	}
+
+	if err := h.webhookRepo.Delete(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, DeletedResponse{Deleted: true})
+}
+
+func (h *Handler) ListWebhookDeliveries(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	var params PaginationParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+	if params.Limit <= 0 || params.Limit > 200 {
+		params.Limit = 50
+	}
+
+	if _, err := h.webhookRepo.GetByID(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook_not_found"})
+		return
+	}
+
+	deliveries, total, err := h.webhookRepo.ListDeliveries(c.Request.Context(), id, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	items := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		items = append(items, WebhookDeliveryResponse{
+			ID:         d.ID,
+			JobID:      d.JobID,
+			Attempt:    d.Attempt,
+			StatusCode: d.StatusCode,
+			Error:      d.Error,
+			DurationMs: d.DurationMs,
+			CreatedAt:  d.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	c.JSON(http.StatusOK, NewListResponse(items, params.Limit, params.Offset, total))
+}
+
+// validateWebhook returns error code for invalid URL scheme, a host that
+// doesn't resolve to public addresses or unknown events
+func validateWebhook(ctx context.Context, rawURL string, events []string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "invalid_url"
+	}
+	for _, e := range events {
+		if !webhooks.IsKnownEvent(e) {
+			return "unknown_event"
+		}
+	}
+	if err := webhooks.CheckURL(ctx, rawURL); errors.Is(err, webhooks.ErrForbiddenAddress) {
+		return "forbidden_url"
+	} else if err != nil {
+		return "invalid_url"
+	}
+	return ""
+}
+
+func webhookResponse(w *domain.Webhook) WebhookResponse {
+	return WebhookResponse{
+		ID:        w.ID,
+		URL:       w.URL,
+		Events:    w.Events,
+		Active:    w.Active,
+		CreatedAt: w.CreatedAt.Format(time.RFC3339),
+		Version:   w.Version,
+	}
 }
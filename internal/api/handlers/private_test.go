@@ -0,0 +1,113 @@
+// internal/api/handlers/private_test.go
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/api/middleware"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// fakeTournamentRepo keeps one tournament; other methods are not used
+type fakeTournamentRepo struct {
+	repository.TournamentRepository
+	tournament *domain.Tournament
+	updated    *domain.Tournament
+}
+
+func (r *fakeTournamentRepo) GetByID(_ context.Context, id int64) (*domain.Tournament, error) {
+	t := *r.tournament
+	return &t, nil
+}
+
+func (r *fakeTournamentRepo) Update(_ context.Context, t *domain.Tournament) error {
+	r.updated = t
+	return nil
+}
+
+func TestUpdateTournamentSchedule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	moscow := time.FixedZone("MSK", 3*60*60)
+	start := time.Date(2026, 3, 19, 19, 30, 0, 0, moscow)
+
+	tests := []struct {
+		name         string
+		body         string
+		wantStart    time.Time
+		wantAllDay   bool
+		wantDuration int
+	}{
+		{
+			name:         "schedule fields left out",
+			body:         `{"name":"Квиз","location":"Бар","version":1}`,
+			wantStart:    start,
+			wantDuration: 150,
+		},
+		{
+			name:         "date only keeps start time",
+			body:         `{"name":"Квиз","date":"2026-03-21","location":"Бар","version":1}`,
+			wantStart:    start.AddDate(0, 0, 2),
+			wantDuration: 150,
+		},
+		{
+			name:         "starts_at without time makes all-day",
+			body:         `{"name":"Квиз","starts_at":"2026-03-21","duration_minutes":0,"version":1}`,
+			wantStart:    time.Date(2026, 3, 21, 0, 0, 0, 0, moscow),
+			wantAllDay:   true,
+			wantDuration: 0,
+		},
+		{
+			name:         "starts_at and duration",
+			body:         `{"name":"Квиз","starts_at":"2026-03-19T18:00","duration_minutes":90,"version":1}`,
+			wantStart:    start.Add(-90 * time.Minute),
+			wantDuration: 90,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tournament := &domain.Tournament{
+				ID:              7,
+				Name:            "Квиз",
+				ParticipantMode: domain.ParticipantTeams,
+				Status:          domain.TournamentPlanned,
+				DurationMinutes: 150,
+				Timezone:        "Europe/Moscow",
+				Version:         1,
+			}
+			tournament.SetStart(start, true)
+			repo := &fakeTournamentRepo{tournament: tournament}
+			h := &Handler{tournamentRepo: repo, loc: moscow}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPatch, "/private/tournaments/7", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = gin.Params{{Key: "id", Value: "7"}}
+			c.Set(middleware.ContextKeyUser, &domain.User{TelegramID: 1, Role: domain.RoleOrganizer})
+
+			h.UpdateTournament(c)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+			}
+			got := repo.updated
+			if !got.Start(moscow).Equal(tt.wantStart) {
+				t.Errorf("expected start %v, got %v", tt.wantStart, got.Start(moscow))
+			}
+			if allDay := got.StartsAt == nil; allDay != tt.wantAllDay {
+				t.Errorf("expected all-day %v, got %v", tt.wantAllDay, allDay)
+			}
+			if got.DurationMinutes != tt.wantDuration {
+				t.Errorf("expected duration %d, got %d", tt.wantDuration, got.DurationMinutes)
+			}
+		})
+	}
+}
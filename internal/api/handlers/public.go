@@ -2,17 +2,28 @@
 package handlers
 
 import (
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/eugene-twix/amber-bot/internal/achievements"
 	"github.com/eugene-twix/amber-bot/internal/api/middleware"
+	"github.com/eugene-twix/amber-bot/internal/calendar"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/live"
+	"github.com/eugene-twix/amber-bot/internal/repository"
 	"github.com/gin-gonic/gin"
 )
 
 const (
-	ratingCacheKey = "api:rating"
-	ratingCacheTTL = 5 * time.Minute
+	// Keeps SSE connections alive through proxies
+	liveHeartbeatInterval = 25 * time.Second
+
+	// Calendar feeds keep recent past tournaments
+	calendarPastDays = 30
 )
 
 // GetMe returns current user info
@@ -23,31 +34,35 @@ func (h *Handler) GetMe(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"telegram_id": user.TelegramID,
-		"username":    user.Username,
-		"role":        user.Role,
-		"created_at":  user.CreatedAt.Format(time.RFC3339),
+	c.JSON(http.StatusOK, MeResponse{
+		TelegramID:  user.TelegramID,
+		Username:    user.Username,
+		Role:        user.Role,
+		Permissions: user.Permissions,
+		CreatedAt:   user.CreatedAt.Format(time.RFC3339),
 	})
 }
 
-// ListTeams returns list of teams
+// ListTeams returns list of teams, ?q= searches names and aliases
 func (h *Handler) ListTeams(c *gin.Context) {
-	teams, err := h.teamRepo.List(c.Request.Context())
+	var (
+		teams []*domain.Team
+		err   error
+	)
+	if q := strings.TrimSpace(c.Query("q")); q != "" {
+		teams, err = h.teamRepo.Search(c.Request.Context(), q)
+	} else {
+		teams, err = h.teamRepo.List(c.Request.Context())
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 		return
 	}
 
 	// Transform to response format
-	items := make([]gin.H, 0, len(teams))
+	items := make([]TeamResponse, 0, len(teams))
 	for _, t := range teams {
-		items = append(items, gin.H{
-			"id":         t.ID,
-			"name":       t.Name,
-			"created_at": t.CreatedAt.Format(time.RFC3339),
-			"version":    t.Version,
-		})
+		items = append(items, teamResponse(t))
 	}
 
 	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
@@ -68,15 +83,99 @@ func (h *Handler) GetTeam(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"id":         team.ID,
-		"name":       team.Name,
-		"created_at": team.CreatedAt.Format(time.RFC3339),
-		"created_by": team.CreatedBy,
-		"version":    team.Version,
+	badges, err := h.badgeRepo.GetByTeamID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, TeamDetailsResponse{
+		TeamResponse: teamResponse(team),
+		Badges:       badgesResponse(badges),
 	})
 }
 
+func teamResponse(t *domain.Team) TeamResponse {
+	return TeamResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		CreatedAt: t.CreatedAt.Format(time.RFC3339),
+		CreatedBy: t.CreatedBy,
+		UpdatedAt: formatTime(t.UpdatedAt),
+		Version:   t.Version,
+	}
+}
+
+// ListTeamBadges returns badges earned by team
+func (h *Handler) ListTeamBadges(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	if _, err := h.teamRepo.GetByID(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "team_not_found"})
+		return
+	}
+
+	badges, err := h.badgeRepo.GetByTeamID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	items := badgesResponse(badges)
+	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
+}
+
+// ListMemberBadges returns badges earned by member while on a team
+func (h *Handler) ListMemberBadges(c *gin.Context) {
+	teamID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+	memberID, err := strconv.ParseInt(c.Param("member_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_member_id"})
+		return
+	}
+
+	member, err := h.memberRepo.GetByID(c.Request.Context(), memberID)
+	if err != nil || member.TeamID != teamID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "member_not_found"})
+		return
+	}
+
+	badges, err := h.badgeRepo.GetByMemberID(c.Request.Context(), memberID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	items := badgesResponse(badges)
+	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
+}
+
+func badgesResponse(badges []*domain.Badge) []BadgeResponse {
+	items := make([]BadgeResponse, 0, len(badges))
+	for _, b := range badges {
+		item := BadgeResponse{
+			Code:      b.Code,
+			TeamID:    b.TeamID,
+			ResultID:  b.ResultID,
+			AwardedAt: b.AwardedAt.Format(time.RFC3339),
+		}
+		if rule, ok := achievements.Find(b.Code); ok {
+			item.Title = rule.Title
+			item.Emoji = rule.Emoji
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
 // ListTeamMembers returns team members
 func (h *Handler) ListTeamMembers(c *gin.Context) {
 	idStr := c.Param("id")
@@ -92,20 +191,24 @@ func (h *Handler) ListTeamMembers(c *gin.Context) {
 		return
 	}
 
-	items := make([]gin.H, 0, len(members))
+	items := make([]MemberResponse, 0, len(members))
 	for _, m := range members {
-		items = append(items, gin.H{
-			"id":        m.ID,
-			"name":      m.Name,
-			"team_id":   m.TeamID,
-			"joined_at": m.JoinedAt.Format(time.RFC3339),
-			"version":   m.Version,
-		})
+		items = append(items, memberResponse(m))
 	}
 
 	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
 }
 
+func memberResponse(m *domain.Member) MemberResponse {
+	return MemberResponse{
+		ID:       m.ID,
+		Name:     m.Name,
+		TeamID:   m.TeamID,
+		JoinedAt: m.JoinedAt.Format(time.RFC3339),
+		Version:  m.Version,
+	}
+}
+
 // ListTeamResults returns team's tournament results
 func (h *Handler) ListTeamResults(c *gin.Context) {
 	idStr := c.Param("id")
@@ -121,19 +224,23 @@ func (h *Handler) ListTeamResults(c *gin.Context) {
 		return
 	}
 
-	items := make([]gin.H, 0, len(results))
+	items := make([]TeamResultResponse, 0, len(results))
 	for _, r := range results {
-		item := gin.H{
-			"id":            r.ID,
-			"team_id":       r.TeamID,
-			"tournament_id": r.TournamentID,
-			"place":         r.Place,
-			"recorded_at":   r.RecordedAt.Format(time.RFC3339),
-			"version":       r.Version,
+		item := TeamResultResponse{
+			ID:           r.ID,
+			TeamID:       r.TeamID,
+			TournamentID: r.TournamentID,
+			Place:        r.Place,
+			RecordedAt:   r.RecordedAt.Format(time.RFC3339),
+			Version:      r.Version,
 		}
 		if r.Tournament != nil {
-			item["tournament_name"] = r.Tournament.Name
-			item["tournament_date"] = r.Tournament.Date.Format("2006-01-02")
+			item.TournamentName = r.Tournament.Name
+			item.TournamentDate = r.Tournament.Date.Format("2006-01-02")
+			item.TournamentStartsAt = r.Tournament.Start(h.loc).Format(time.RFC3339)
+		}
+		if r.TeamNameAtDate != nil {
+			item.TeamNameAtDate = *r.TeamNameAtDate
 		}
 		items = append(items, item)
 	}
@@ -141,24 +248,218 @@ func (h *Handler) ListTeamResults(c *gin.Context) {
 	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
 }
 
-// ListTournaments returns list of tournaments
+// ListTeamNames returns team's previous names with the periods they were used
+func (h *Handler) ListTeamNames(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	history, err := h.teamRepo.GetNameHistory(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	items := make([]TeamNameResponse, 0, len(history))
+	for _, a := range history {
+		items = append(items, TeamNameResponse{
+			Name:      a.Name,
+			ValidFrom: formatTime(a.ValidFrom),
+			ValidTo:   formatTime(a.ValidTo),
+		})
+	}
+
+	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
+}
+
+// GetHeadToHead compares two teams over tournaments where both played
+func (h *Handler) GetHeadToHead(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+	otherID, err := strconv.ParseInt(c.Param("other_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_other_id"})
+		return
+	}
+	if id == otherID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "same_team"})
+		return
+	}
+
+	team, err := h.teamRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "team_not_found"})
+		return
+	}
+	other, err := h.teamRepo.GetByID(c.Request.Context(), otherID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "team_not_found"})
+		return
+	}
+
+	games, err := h.resultRepo.GetHeadToHead(c.Request.Context(), id, otherID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+	h2h := repository.NewHeadToHead(id, otherID, games)
+
+	items := make([]HeadToHeadGameResponse, 0, len(h2h.Games))
+	for _, g := range h2h.Games {
+		item := HeadToHeadGameResponse{
+			TournamentID:   g.TournamentID,
+			TournamentName: g.TournamentName,
+			TournamentDate: g.TournamentDate.Format("2006-01-02"),
+			Place:          g.Place,
+			OtherPlace:     g.OtherPlace,
+		}
+		switch {
+		case g.Place < g.OtherPlace:
+			item.WinnerTeamID = id
+		case g.Place > g.OtherPlace:
+			item.WinnerTeamID = otherID
+		}
+		items = append(items, item)
+	}
+
+	c.JSON(http.StatusOK, HeadToHeadResponse{
+		Team:         TeamRef{ID: team.ID, Name: team.Name},
+		Other:        TeamRef{ID: other.ID, Name: other.Name},
+		GamesCount:   len(h2h.Games),
+		Wins:         h2h.Wins,
+		Losses:       h2h.Losses,
+		Draws:        h2h.Draws,
+		AvgPlaceDiff: h2h.AvgPlaceDiff,
+		Games:        items,
+	})
+}
+
+// GetTeamStats returns team dashboard stats
+func (h *Handler) GetTeamStats(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	disciplineID, ok := disciplineParam(c)
+	if !ok {
+		return
+	}
+
+	if _, err := h.teamRepo.GetByID(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "team_not_found"})
+		return
+	}
+
+	stats, err := h.statsRepo.GetTeamStats(c.Request.Context(), id, disciplineID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	trend := make([]MonthlyPlaceResponse, 0, len(stats.MonthlyTrend))
+	for _, m := range stats.MonthlyTrend {
+		trend = append(trend, MonthlyPlaceResponse{
+			Month:    m.Month.Format("2006-01"),
+			Games:    m.Games,
+			AvgPlace: m.AvgPlace,
+		})
+	}
+
+	opponents := make([]OpponentResponse, 0, len(stats.FrequentOpponents))
+	for _, o := range stats.FrequentOpponents {
+		opponents = append(opponents, OpponentResponse{
+			TeamID:   o.TeamID,
+			TeamName: o.TeamName,
+			Games:    o.Games,
+		})
+	}
+
+	teammates := make([]TeammateResponse, 0, len(stats.FrequentTeammates))
+	for _, m := range stats.FrequentTeammates {
+		teammates = append(teammates, TeammateResponse{
+			MemberID: m.MemberID,
+			Name:     m.Name,
+			Games:    m.Games,
+		})
+	}
+
+	resp := TeamStatsResponse{
+		TeamID:     stats.TeamID,
+		TotalGames: stats.TotalGames,
+		Podiums: PodiumsResponse{
+			First:  stats.Podiums.First,
+			Second: stats.Podiums.Second,
+			Third:  stats.Podiums.Third,
+		},
+		BestPlace:         stats.BestPlace,
+		WorstPlace:        stats.WorstPlace,
+		AvgPlace:          stats.AvgPlace,
+		MonthlyTrend:      trend,
+		CurrentStreak:     stats.CurrentStreak,
+		LongestStreak:     stats.LongestStreak,
+		Rank:              stats.Rank,
+		RatedTeams:        stats.RatedTeams,
+		Percentile:        stats.Percentile,
+		FrequentOpponents: opponents,
+		FrequentTeammates: teammates,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListTournaments returns list of tournaments, optionally by phase
+// (?phase=upcoming|ongoing|finished), team (?team_id=), venue (?venue_id=)
+// and discipline (?discipline_id=)
 func (h *Handler) ListTournaments(c *gin.Context) {
-	tournaments, err := h.tournamentRepo.List(c.Request.Context())
+	phase := c.Query("phase")
+	teamID, err := strconv.ParseInt(c.DefaultQuery("team_id", "0"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_team_id"})
+		return
+	}
+	venueID, err := strconv.ParseInt(c.DefaultQuery("venue_id", "0"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_venue_id"})
+		return
+	}
+
+	disciplineID, ok := disciplineParam(c)
+	if !ok {
+		return
+	}
+
+	switch phase {
+	case "", domain.PhaseUpcoming, domain.PhaseOngoing, domain.PhaseFinished:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_phase"})
+		return
+	}
+
+	var tournaments []*domain.Tournament
+	if phase == "" && teamID == 0 && venueID == 0 && disciplineID == 0 {
+		tournaments, err = h.tournamentRepo.List(c.Request.Context())
+	} else {
+		filter := h.tournamentFilter(phase, teamID, 0)
+		filter.VenueID = venueID
+		filter.DisciplineID = disciplineID
+		tournaments, err = h.tournamentRepo.ListByPhase(c.Request.Context(), filter)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 		return
 	}
 
-	items := make([]gin.H, 0, len(tournaments))
+	items := make([]TournamentResponse, 0, len(tournaments))
 	for _, t := range tournaments {
-		items = append(items, gin.H{
-			"id":         t.ID,
-			"name":       t.Name,
-			"date":       t.Date.Format("2006-01-02"),
-			"location":   t.Location,
-			"created_at": t.CreatedAt.Format(time.RFC3339),
-			"version":    t.Version,
-		})
+		items = append(items, h.tournamentResponse(t))
 	}
 
 	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
@@ -179,15 +480,72 @@ func (h *Handler) GetTournament(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"id":         tournament.ID,
-		"name":       tournament.Name,
-		"date":       tournament.Date.Format("2006-01-02"),
-		"location":   tournament.Location,
-		"created_at": tournament.CreatedAt.Format(time.RFC3339),
-		"created_by": tournament.CreatedBy,
-		"version":    tournament.Version,
-	})
+	c.JSON(http.StatusOK, h.tournamentResponse(tournament))
+}
+
+// tournamentResponse includes tournament start, end and timezone; times are
+// RFC3339 with the tournament's offset
+func (h *Handler) tournamentResponse(t *domain.Tournament) TournamentResponse {
+	return TournamentResponse{
+		ID:              t.ID,
+		Name:            t.Name,
+		Date:            t.Date.Format("2006-01-02"),
+		Location:        t.Location,
+		CreatedAt:       t.CreatedAt.Format(time.RFC3339),
+		CreatedBy:       t.CreatedBy,
+		Version:         t.Version,
+		VenueID:         t.VenueID,
+		DisciplineID:    t.DisciplineID,
+		ParticipantMode: t.ParticipantMode,
+		Status:          t.Status,
+		Phase:           t.Phase(time.Now(), h.loc),
+		FinalizedAt:     formatTime(t.FinalizedAt),
+		StartsAt:        t.Start(h.loc).Format(time.RFC3339),
+		EndsAt:          t.End(h.loc).Format(time.RFC3339),
+		AllDay:          t.StartsAt == nil,
+		DurationMinutes: t.DurationMinutes,
+		Timezone:        t.Zone(h.loc).String(),
+	}
+}
+
+// tournamentFilter builds a phase filter relative to now
+func (h *Handler) tournamentFilter(phase string, teamID int64, limit int) repository.TournamentFilter {
+	return repository.TournamentFilter{
+		Phase:           phase,
+		TeamID:          teamID,
+		Now:             time.Now(),
+		DefaultTimezone: h.loc.String(),
+		Limit:           limit,
+	}
+}
+
+// GetNextTournament returns the ongoing tournament or, if none, the nearest
+// upcoming one (?team_id= limits to the team's tournaments)
+func (h *Handler) GetNextTournament(c *gin.Context) {
+	teamID, err := strconv.ParseInt(c.DefaultQuery("team_id", "0"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_team_id"})
+		return
+	}
+
+	var next *domain.Tournament
+	for _, phase := range []string{domain.PhaseOngoing, domain.PhaseUpcoming} {
+		tournaments, err := h.tournamentRepo.ListByPhase(c.Request.Context(), h.tournamentFilter(phase, teamID, 1))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+			return
+		}
+		if len(tournaments) > 0 {
+			next = tournaments[0]
+			break
+		}
+	}
+	if next == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no_upcoming_tournament"})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.tournamentResponse(next))
 }
 
 // ListTournamentResults returns tournament results
@@ -205,54 +563,477 @@ func (h *Handler) ListTournamentResults(c *gin.Context) {
 		return
 	}
 
-	items := make([]gin.H, 0, len(results))
+	items := tournamentResultItems(results)
+	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
+}
+
+// LiveTournament streams tournament result changes as Server-Sent Events.
+// The first event ("snapshot") carries current standings.
+func (h *Handler) LiveTournament(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	if _, err := h.tournamentRepo.GetByID(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "tournament_not_found"})
+		return
+	}
+
+	// Subscribe before reading standings so no change is missed in between
+	events, unsubscribe := h.live.Subscribe(id)
+	defer unsubscribe()
+
+	results, err := h.resultRepo.GetByTournamentID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.SSEvent("snapshot", tournamentResultItems(results))
+
+	heartbeat := time.NewTicker(liveHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case e, ok := <-events:
+			if !ok {
+				// Fell behind: client reconnects and gets a fresh snapshot
+				return false
+			}
+			c.SSEvent(e.Type, e)
+			return e.Type != live.EventTournamentDeleted
+		case <-heartbeat.C:
+			c.SSEvent("ping", gin.H{"at": time.Now().Format(time.RFC3339)})
+			return true
+		}
+	})
+}
+
+func tournamentResultItems(results []*domain.Result) []TournamentResultResponse {
+	items := make([]TournamentResultResponse, 0, len(results))
 	for _, r := range results {
-		item := gin.H{
-			"id":            r.ID,
-			"team_id":       r.TeamID,
-			"tournament_id": r.TournamentID,
-			"place":         r.Place,
-			"recorded_at":   r.RecordedAt.Format(time.RFC3339),
-			"version":       r.Version,
+		item := TournamentResultResponse{
+			ID:           r.ID,
+			TeamID:       r.TeamID,
+			TournamentID: r.TournamentID,
+			Place:        r.Place,
+			RecordedAt:   r.RecordedAt.Format(time.RFC3339),
+			Version:      r.Version,
 		}
 		if r.Team != nil {
-			item["team_name"] = r.Team.Name
+			// Name the team used at that tournament
+			item.TeamName = r.DisplayTeamName()
+			item.CurrentTeamName = r.Team.Name
+		}
+		if r.PlayerID != 0 {
+			item.PlayerID = r.PlayerID
+			item.PlayerName = r.DisplayName()
 		}
 		items = append(items, item)
 	}
-
-	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
+	return items
 }
 
-// GetRating returns team ratings (cached)
+// GetRating returns team ratings of a discipline (see ratingDisciplineParam)
 func (h *Handler) GetRating(c *gin.Context) {
-	// Try cache first
-	var cachedRating []gin.H
-	if err := h.cache.Get(c.Request.Context(), ratingCacheKey, &cachedRating); err == nil {
-		c.JSON(http.StatusOK, NewListResponse(cachedRating, 50, 0, len(cachedRating)))
+	disciplineID, ok := h.ratingDisciplineParam(c)
+	if !ok {
 		return
 	}
 
-	// Get from DB
-	ratings, err := h.resultRepo.GetTeamRating(c.Request.Context())
+	ratings, err := h.resultRepo.GetTeamRating(c.Request.Context(), disciplineID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 		return
 	}
 
-	items := make([]gin.H, 0, len(ratings))
+	items := make([]RatingResponse, 0, len(ratings))
 	for _, r := range ratings {
-		items = append(items, gin.H{
-			"team_id":     r.TeamID,
-			"team_name":   r.TeamName,
-			"top_places":  r.Wins,
-			"total_games": r.TotalGames,
-			"avg_place":   r.AvgPlace,
+		items = append(items, RatingResponse{
+			TeamID:     r.TeamID,
+			TeamName:   r.TeamName,
+			TopPlaces:  r.Wins,
+			TotalGames: r.TotalGames,
+			AvgPlace:   r.AvgPlace,
+		})
+	}
+
+	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
+}
+
+// ListTournamentRegistrations returns teams registered for a tournament
+func (h *Handler) ListTournamentRegistrations(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	regs, err := h.tournamentRepo.ListRegistrations(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	items := make([]RegistrationResponse, 0, len(regs))
+	for _, r := range regs {
+		item := RegistrationResponse{
+			TournamentID: r.TournamentID,
+			TeamID:       r.TeamID,
+			RegisteredAt: r.RegisteredAt.Format(time.RFC3339),
+		}
+		if r.Team != nil {
+			item.TeamName = r.Team.Name
+		}
+		items = append(items, item)
+	}
+
+	c.JSON(http.StatusOK, NewListResponse(items, len(items), 0, len(items)))
+}
+
+// GetCalendarLinks returns subscription URLs of the tournament feed and,
+// with team_id, of the team feed
+func (h *Handler) GetCalendarLinks(c *gin.Context) {
+	resp := CalendarLinksResponse{URL: h.calendarURL(c, 0)}
+
+	if raw := c.Query("team_id"); raw != "" {
+		teamID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_team_id"})
+			return
+		}
+		if _, err := h.teamRepo.GetByID(c.Request.Context(), teamID); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "team_not_found"})
+			return
+		}
+		resp.TeamURL = h.calendarURL(c, teamID)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// calendarURL returns absolute feed URL with token
+func (h *Handler) calendarURL(c *gin.Context, teamID int64) string {
+	scheme := "https"
+	if c.Request.TLS == nil && c.GetHeader("X-Forwarded-Proto") != "https" {
+		scheme = "http"
+	}
+	path := "/api/v1/public/calendar.ics"
+	if teamID > 0 {
+		path = fmt.Sprintf("/api/v1/public/teams/%d/calendar.ics", teamID)
+	}
+	return fmt.Sprintf("%s://%s%s?token=%s", scheme, c.Request.Host, path, h.calendar.Token(calendar.Scope(teamID)))
+}
+
+// GetCalendar serves iCalendar feed of all tournaments (token auth)
+func (h *Handler) GetCalendar(c *gin.Context) {
+	if !h.calendar.Verify(calendar.Scope(0), c.Query("token")) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid_token"})
+		return
+	}
+	h.serveCalendar(c, "Турниры", 0)
+}
+
+// GetTeamCalendar serves iCalendar feed of tournaments the team is
+// registered for or played (token auth)
+func (h *Handler) GetTeamCalendar(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+	if !h.calendar.Verify(calendar.Scope(id), c.Query("token")) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid_token"})
+		return
+	}
+
+	team, err := h.teamRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "team_not_found"})
+		return
+	}
+	h.serveCalendar(c, "Турниры: "+team.Name, id)
+}
+
+func (h *Handler) serveCalendar(c *gin.Context, name string, teamID int64) {
+	from := time.Now().AddDate(0, 0, -calendarPastDays)
+	tournaments, err := h.tournamentRepo.ListSince(c.Request.Context(), from, teamID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	events := make([]calendar.Event, 0, len(tournaments))
+	for _, t := range tournaments {
+		updated := t.CreatedAt
+		if t.UpdatedAt != nil {
+			updated = *t.UpdatedAt
+		}
+		link := h.calendar.TournamentLink(t.ID)
+		events = append(events, calendar.Event{
+			UID:         calendar.TournamentUID(t.ID),
+			Start:       t.Start(h.loc),
+			End:         t.End(h.loc),
+			AllDay:      t.StartsAt == nil,
+			Summary:     t.Name,
+			Location:    t.Location,
+			Description: link,
+			URL:         link,
+			Updated:     updated,
 		})
 	}
 
-	// Cache result
-	_ = h.cache.Set(c.Request.Context(), ratingCacheKey, items, ratingCacheTTL)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar.Render(name, events))
+}
+
+// ListVenues returns all venues
+func (h *Handler) ListVenues(c *gin.Context) {
+	venues, err := h.venueRepo.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	items := make([]VenueResponse, 0, len(venues))
+	for _, v := range venues {
+		items = append(items, venueResponse(v))
+	}
+
+	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
+}
+
+// GetVenue returns venue details
+func (h *Handler) GetVenue(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	venue, err := h.venueRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "venue_not_found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, venueResponse(venue))
+}
+
+// GetVenueStats returns tournaments hosted and average attendance
+func (h *Handler) GetVenueStats(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	disciplineID, ok := disciplineParam(c)
+	if !ok {
+		return
+	}
+
+	if _, err := h.venueRepo.GetByID(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "venue_not_found"})
+		return
+	}
+
+	stats, err := h.statsRepo.GetVenueStats(c.Request.Context(), id, disciplineID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	resp := VenueStatsResponse{
+		VenueID:           stats.VenueID,
+		TournamentsHosted: stats.TournamentsHosted,
+		AvgAttendance:     stats.AvgAttendance,
+	}
+	if stats.LastTournament != nil {
+		resp.LastTournament = stats.LastTournament.Format("2006-01-02")
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func venueResponse(v *domain.Venue) VenueResponse {
+	return VenueResponse{
+		ID:        v.ID,
+		Name:      v.Name,
+		Address:   v.Address,
+		Latitude:  v.Latitude,
+		Longitude: v.Longitude,
+		Notes:     v.Notes,
+		CreatedAt: v.CreatedAt.Format(time.RFC3339),
+		Version:   v.Version,
+	}
+}
+
+// disciplineParam parses optional ?discipline_id=, writing 400 on error
+func disciplineParam(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.DefaultQuery("discipline_id", "0"), 10, 64)
+	if err != nil || id < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_discipline_id"})
+		return 0, false
+	}
+	return id, true
+}
+
+// ratingDisciplineParam parses ?discipline_id= of ratings: an ID, "all" (or 0)
+// for every discipline; without it - the default discipline, since places in
+// different game types don't compare. Writes the error response on failure.
+func (h *Handler) ratingDisciplineParam(c *gin.Context) (int64, bool) {
+	switch c.Query("discipline_id") {
+	case "all":
+		return 0, true
+	case "":
+		disciplines, err := h.disciplineRepo.List(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+			return 0, false
+		}
+		if len(disciplines) == 0 {
+			return 0, true
+		}
+		return disciplines[0].ID, true
+	}
+	return disciplineParam(c)
+}
+
+// ListDisciplines returns game types, the default one first
+func (h *Handler) ListDisciplines(c *gin.Context) {
+	disciplines, err := h.disciplineRepo.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	items := make([]DisciplineResponse, 0, len(disciplines))
+	for _, d := range disciplines {
+		items = append(items, disciplineResponse(d))
+	}
+
+	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
+}
+
+func disciplineResponse(d *domain.Discipline) DisciplineResponse {
+	return DisciplineResponse{
+		ID:      d.ID,
+		Name:    d.Name,
+		Version: d.Version,
+	}
+}
+
+// ListPlayers returns participants of individual tournaments
+func (h *Handler) ListPlayers(c *gin.Context) {
+	players, err := h.playerRepo.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	items := make([]PlayerResponse, 0, len(players))
+	for _, p := range players {
+		items = append(items, playerResponse(p))
+	}
+
+	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
+}
+
+// GetPlayer returns player details
+func (h *Handler) GetPlayer(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	player, err := h.playerRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "player_not_found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, playerResponse(player))
+}
+
+// ListPlayerResults returns results of a player in individual tournaments
+func (h *Handler) ListPlayerResults(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_id"})
+		return
+	}
+
+	results, err := h.resultRepo.GetByPlayerID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	items := make([]PlayerResultResponse, 0, len(results))
+	for _, r := range results {
+		item := PlayerResultResponse{
+			ID:           r.ID,
+			PlayerID:     r.PlayerID,
+			TournamentID: r.TournamentID,
+			Place:        r.Place,
+			RecordedAt:   r.RecordedAt.Format(time.RFC3339),
+			Version:      r.Version,
+		}
+		if r.Tournament != nil {
+			item.TournamentName = r.Tournament.Name
+			item.TournamentDate = r.Tournament.Date.Format("2006-01-02")
+		}
+		items = append(items, item)
+	}
+
+	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
+}
+
+// GetPlayerRating returns player ratings, like GetRating
+func (h *Handler) GetPlayerRating(c *gin.Context) {
+	disciplineID, ok := h.ratingDisciplineParam(c)
+	if !ok {
+		return
+	}
+
+	ratings, err := h.resultRepo.GetPlayerRating(c.Request.Context(), disciplineID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	items := make([]PlayerRatingResponse, 0, len(ratings))
+	for _, r := range ratings {
+		items = append(items, PlayerRatingResponse{
+			PlayerID:   r.PlayerID,
+			PlayerName: r.PlayerName,
+			TopPlaces:  r.Wins,
+			TotalGames: r.TotalGames,
+			AvgPlace:   r.AvgPlace,
+		})
+	}
 
 	c.JSON(http.StatusOK, NewListResponse(items, 50, 0, len(items)))
 }
+
+func playerResponse(p *domain.Player) PlayerResponse {
+	return PlayerResponse{
+		ID:         p.ID,
+		Name:       p.Name,
+		MemberID:   p.MemberID,
+		TelegramID: p.TelegramID,
+		CreatedAt:  p.CreatedAt.Format(time.RFC3339),
+		Version:    p.Version,
+	}
+}
@@ -0,0 +1,135 @@
+// internal/api/handlers/public_test.go
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// fakeMemberRepo keeps one member; other methods are not used
+type fakeMemberRepo struct {
+	repository.MemberRepository
+	member *domain.Member
+}
+
+func (r *fakeMemberRepo) GetByID(_ context.Context, id int64) (*domain.Member, error) {
+	if id != r.member.ID {
+		return nil, sql.ErrNoRows
+	}
+	return r.member, nil
+}
+
+// fakeBadgeRepo returns no badges; other methods are not used
+type fakeBadgeRepo struct {
+	repository.BadgeRepository
+}
+
+func (r *fakeBadgeRepo) GetByMemberID(_ context.Context, _ int64) ([]*domain.Badge, error) {
+	return nil, nil
+}
+
+func TestListMemberBadges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		teamID   string
+		memberID string
+		wantCode int
+	}{
+		{"member of the team", "3", "11", http.StatusOK},
+		{"member of another team", "4", "11", http.StatusNotFound},
+		{"unknown member", "3", "12", http.StatusNotFound},
+		{"invalid team id", "x", "11", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				memberRepo: &fakeMemberRepo{member: &domain.Member{ID: 11, TeamID: 3, Name: "Аня"}},
+				badgeRepo:  &fakeBadgeRepo{},
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/public/teams/"+tt.teamID+"/members/"+tt.memberID+"/badges", nil)
+			c.Params = gin.Params{{Key: "id", Value: tt.teamID}, {Key: "member_id", Value: tt.memberID}}
+
+			h.ListMemberBadges(c)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("expected %d, got %d: %s", tt.wantCode, w.Code, w.Body)
+			}
+		})
+	}
+}
+
+// fakeDisciplineRepo lists disciplines, the default first
+type fakeDisciplineRepo struct {
+	repository.DisciplineRepository
+	disciplines []*domain.Discipline
+}
+
+func (r *fakeDisciplineRepo) List(_ context.Context) ([]*domain.Discipline, error) {
+	return r.disciplines, nil
+}
+
+// fakeRatingRepo records the discipline the rating was asked for
+type fakeRatingRepo struct {
+	repository.ResultRepository
+	disciplineID int64
+}
+
+func (r *fakeRatingRepo) GetTeamRating(_ context.Context, disciplineID int64) ([]repository.TeamRating, error) {
+	r.disciplineID = disciplineID
+	return nil, nil
+}
+
+func TestGetRatingDiscipline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		query       string
+		disciplines []*domain.Discipline
+		wantCode    int
+		want        int64
+	}{
+		{"default discipline", "", []*domain.Discipline{{ID: 2}, {ID: 5}}, http.StatusOK, 2},
+		{"no disciplines", "", nil, http.StatusOK, 0},
+		{"explicit discipline", "?discipline_id=5", []*domain.Discipline{{ID: 2}, {ID: 5}}, http.StatusOK, 5},
+		{"all disciplines", "?discipline_id=all", []*domain.Discipline{{ID: 2}, {ID: 5}}, http.StatusOK, 0},
+		{"zero is all", "?discipline_id=0", []*domain.Discipline{{ID: 2}, {ID: 5}}, http.StatusOK, 0},
+		{"invalid", "?discipline_id=x", []*domain.Discipline{{ID: 2}}, http.StatusBadRequest, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := &fakeRatingRepo{disciplineID: -1}
+			h := &Handler{
+				resultRepo:     results,
+				disciplineRepo: &fakeDisciplineRepo{disciplines: tt.disciplines},
+			}
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/public/rating"+tt.query, nil)
+
+			h.GetRating(c)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, w.Code, w.Body)
+			}
+			if results.disciplineID != tt.want {
+				t.Errorf("expected rating of discipline %d, got %d", tt.want, results.disciplineID)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// internal/api/handlers/session.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/api/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateSession exchanges validated initData or Login Widget payload for a
+// session token
+func (h *Handler) CreateSession(c *gin.Context) {
+	// Session and API tokens can't mint new sessions
+	switch middleware.GetAuthMethod(c) {
+	case middleware.AuthInitData, middleware.AuthLoginWidget, middleware.AuthDev:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "init_data_required"})
+		return
+	}
+
+	user := middleware.GetUser(c)
+
+	tokens, err := h.sessions.Issue(c.Request.Context(), user.TelegramID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, sessionResponse(tokens))
+}
+
+type RefreshSessionRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// RefreshSession issues a new session token; the refresh token is rotated
+func (h *Handler) RefreshSession(c *gin.Context) {
+	var req RefreshSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+		return
+	}
+
+	tokens, err := h.sessions.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		code := "invalid_refresh_token"
+		if errors.Is(err, middleware.ErrRevokedSession) {
+			code = "session_revoked"
+		} else if !errors.Is(err, middleware.ErrInvalidSession) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": code})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessionResponse(tokens))
+}
+
+func sessionResponse(t *middleware.SessionTokens) SessionResponse {
+	return SessionResponse{
+		AccessToken:  t.AccessToken,
+		RefreshToken: t.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresAt:    t.ExpiresAt.Format(time.RFC3339),
+	}
+}
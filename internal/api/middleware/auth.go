@@ -7,6 +7,7 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -32,8 +33,18 @@ const (
 	ReplayTTL = 1 * time.Second
 
 	// Context keys
-	ContextKeyUser     = "user"
-	ContextKeyInitData = "initData"
+	ContextKeyUser       = "user"
+	ContextKeyInitData   = "initData"
+	ContextKeyAuthMethod = "authMethod"
+)
+
+// How the request was authenticated (ContextKeyAuthMethod)
+const (
+	AuthDev         = "dev"
+	AuthInitData    = "init_data"
+	AuthLoginWidget = "login_widget"
+	AuthSession     = "session"
+	AuthAPIToken    = "api_token"
 )
 
 var (
@@ -65,13 +76,16 @@ type InitDataUser struct {
 type AuthMiddleware struct {
 	botToken  string
 	userRepo  repository.UserRepository
-	cache     *cache.Cache
+	roleRepo  repository.RoleRepository
+	tokenRepo repository.APITokenRepository
+	sessions  *Sessions
+	cache     keyStore
 	secretKey []byte
 	devMode   bool
 	devUserID int64
 }
 
-func NewAuthMiddleware(botToken string, userRepo repository.UserRepository, cache *cache.Cache) *AuthMiddleware {
+func NewAuthMiddleware(botToken string, userRepo repository.UserRepository, roleRepo repository.RoleRepository, tokenRepo repository.APITokenRepository, sessions *Sessions, cache *cache.Cache) *AuthMiddleware {
 	// Compute secret key: HMAC_SHA256(bot_token, "WebAppData")
 	h := hmac.New(sha256.New, []byte("WebAppData"))
 	h.Write([]byte(botToken))
@@ -80,6 +94,9 @@ func NewAuthMiddleware(botToken string, userRepo repository.UserRepository, cach
 	return &AuthMiddleware{
 		botToken:  botToken,
 		userRepo:  userRepo,
+		roleRepo:  roleRepo,
+		tokenRepo: tokenRepo,
+		sessions:  sessions,
 		cache:     cache,
 		secretKey: secretKey,
 		devMode:   false,
@@ -93,28 +110,46 @@ func (m *AuthMiddleware) EnableDevMode(userID int64) {
 	m.devUserID = userID
 }
 
-// Authenticate validates Telegram initData and loads user
+// Authenticate validates Telegram initData, a session token or an API token
+// and loads user
 func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Dev mode: bypass authentication
 		if m.devMode {
-			user, err := m.userRepo.GetOrCreate(c.Request.Context(), m.devUserID, "dev_user")
+			user, err := m.userRepo.GetOrCreate(c.Request.Context(), m.devUserID, "dev_user", "Dev", "")
+			if err == nil {
+				err = m.loadPermissions(c.Request.Context(), user)
+			}
 			if err != nil {
 				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 				return
 			}
+			if rejectBlocked(c, user) {
+				return
+			}
 			c.Set(ContextKeyUser, user)
+			c.Set(ContextKeyAuthMethod, AuthDev)
 			c.Next()
 			return
 		}
 
-		// Get Authorization header: "TMA <initData>"
+		// Get Authorization header: "TMA <initData>" or "Bearer <token>"
+		// (session token ses_..., API token amb_...)
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing_authorization"})
 			return
 		}
 
+		if token, ok := strings.CutPrefix(authHeader, "Bearer "); ok {
+			if strings.HasPrefix(token, SessionPrefix) {
+				m.authenticateSession(c, token)
+			} else {
+				m.authenticateToken(c, token)
+			}
+			return
+		}
+
 		if !strings.HasPrefix(authHeader, "TMA ") {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid_authorization_format"})
 			return
@@ -147,7 +182,8 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 			return
 		}
 
-		// Check replay attack for mutations
+		// Check replay attack for mutations; a retry with the same
+		// Idempotency-Key passes to get the first response replayed
 		if c.Request.Method != http.MethodGet && initData.QueryID != "" {
 			// Read body and compute hash for replay key
 			bodyBytes, _ := io.ReadAll(c.Request.Body)
@@ -159,47 +195,172 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 				bodyHash = hex.EncodeToString(h[:8]) // First 8 bytes is enough
 			}
 
-			if err := m.checkReplay(c.Request.Context(), initData.QueryID, c.Request.Method, c.Request.URL.Path, bodyHash); err != nil {
+			if err := m.checkReplay(c.Request.Context(), initData.QueryID, c.Request.Method, c.Request.URL.RequestURI(), bodyHash, c.GetHeader(IdempotencyHeader)); err != nil {
 				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "replay_detected"})
 				return
 			}
 		}
 
 		// Get or create user
-		user, err := m.userRepo.GetOrCreate(c.Request.Context(), initData.User.ID, initData.User.Username)
+		user, err := m.userRepo.GetOrCreate(c.Request.Context(), initData.User.ID, initData.User.Username, initData.User.FirstName, initData.User.LastName)
+		if err == nil {
+			err = m.loadPermissions(c.Request.Context(), user)
+		}
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 			return
 		}
+		if rejectBlocked(c, user) {
+			return
+		}
 
 		// Store in context
 		c.Set(ContextKeyUser, user)
 		c.Set(ContextKeyInitData, initData)
+		c.Set(ContextKeyAuthMethod, AuthInitData)
 
 		c.Next()
 	}
 }
 
-// RequireOrganizer checks if user has organizer or admin role
-func (m *AuthMiddleware) RequireOrganizer() gin.HandlerFunc {
+// StreamTokenParam - query parameter with a session token on event streams
+const StreamTokenParam = "access_token"
+
+// AuthenticateStream is Authenticate for event streams: EventSource can't
+// send headers, so without Authorization a session token (ses_..., expires
+// in SessionTTL) is taken from the access_token query parameter. Long-lived
+// credentials (API tokens, initData) aren't accepted in the URL.
+func (m *AuthMiddleware) AuthenticateStream() gin.HandlerFunc {
+	authenticate := m.Authenticate()
 	return func(c *gin.Context) {
-		user := GetUser(c)
-		if user == nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		token := c.Query(StreamTokenParam)
+		if m.devMode || token == "" || c.GetHeader("Authorization") != "" {
+			authenticate(c)
 			return
 		}
+		if !strings.HasPrefix(token, SessionPrefix) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid_session"})
+			return
+		}
+		m.authenticateSession(c, token)
+	}
+}
 
-		if !user.CanManage() {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+// authenticateSession loads the user of a session token
+func (m *AuthMiddleware) authenticateSession(c *gin.Context, token string) {
+	ctx := c.Request.Context()
+
+	telegramID, err := m.sessions.Verify(ctx, token, time.Now())
+	if err != nil {
+		code := "invalid_session"
+		switch {
+		case errors.Is(err, ErrExpiredSession):
+			code = "session_expired"
+		case errors.Is(err, ErrRevokedSession):
+			code = "session_revoked"
+		case !errors.Is(err, ErrInvalidSession):
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
 			return
 		}
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": code})
+		return
+	}
 
-		c.Next()
+	user, err := m.userRepo.GetByTelegramID(ctx, telegramID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid_session"})
+		return
+	}
+	if rejectBlocked(c, user) {
+		return
+	}
+	if err := m.loadPermissions(ctx, user); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
 	}
+
+	// Best effort: activity tracking must not fail the request
+	_ = m.userRepo.TouchLastSeen(ctx, telegramID, time.Now())
+
+	c.Set(ContextKeyUser, user)
+	c.Set(ContextKeyAuthMethod, AuthSession)
+	c.Next()
 }
 
-// RequireAdmin checks if user has admin role
-func (m *AuthMiddleware) RequireAdmin() gin.HandlerFunc {
+// authenticateToken loads the API token and acts as its creator, with role
+// capped by token scopes
+func (m *AuthMiddleware) authenticateToken(c *gin.Context, raw string) {
+	ctx := c.Request.Context()
+
+	token, err := m.tokenRepo.GetByHash(ctx, HashToken(raw))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid_token"})
+		return
+	}
+
+	now := time.Now()
+	if !token.Active(now) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token_expired"})
+		return
+	}
+
+	if !TokenAllows(token, c.Request.Method, c.FullPath()) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient_scope"})
+		return
+	}
+
+	creator, err := m.userRepo.GetByTelegramID(ctx, token.CreatedBy)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid_token"})
+		return
+	}
+	if rejectBlocked(c, creator) {
+		return
+	}
+	if err := m.loadPermissions(ctx, creator); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+		return
+	}
+
+	// Best effort: usage tracking must not fail the request
+	_ = m.tokenRepo.TouchLastUsed(ctx, token.ID, now)
+
+	user := *creator
+	user.Role = token.Role(creator.Role)
+	user.Permissions = token.Permissions(creator.Permissions)
+
+	c.Set(ContextKeyUser, &user)
+	c.Set(ContextKeyToken, token)
+	c.Set(ContextKeyAuthMethod, AuthAPIToken)
+
+	c.Next()
+}
+
+// loadPermissions fills user permissions from the user's role
+func (m *AuthMiddleware) loadPermissions(ctx context.Context, user *domain.User) error {
+	role, err := m.roleRepo.GetByName(ctx, string(user.Role))
+	if err != nil {
+		return err
+	}
+	user.Permissions = role.Grants()
+	return nil
+}
+
+// rejectBlocked aborts the request of a blocked user with 403 user_blocked
+func rejectBlocked(c *gin.Context, user *domain.User) bool {
+	if !user.IsBlocked(time.Now()) {
+		return false
+	}
+	resp := gin.H{"error": "user_blocked", "reason": user.BlockedReason}
+	if user.BlockedUntil != nil {
+		resp["blocked_until"] = user.BlockedUntil.Format(time.RFC3339)
+	}
+	c.AbortWithStatusJSON(http.StatusForbidden, resp)
+	return true
+}
+
+// RequirePermission checks if user's role grants the permission
+func (m *AuthMiddleware) RequirePermission(perm domain.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		user := GetUser(c)
 		if user == nil {
@@ -207,7 +368,7 @@ func (m *AuthMiddleware) RequireAdmin() gin.HandlerFunc {
 			return
 		}
 
-		if !user.IsAdmin() {
+		if !user.Can(perm) {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 			return
 		}
@@ -374,21 +535,28 @@ func (m *AuthMiddleware) validateHash(raw, hash string) bool {
 
 // checkReplay checks if exact request was already made (replay attack)
 // Uses query_id + method + path + bodyHash to allow different requests in same session
-func (m *AuthMiddleware) checkReplay(ctx context.Context, queryID, method, path, bodyHash string) error {
+func (m *AuthMiddleware) checkReplay(ctx context.Context, queryID, method, path, bodyHash, idemKey string) error {
 	key := fmt.Sprintf("replay:%s:%s:%s:%s", queryID, method, path, bodyHash)
 
+	// The request is bound to the Idempotency-Key it first came with:
+	// only a retry with the same key gets through
+	value, _ := json.Marshal(idemKey)
+
 	// Try to set with NX (only if not exists)
-	set, err := m.cache.SetNX(ctx, key, "1", ReplayTTL)
+	set, err := m.cache.SetNX(ctx, key, string(value), ReplayTTL)
 	if err != nil {
 		// If Redis error, allow request (fail open)
 		return nil
 	}
-
-	if !set {
-		return ErrReplayAttack
+	if set {
+		return nil
 	}
 
-	return nil
+	var first string
+	if idemKey != "" && m.cache.Get(ctx, key, &first) == nil && first == idemKey {
+		return nil
+	}
+	return ErrReplayAttack
 }
 
 // GetUser extracts user from gin context
@@ -400,6 +568,11 @@ func GetUser(c *gin.Context) *domain.User {
 	return user.(*domain.User)
 }
 
+// GetAuthMethod returns how the request was authenticated (Auth* constants)
+func GetAuthMethod(c *gin.Context) string {
+	return c.GetString(ContextKeyAuthMethod)
+}
+
 // GetInitData extracts initData from gin context
 func GetInitData(c *gin.Context) *InitData {
 	data, exists := c.Get(ContextKeyInitData)
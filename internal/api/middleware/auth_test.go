@@ -2,15 +2,23 @@
 package middleware
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
+	"database/sql"
 	"encoding/hex"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"sort"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+	"github.com/gin-gonic/gin"
 )
 
 func TestParseUserJSON(t *testing.T) {
@@ -296,3 +304,75 @@ func TestAuthDateTTL(t *testing.T) {
 		})
 	}
 }
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	users map[int64]*domain.User
+}
+
+func (r *fakeUserRepo) GetByTelegramID(_ context.Context, id int64) (*domain.User, error) {
+	if u, ok := r.users[id]; ok {
+		return u, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *fakeUserRepo) TouchLastSeen(context.Context, int64, time.Time) error {
+	return nil
+}
+
+type fakeRoleRepo struct {
+	repository.RoleRepository
+}
+
+func (fakeRoleRepo) GetByName(_ context.Context, name string) (*domain.RoleDef, error) {
+	return &domain.RoleDef{Name: name}, nil
+}
+
+func TestAuthenticateStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	sessions := &Sessions{secret: []byte("test_secret"), cache: newFakeStore()}
+	m := &AuthMiddleware{
+		userRepo: &fakeUserRepo{users: map[int64]*domain.User{
+			123456789: {TelegramID: 123456789, Role: domain.RoleViewer},
+		}},
+		roleRepo: fakeRoleRepo{},
+		sessions: sessions,
+	}
+	tokens, err := sessions.Issue(ctx, 123456789)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		header string
+		want   int
+	}{
+		{"session token in query", "?access_token=" + tokens.AccessToken, "", http.StatusOK},
+		{"session token in header", "", "Bearer " + tokens.AccessToken, http.StatusOK},
+		{"api token in query", "?access_token=amb_secret", "", http.StatusUnauthorized},
+		{"tampered session token", "?access_token=" + tokens.AccessToken + "0", "", http.StatusUnauthorized},
+		{"no credentials", "", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/live", m.AuthenticateStream(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+			req := httptest.NewRequest(http.MethodGet, "/live"+tt.query, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d (%s)", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
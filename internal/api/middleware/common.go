@@ -26,7 +26,8 @@ func Logger() gin.HandlerFunc {
 	}
 }
 
-// Timeout adds request timeout
+// Timeout adds request timeout; long-lived routes (event streams) must be
+// registered without it
 func Timeout(timeout time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
@@ -60,7 +61,7 @@ func CORS() gin.HandlerFunc {
 			c.Header("Access-Control-Allow-Origin", origin)
 			c.Header("Access-Control-Allow-Credentials", "true")
 			c.Header("Access-Control-Allow-Methods", "GET, POST, PATCH, PUT, DELETE, OPTIONS")
-			c.Header("Access-Control-Allow-Headers", "Authorization, Content-Type")
+			c.Header("Access-Control-Allow-Headers", "Authorization, Content-Type, Idempotency-Key")
 			c.Header("Access-Control-Max-Age", "86400")
 		}
 
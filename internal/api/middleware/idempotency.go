@@ -0,0 +1,175 @@
+// internal/api/middleware/idempotency.go
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/cache"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyHeader         = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+	IdempotencyTTL            = 24 * time.Hour
+	// IdempotencyLockTTL bounds how long a crashed request keeps its key busy
+	IdempotencyLockTTL = time.Minute
+	maxIdempotencyKey  = 255
+)
+
+// storedResponse is the first response for an idempotency key
+type storedResponse struct {
+	Fingerprint string `json:"fingerprint"`
+	Pending     bool   `json:"pending,omitempty"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// keyStore is the part of cache.Cache used for replay and idempotency keys
+type keyStore interface {
+	SetNX(ctx context.Context, key string, value string, ttl time.Duration) (bool, error)
+	Get(ctx context.Context, key string, dest any) error
+	Set(ctx context.Context, key string, value any, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+type IdempotencyMiddleware struct {
+	cache keyStore
+}
+
+func NewIdempotencyMiddleware(cache *cache.Cache) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{cache: cache}
+}
+
+// Handle replays the stored response of a mutation repeated with the same
+// Idempotency-Key. Keys are per user (or API token); reusing a key with
+// another method, URL or body is a conflict. Requests without the header
+// and reads pass through.
+func (m *IdempotencyMiddleware) Handle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idemKey := c.GetHeader(IdempotencyHeader)
+		if idemKey == "" || !isMutation(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if !validIdempotencyKey(idemKey) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid_idempotency_key"})
+			return
+		}
+		user := GetUser(c)
+		if user == nil {
+			c.Next()
+			return
+		}
+
+		bodyBytes, _ := io.ReadAll(c.Request.Body)
+		c.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes)) // Restore body for handlers
+		fingerprint := idempotencyFingerprint(c.Request.Method, c.Request.URL.RequestURI(), bodyBytes)
+
+		scope := fmt.Sprintf("%d", user.TelegramID)
+		if token := GetToken(c); token != nil {
+			scope = fmt.Sprintf("token:%d", token.ID)
+		}
+		key := cache.IdempotencyKey(scope, idemKey)
+
+		ctx := c.Request.Context()
+		lock, _ := json.Marshal(storedResponse{Fingerprint: fingerprint, Pending: true})
+		acquired, err := m.cache.SetNX(ctx, key, string(lock), IdempotencyLockTTL)
+		if err != nil {
+			// Fail open on Redis errors
+			c.Next()
+			return
+		}
+
+		if !acquired {
+			var stored storedResponse
+			if err := m.cache.Get(ctx, key, &stored); err != nil {
+				// Expired in between: let the client retry
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "idempotency_key_in_use"})
+				return
+			}
+			switch {
+			case stored.Fingerprint != fingerprint:
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "idempotency_key_reused"})
+			case stored.Pending:
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "idempotency_key_in_use"})
+			default:
+				c.Header(IdempotencyReplayedHeader, "true")
+				c.Data(stored.Status, stored.ContentType, stored.Body)
+				c.Abort()
+			}
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+			// Not a final answer: free the key for a retry
+			_ = m.cache.Delete(ctx, key)
+			return
+		}
+		_ = m.cache.Set(ctx, key, storedResponse{
+			Fingerprint: fingerprint,
+			Status:      status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}, IdempotencyTTL)
+	}
+}
+
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// validIdempotencyKey accepts printable ASCII up to 255 bytes (UUIDs and the like)
+func validIdempotencyKey(key string) bool {
+	if len(key) == 0 || len(key) > maxIdempotencyKey {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// idempotencyFingerprint identifies the request a key was first used with
+func idempotencyFingerprint(method, uri string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + uri + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder copies the response body while writing it to the client
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
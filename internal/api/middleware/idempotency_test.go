@@ -0,0 +1,243 @@
+// internal/api/middleware/idempotency_test.go
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/cache"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeStore is an in-memory keyStore; values are kept JSON-encoded like in Redis
+type fakeStore struct {
+	values map[string]string
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{values: make(map[string]string)}
+}
+
+func (s *fakeStore) SetNX(_ context.Context, key string, value string, _ time.Duration) (bool, error) {
+	if _, ok := s.values[key]; ok {
+		return false, nil
+	}
+	s.values[key] = value
+	return true, nil
+}
+
+func (s *fakeStore) Get(_ context.Context, key string, dest any) error {
+	value, ok := s.values[key]
+	if !ok {
+		return redis.Nil
+	}
+	return json.Unmarshal([]byte(value), dest)
+}
+
+func (s *fakeStore) GetDel(ctx context.Context, key string, dest any) error {
+	err := s.Get(ctx, key, dest)
+	delete(s.values, key)
+	return err
+}
+
+func (s *fakeStore) Incr(_ context.Context, key string) (int64, error) {
+	n, _ := strconv.ParseInt(s.values[key], 10, 64)
+	n++
+	s.values[key] = strconv.FormatInt(n, 10)
+	return n, nil
+}
+
+func (s *fakeStore) Set(_ context.Context, key string, value any, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	s.values[key] = string(data)
+	return err
+}
+
+func (s *fakeStore) Delete(_ context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(s.values, key)
+	}
+	return nil
+}
+
+func TestValidIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want bool
+	}{
+		{"uuid", "0b6f3c1e-8d4a-4f7e-9a51-2c3d4e5f6a7b", true},
+		{"empty", "", false},
+		{"max length", strings.Repeat("k", 255), true},
+		{"too long", strings.Repeat("k", 256), false},
+		{"space", "retry 1", false},
+		{"non ascii", "ключ", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validIdempotencyKey(tt.key); got != tt.want {
+				t.Errorf("validIdempotencyKey(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdempotencyFingerprint(t *testing.T) {
+	base := idempotencyFingerprint("POST", "/api/v1/private/teams", []byte(`{"name":"A"}`))
+
+	if got := idempotencyFingerprint("POST", "/api/v1/private/teams", []byte(`{"name":"A"}`)); got != base {
+		t.Error("same request must have the same fingerprint")
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"other body", "POST", "/api/v1/private/teams", `{"name":"B"}`},
+		{"other query", "POST", "/api/v1/private/teams?dry_run=1", `{"name":"A"}`},
+		{"other path", "POST", "/api/v1/private/venues", `{"name":"A"}`},
+		{"other method", "PATCH", "/api/v1/private/teams", `{"name":"A"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := idempotencyFingerprint(tt.method, tt.path, []byte(tt.body)); got == base {
+				t.Error("different request must not share the fingerprint")
+			}
+		})
+	}
+}
+
+func TestIdempotencyHandle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		uri  string
+		body string
+	}
+	tests := []struct {
+		name         string
+		requests     []request
+		pending      bool // first request is still running
+		wantStatus   int
+		wantReplayed bool
+		wantCalls    int
+	}{
+		{
+			name:         "retry gets stored response",
+			requests:     []request{{"/teams", `{"name":"A"}`}, {"/teams", `{"name":"A"}`}},
+			wantStatus:   http.StatusCreated,
+			wantReplayed: true,
+			wantCalls:    1,
+		},
+		{
+			name:       "other body",
+			requests:   []request{{"/teams", `{"name":"A"}`}, {"/teams", `{"name":"B"}`}},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCalls:  1,
+		},
+		{
+			name:       "other query",
+			requests:   []request{{"/teams", `{"name":"A"}`}, {"/teams?force=1", `{"name":"A"}`}},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCalls:  1,
+		},
+		{
+			name:       "first still running",
+			requests:   []request{{"/teams", `{"name":"A"}`}},
+			pending:    true,
+			wantStatus: http.StatusConflict,
+			wantCalls:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeStore()
+			m := &IdempotencyMiddleware{cache: store}
+			calls := 0
+
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				c.Set(ContextKeyUser, &domain.User{TelegramID: 42})
+			}, m.Handle())
+			r.POST("/teams", func(c *gin.Context) {
+				calls++
+				c.JSON(http.StatusCreated, gin.H{"id": calls})
+			})
+
+			if tt.pending {
+				lock, _ := json.Marshal(storedResponse{
+					Fingerprint: idempotencyFingerprint(http.MethodPost, "/teams", []byte(`{"name":"A"}`)),
+					Pending:     true,
+				})
+				store.values[cache.IdempotencyKey("42", "key-1")] = string(lock)
+			}
+
+			var w *httptest.ResponseRecorder
+			var first string
+			for i, req := range tt.requests {
+				w = httptest.NewRecorder()
+				httpReq := httptest.NewRequest(http.MethodPost, req.uri, strings.NewReader(req.body))
+				httpReq.Header.Set(IdempotencyHeader, "key-1")
+				r.ServeHTTP(w, httpReq)
+				if i == 0 {
+					first = w.Body.String()
+				}
+			}
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body)
+			}
+			if replayed := w.Header().Get(IdempotencyReplayedHeader) == "true"; replayed != tt.wantReplayed {
+				t.Errorf("expected replayed %v, got %v", tt.wantReplayed, replayed)
+			}
+			if tt.wantReplayed && w.Body.String() != first {
+				t.Errorf("expected first body %s, got %s", first, w.Body)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("expected %d handler calls, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestCheckReplay(t *testing.T) {
+	tests := []struct {
+		name    string
+		first   string // Idempotency-Key of the first request
+		second  string
+		wantErr bool
+	}{
+		{"no keys", "", "", true},
+		{"retry with the same key", "key-1", "key-1", false},
+		{"key added to a replay", "", "key-1", true},
+		{"other key", "key-1", "key-2", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &AuthMiddleware{cache: newFakeStore()}
+			ctx := context.Background()
+
+			if err := m.checkReplay(ctx, "AAQ", "POST", "/teams", "abc", tt.first); err != nil {
+				t.Fatalf("first request: %v", err)
+			}
+			err := m.checkReplay(ctx, "AAQ", "POST", "/teams", "abc", tt.second)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
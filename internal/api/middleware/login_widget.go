@@ -0,0 +1,105 @@
+// internal/api/middleware/login_widget.go
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoginWidgetTTL limits how old a Login Widget payload may be
+const LoginWidgetTTL = 24 * time.Hour
+
+// LoginWidgetData is the user object Telegram Login Widget passes to the
+// page (browser sign-in outside Telegram)
+type LoginWidgetData struct {
+	ID        int64  `json:"id" binding:"required"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Username  string `json:"username"`
+	PhotoURL  string `json:"photo_url"`
+	AuthDate  int64  `json:"auth_date" binding:"required"`
+	Hash      string `json:"hash" binding:"required"`
+}
+
+// dataCheckString joins received fields except hash as sorted "key=value"
+// lines; fields the widget did not send are left out
+func (d *LoginWidgetData) dataCheckString() string {
+	fields := map[string]string{
+		"id":         strconv.FormatInt(d.ID, 10),
+		"first_name": d.FirstName,
+		"last_name":  d.LastName,
+		"username":   d.Username,
+		"photo_url":  d.PhotoURL,
+		"auth_date":  strconv.FormatInt(d.AuthDate, 10),
+	}
+
+	var keys []string
+	for k, v := range fields {
+		if v != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, fields[k]))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// validateLoginWidget checks hash: unlike WebApp initData, the secret key
+// is SHA256(bot_token)
+func (m *AuthMiddleware) validateLoginWidget(d *LoginWidgetData) bool {
+	secret := sha256.Sum256([]byte(m.botToken))
+	h := hmac.New(sha256.New, secret[:])
+	h.Write([]byte(d.dataCheckString()))
+	computed := hex.EncodeToString(h.Sum(nil))
+
+	return hmac.Equal([]byte(computed), []byte(d.Hash))
+}
+
+// AuthenticateLoginWidget validates Login Widget payload from the JSON body
+// and loads user; used to exchange it for a session
+func (m *AuthMiddleware) AuthenticateLoginWidget() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var data LoginWidgetData
+		if err := c.ShouldBindJSON(&data); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "validation_error", "details": err.Error()})
+			return
+		}
+
+		if time.Since(time.Unix(data.AuthDate, 0)) > LoginWidgetTTL {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "auth_expired"})
+			return
+		}
+
+		if !m.validateLoginWidget(&data) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid_hash"})
+			return
+		}
+
+		user, err := m.userRepo.GetOrCreate(c.Request.Context(), data.ID, data.Username, data.FirstName, data.LastName)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal_error"})
+			return
+		}
+		if rejectBlocked(c, user) {
+			return
+		}
+
+		c.Set(ContextKeyUser, user)
+		c.Set(ContextKeyAuthMethod, AuthLoginWidget)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,111 @@
+// internal/api/middleware/login_widget_test.go
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoginWidgetDataCheckString(t *testing.T) {
+	tests := []struct {
+		name string
+		data LoginWidgetData
+		want string
+	}{
+		{
+			name: "all fields",
+			data: LoginWidgetData{ID: 123456789, FirstName: "John", LastName: "Doe", Username: "johndoe", PhotoURL: "https://t.me/i/userpic/320/johndoe.jpg", AuthDate: 1700000000, Hash: "ignored"},
+			want: "auth_date=1700000000\nfirst_name=John\nid=123456789\nlast_name=Doe\nphoto_url=https://t.me/i/userpic/320/johndoe.jpg\nusername=johndoe",
+		},
+		{
+			name: "minimal user",
+			data: LoginWidgetData{ID: 42, FirstName: "Test", AuthDate: 1700000000},
+			want: "auth_date=1700000000\nfirst_name=Test\nid=42",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.data.dataCheckString(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateLoginWidget(t *testing.T) {
+	botToken := "123456:ABC-DEF1234ghIkl-zyx57W2v1u123ew11"
+
+	// Login Widget secret key is SHA256(bot_token), not HMAC("WebAppData")
+	secretKey := sha256.Sum256([]byte(botToken))
+
+	authDate := time.Now().Unix()
+	params := map[string]string{
+		"id":         "123456789",
+		"first_name": "John",
+		"username":   "johndoe",
+		"auth_date":  fmt.Sprintf("%d", authDate),
+	}
+
+	var keys []string
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, params[k]))
+	}
+	dataCheckString := strings.Join(parts, "\n")
+
+	hm := hmac.New(sha256.New, secretKey[:])
+	hm.Write([]byte(dataCheckString))
+	validHash := hex.EncodeToString(hm.Sum(nil))
+
+	mw := &AuthMiddleware{botToken: botToken}
+	valid := LoginWidgetData{ID: 123456789, FirstName: "John", Username: "johndoe", AuthDate: authDate, Hash: validHash}
+
+	t.Run("valid hash", func(t *testing.T) {
+		data := valid
+		if !mw.validateLoginWidget(&data) {
+			t.Error("expected valid hash to pass")
+		}
+	})
+
+	t.Run("invalid hash", func(t *testing.T) {
+		data := valid
+		data.Hash = "invalidhash123"
+		if mw.validateLoginWidget(&data) {
+			t.Error("expected invalid hash to fail")
+		}
+	})
+
+	t.Run("tampered data", func(t *testing.T) {
+		data := valid
+		data.AuthDate = authDate + 100
+		if mw.validateLoginWidget(&data) {
+			t.Error("expected tampered data to fail validation")
+		}
+	})
+
+	t.Run("webapp secret rejected", func(t *testing.T) {
+		// Same data signed with WebApp initData key must not pass
+		h := hmac.New(sha256.New, []byte("WebAppData"))
+		h.Write([]byte(botToken))
+		hm := hmac.New(sha256.New, h.Sum(nil))
+		hm.Write([]byte(dataCheckString))
+
+		data := valid
+		data.Hash = hex.EncodeToString(hm.Sum(nil))
+		if mw.validateLoginWidget(&data) {
+			t.Error("expected WebApp-signed data to fail validation")
+		}
+	})
+}
@@ -35,16 +35,37 @@ func (m *RateLimitMiddleware) LimitWrite() gin.HandlerFunc {
 	return m.limit(WriteRateLimit, "write")
 }
 
+// LimitIP applies the write rate limit per client IP, for routes that
+// run before a user is known (refresh token exchange)
+func (m *RateLimitMiddleware) LimitIP() gin.HandlerFunc {
+	return m.limitBy(WriteRateLimit, func(c *gin.Context) string {
+		return "ratelimit:ip:" + c.ClientIP()
+	})
+}
+
 func (m *RateLimitMiddleware) limit(maxRequests int, prefix string) gin.HandlerFunc {
-	return func(c *gin.Context) {
+	return m.limitBy(maxRequests, func(c *gin.Context) string {
 		user := GetUser(c)
 		if user == nil {
+			return ""
+		}
+		// API tokens get their own budget, separate from the creator's
+		if token := GetToken(c); token != nil {
+			return fmt.Sprintf("ratelimit:%s:token:%d", prefix, token.ID)
+		}
+		return fmt.Sprintf("ratelimit:%s:%d", prefix, user.TelegramID)
+	})
+}
+
+// limitBy counts requests per key; an empty key is not limited
+func (m *RateLimitMiddleware) limitBy(maxRequests int, keyOf func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := keyOf(c)
+		if key == "" {
 			c.Next()
 			return
 		}
 
-		key := fmt.Sprintf("ratelimit:%s:%d", prefix, user.TelegramID)
-
 		count, err := m.increment(c.Request.Context(), key)
 		if err != nil {
 			// Fail open on Redis errors
@@ -0,0 +1,179 @@
+// internal/api/middleware/session.go
+package middleware
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/cache"
+)
+
+const (
+	// SessionPrefix marks session tokens in "Authorization: Bearer"
+	SessionPrefix = "ses_"
+	refreshPrefix = "ref_"
+
+	SessionTTL = 1 * time.Hour
+	RefreshTTL = 30 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidSession = errors.New("invalid session token")
+	ErrExpiredSession = errors.New("session expired")
+	ErrRevokedSession = errors.New("session revoked")
+)
+
+// Sessions issues signed session tokens after initData validation.
+// Token: ses_<telegram_id>.<generation>.<expires_unix>.<hmac>. Refresh tokens
+// are random, kept in Dragonfly and rotated on use. Bumping the user's
+// generation (RevokeUser) invalidates both.
+type Sessions struct {
+	secret []byte
+	cache  sessionStore
+}
+
+// sessionStore is the part of cache.Cache sessions need
+type sessionStore interface {
+	Set(ctx context.Context, key string, value any, ttl time.Duration) error
+	Get(ctx context.Context, key string, dest any) error
+	GetDel(ctx context.Context, key string, dest any) error
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+// SessionTokens is the exchange/refresh response
+type SessionTokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+type refreshEntry struct {
+	TelegramID int64 `json:"telegram_id"`
+	Generation int64 `json:"generation"`
+}
+
+func NewSessions(secret string, cache *cache.Cache) *Sessions {
+	return &Sessions{secret: []byte(secret), cache: cache}
+}
+
+// Issue creates a session and refresh token pair for the user
+func (s *Sessions) Issue(ctx context.Context, telegramID int64) (*SessionTokens, error) {
+	gen, err := s.generation(ctx, telegramID)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(SessionTTL)
+
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	refresh := refreshPrefix + hex.EncodeToString(b)
+	entry := refreshEntry{TelegramID: telegramID, Generation: gen}
+	if err := s.cache.Set(ctx, refreshKey(refresh), entry, RefreshTTL); err != nil {
+		return nil, err
+	}
+
+	return &SessionTokens{
+		AccessToken:  s.sign(telegramID, gen, expiresAt),
+		RefreshToken: refresh,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// Refresh exchanges a refresh token for a new pair; the old one is spent,
+// so of concurrent refreshes with one token only the first succeeds
+func (s *Sessions) Refresh(ctx context.Context, refresh string) (*SessionTokens, error) {
+	var entry refreshEntry
+	if err := s.cache.GetDel(ctx, refreshKey(refresh), &entry); err != nil {
+		if cache.IsMiss(err) {
+			return nil, ErrInvalidSession
+		}
+		return nil, err
+	}
+
+	gen, err := s.generation(ctx, entry.TelegramID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Generation != gen {
+		return nil, ErrRevokedSession
+	}
+
+	return s.Issue(ctx, entry.TelegramID)
+}
+
+// Verify checks session token signature, expiry and revocation; returns user ID
+func (s *Sessions) Verify(ctx context.Context, token string, now time.Time) (int64, error) {
+	telegramID, gen, err := s.parse(token, now)
+	if err != nil {
+		return 0, err
+	}
+	current, err := s.generation(ctx, telegramID)
+	if err != nil {
+		return 0, err
+	}
+	if gen != current {
+		return 0, ErrRevokedSession
+	}
+	return telegramID, nil
+}
+
+// RevokeUser invalidates all sessions and refresh tokens of the user
+func (s *Sessions) RevokeUser(ctx context.Context, telegramID int64) error {
+	_, err := s.cache.Incr(ctx, cache.SessionGenerationKey(telegramID))
+	return err
+}
+
+// generation returns the user's session generation, 0 if never revoked.
+// Cache errors are returned: a revoked session must not pass as valid.
+func (s *Sessions) generation(ctx context.Context, telegramID int64) (int64, error) {
+	var gen int64
+	err := s.cache.Get(ctx, cache.SessionGenerationKey(telegramID), &gen)
+	if err != nil && !cache.IsMiss(err) {
+		return 0, err
+	}
+	return gen, nil
+}
+
+func (s *Sessions) sign(telegramID, gen int64, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%d.%d.%d", telegramID, gen, expiresAt.Unix())
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return SessionPrefix + payload + "." + hex.EncodeToString(mac.Sum(nil))
+}
+
+// parse validates signature and expiry; returns user ID and generation
+func (s *Sessions) parse(token string, now time.Time) (int64, int64, error) {
+	parts := strings.Split(strings.TrimPrefix(token, SessionPrefix), ".")
+	if !strings.HasPrefix(token, SessionPrefix) || len(parts) != 4 {
+		return 0, 0, ErrInvalidSession
+	}
+
+	telegramID, err1 := strconv.ParseInt(parts[0], 10, 64)
+	gen, err2 := strconv.ParseInt(parts[1], 10, 64)
+	exp, err3 := strconv.ParseInt(parts[2], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return 0, 0, ErrInvalidSession
+	}
+
+	if !hmac.Equal([]byte(s.sign(telegramID, gen, time.Unix(exp, 0))), []byte(token)) {
+		return 0, 0, ErrInvalidSession
+	}
+	if !now.Before(time.Unix(exp, 0)) {
+		return 0, 0, ErrExpiredSession
+	}
+	return telegramID, gen, nil
+}
+
+func refreshKey(refresh string) string {
+	return "session:refresh:" + HashToken(refresh)
+}
@@ -0,0 +1,73 @@
+// internal/api/middleware/session_test.go
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSessionParse(t *testing.T) {
+	s := NewSessions("test_secret", nil)
+	now := time.Unix(1700000000, 0)
+	valid := s.sign(123456789, 2, now.Add(SessionTTL))
+
+	tests := []struct {
+		name    string
+		token   string
+		wantID  int64
+		wantGen int64
+		wantErr error
+	}{
+		{"valid", valid, 123456789, 2, nil},
+		{"expired", s.sign(123456789, 2, now.Add(-time.Second)), 0, 0, ErrExpiredSession},
+		{"tampered user", strings.Replace(valid, "ses_123456789", "ses_123456780", 1), 0, 0, ErrInvalidSession},
+		{"other secret", NewSessions("other", nil).sign(123456789, 2, now.Add(SessionTTL)), 0, 0, ErrInvalidSession},
+		{"missing prefix", strings.TrimPrefix(valid, SessionPrefix), 0, 0, ErrInvalidSession},
+		{"garbage", "ses_a.b.c.d", 0, 0, ErrInvalidSession},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, gen, err := s.parse(tt.token, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if id != tt.wantID || gen != tt.wantGen {
+				t.Errorf("parse() = %d, %d, want %d, %d", id, gen, tt.wantID, tt.wantGen)
+			}
+		})
+	}
+}
+
+func TestSessionRefresh(t *testing.T) {
+	ctx := context.Background()
+	s := &Sessions{secret: []byte("test_secret"), cache: newFakeStore()}
+
+	first, err := s.Issue(ctx, 123456789)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := s.Refresh(ctx, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("first refresh: %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Error("refresh token must rotate")
+	}
+	if _, err := s.Refresh(ctx, first.RefreshToken); !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("spent refresh token: error = %v, want %v", err, ErrInvalidSession)
+	}
+
+	if err := s.RevokeUser(ctx, 123456789); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Refresh(ctx, second.RefreshToken); !errors.Is(err, ErrRevokedSession) {
+		t.Errorf("revoked refresh token: error = %v, want %v", err, ErrRevokedSession)
+	}
+	if _, err := s.Verify(ctx, second.AccessToken, time.Now()); !errors.Is(err, ErrRevokedSession) {
+		t.Errorf("revoked session: error = %v, want %v", err, ErrRevokedSession)
+	}
+}
@@ -0,0 +1,61 @@
+// internal/api/middleware/token.go
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// TokenPrefix marks API tokens, so leaked ones are easy to grep for
+	TokenPrefix = "amb_"
+
+	// ContextKeyToken holds *domain.APIToken for Bearer requests
+	ContextKeyToken = "apiToken"
+
+	// resultsRoute is the only write path open to write:results tokens
+	resultsRoute = "/api/v1/private/tournaments/:id/results"
+)
+
+// GenerateToken returns a new random API token and its display prefix
+func GenerateToken() (token, prefix string, err error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = TokenPrefix + hex.EncodeToString(b)
+	return token, token[:len(TokenPrefix)+6], nil
+}
+
+// HashToken returns hex SHA-256 of token, as stored in the database
+func HashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// TokenAllows reports whether token scopes permit the request. route is the
+// matched route pattern (gin FullPath).
+func TokenAllows(token *domain.APIToken, method, route string) bool {
+	if token.HasScope(domain.ScopeAdmin) {
+		return true
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return token.HasScope(domain.ScopeRead) || token.HasScope(domain.ScopeWriteResults)
+	}
+	return token.HasScope(domain.ScopeWriteResults) && strings.HasPrefix(route, resultsRoute)
+}
+
+// GetToken extracts API token from gin context; nil for Telegram auth
+func GetToken(c *gin.Context) *domain.APIToken {
+	token, exists := c.Get(ContextKeyToken)
+	if !exists {
+		return nil
+	}
+	return token.(*domain.APIToken)
+}
@@ -0,0 +1,56 @@
+// internal/api/middleware/token_test.go
+package middleware
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+)
+
+func TestTokenAllows(t *testing.T) {
+	token := func(scopes ...string) *domain.APIToken {
+		return &domain.APIToken{Scopes: scopes}
+	}
+
+	tests := []struct {
+		name   string
+		token  *domain.APIToken
+		method string
+		route  string
+		want   bool
+	}{
+		{"read get", token(domain.ScopeRead), "GET", "/api/v1/public/rating", true},
+		{"read post", token(domain.ScopeRead), "POST", "/api/v1/private/teams", false},
+		{"results get", token(domain.ScopeWriteResults), "GET", "/api/v1/public/teams", true},
+		{"results create", token(domain.ScopeWriteResults), "POST", "/api/v1/private/tournaments/:id/results", true},
+		{"results update", token(domain.ScopeWriteResults), "PATCH", "/api/v1/private/tournaments/:id/results/:result_id", true},
+		{"results other write", token(domain.ScopeWriteResults), "POST", "/api/v1/private/teams", false},
+		{"admin any write", token(domain.ScopeAdmin), "DELETE", "/api/v1/private/teams/:id", true},
+		{"no scopes", token(), "GET", "/api/v1/public/teams", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TokenAllows(tt.token, tt.method, tt.route); got != tt.want {
+				t.Errorf("TokenAllows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateToken(t *testing.T) {
+	raw, prefix, err := GenerateToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(raw, TokenPrefix) || !strings.HasPrefix(raw, prefix) {
+		t.Errorf("token %q should start with %q and prefix %q", raw, TokenPrefix, prefix)
+	}
+	if HashToken(raw) == HashToken(raw+"x") {
+		t.Error("different tokens should have different hashes")
+	}
+	if len(HashToken(raw)) != 64 {
+		t.Errorf("hash length = %d, want 64", len(HashToken(raw)))
+	}
+}
@@ -0,0 +1,357 @@
+// internal/api/openapi.go
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/eugene-twix/amber-bot/internal/api/handlers"
+	"github.com/eugene-twix/amber-bot/internal/api/middleware"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+const basePath = "/api/v1"
+
+type authKind int
+
+const (
+	authNone     authKind = iota
+	authInitData          // Mini App initData only
+	authUser              // session, API token or initData
+)
+
+// route documents one route of setupRoutes. TestSpecMatchesRoutes fails when
+// the table and the router disagree on paths, methods or handlers.
+type route struct {
+	method      string
+	path        string // relative to /api/v1, gin syntax
+	handler     any    // method expression of the route handler
+	summary     string
+	auth        authKind
+	permission  domain.Permission
+	query       any    // struct with form tags
+	request     any    // JSON body
+	response    any    // JSON body
+	status      int    // default 200
+	contentType string // non-JSON response
+}
+
+// Query parameters the handlers read one by one
+type (
+	calendarFeedQuery struct {
+		Token string `form:"token" binding:"required"`
+	}
+	teamSearchQuery struct {
+		Q string `form:"q"` // name or alias
+	}
+	tournamentsQuery struct {
+		Phase        string `form:"phase" enum:"upcoming,ongoing,finished"`
+		TeamID       int64  `form:"team_id"`
+		VenueID      int64  `form:"venue_id"`
+		DisciplineID int64  `form:"discipline_id"`
+	}
+	teamQuery struct {
+		TeamID int64 `form:"team_id"`
+	}
+	disciplineQuery struct {
+		DisciplineID int64 `form:"discipline_id"`
+	}
+	ratingQuery struct {
+		DisciplineID string `form:"discipline_id"` // ID or "all"; default - the default discipline
+	}
+	streamQuery struct {
+		AccessToken string `form:"access_token"` // session token instead of Authorization (EventSource)
+	}
+)
+
+type list[T any] = handlers.ListResponse[T]
+
+var routes = []route{
+	{method: "GET", path: "/openapi.json", handler: (*Server).getSpec, summary: "This document", response: map[string]any{}},
+
+	// Calendar feeds
+	{method: "GET", path: "/public/calendar.ics", handler: (*handlers.Handler).GetCalendar, summary: "iCalendar feed of all tournaments", query: calendarFeedQuery{}, contentType: "text/calendar"},
+	{method: "GET", path: "/public/teams/:id/calendar.ics", handler: (*handlers.Handler).GetTeamCalendar, summary: "iCalendar feed of the team's tournaments", query: calendarFeedQuery{}, contentType: "text/calendar"},
+
+	// Sessions
+	{method: "POST", path: "/auth/session", handler: (*handlers.Handler).CreateSession, summary: "Exchange initData for a session", auth: authInitData, response: handlers.SessionResponse{}, status: http.StatusCreated},
+	{method: "POST", path: "/auth/telegram", handler: (*handlers.Handler).CreateSession, summary: "Exchange Login Widget payload for a session", request: middleware.LoginWidgetData{}, response: handlers.SessionResponse{}, status: http.StatusCreated},
+	{method: "POST", path: "/auth/refresh", handler: (*handlers.Handler).RefreshSession, summary: "Rotate refresh token and issue a session", request: handlers.RefreshSessionRequest{}, response: handlers.SessionResponse{}},
+
+	// Public
+	{method: "GET", path: "/public/me", handler: (*handlers.Handler).GetMe, summary: "Current user", auth: authUser, response: handlers.MeResponse{}},
+	{method: "GET", path: "/public/teams", handler: (*handlers.Handler).ListTeams, summary: "Teams", auth: authUser, query: teamSearchQuery{}, response: list[handlers.TeamResponse]{}},
+	{method: "GET", path: "/public/teams/:id", handler: (*handlers.Handler).GetTeam, summary: "Team with badges", auth: authUser, response: handlers.TeamDetailsResponse{}},
+	{method: "GET", path: "/public/teams/:id/members", handler: (*handlers.Handler).ListTeamMembers, summary: "Team members", auth: authUser, response: list[handlers.MemberResponse]{}},
+	{method: "GET", path: "/public/teams/:id/results", handler: (*handlers.Handler).ListTeamResults, summary: "Team results", auth: authUser, response: list[handlers.TeamResultResponse]{}},
+	{method: "GET", path: "/public/teams/:id/names", handler: (*handlers.Handler).ListTeamNames, summary: "Previous team names", auth: authUser, response: list[handlers.TeamNameResponse]{}},
+	{method: "GET", path: "/public/teams/:id/vs/:other_id", handler: (*handlers.Handler).GetHeadToHead, summary: "Head-to-head of two teams", auth: authUser, response: handlers.HeadToHeadResponse{}},
+	{method: "GET", path: "/public/teams/:id/stats", handler: (*handlers.Handler).GetTeamStats, summary: "Team dashboard", auth: authUser, query: disciplineQuery{}, response: handlers.TeamStatsResponse{}},
+	{method: "GET", path: "/public/teams/:id/badges", handler: (*handlers.Handler).ListTeamBadges, summary: "Team badges", auth: authUser, response: list[handlers.BadgeResponse]{}},
+	{method: "GET", path: "/public/teams/:id/members/:member_id/badges", handler: (*handlers.Handler).ListMemberBadges, summary: "Member badges", auth: authUser, response: list[handlers.BadgeResponse]{}},
+	{method: "GET", path: "/public/tournaments", handler: (*handlers.Handler).ListTournaments, summary: "Tournaments", auth: authUser, query: tournamentsQuery{}, response: list[handlers.TournamentResponse]{}},
+	{method: "GET", path: "/public/tournaments/next", handler: (*handlers.Handler).GetNextTournament, summary: "Ongoing or nearest upcoming tournament", auth: authUser, query: teamQuery{}, response: handlers.TournamentResponse{}},
+	{method: "GET", path: "/public/tournaments/:id", handler: (*handlers.Handler).GetTournament, summary: "Tournament", auth: authUser, response: handlers.TournamentResponse{}},
+	{method: "GET", path: "/public/tournaments/:id/results", handler: (*handlers.Handler).ListTournamentResults, summary: "Tournament standings", auth: authUser, response: list[handlers.TournamentResultResponse]{}},
+	{method: "GET", path: "/public/tournaments/:id/live", handler: (*handlers.Handler).LiveTournament, summary: "Standings changes as Server-Sent Events", auth: authUser, query: streamQuery{}, contentType: "text/event-stream"},
+	{method: "GET", path: "/public/tournaments/:id/registrations", handler: (*handlers.Handler).ListTournamentRegistrations, summary: "Registered teams", auth: authUser, response: list[handlers.RegistrationResponse]{}},
+	{method: "GET", path: "/public/venues", handler: (*handlers.Handler).ListVenues, summary: "Venues", auth: authUser, response: list[handlers.VenueResponse]{}},
+	{method: "GET", path: "/public/venues/:id", handler: (*handlers.Handler).GetVenue, summary: "Venue", auth: authUser, response: handlers.VenueResponse{}},
+	{method: "GET", path: "/public/venues/:id/stats", handler: (*handlers.Handler).GetVenueStats, summary: "Venue stats", auth: authUser, query: disciplineQuery{}, response: handlers.VenueStatsResponse{}},
+	{method: "GET", path: "/public/players", handler: (*handlers.Handler).ListPlayers, summary: "Players", auth: authUser, response: list[handlers.PlayerResponse]{}},
+	{method: "GET", path: "/public/players/:id", handler: (*handlers.Handler).GetPlayer, summary: "Player", auth: authUser, response: handlers.PlayerResponse{}},
+	{method: "GET", path: "/public/players/:id/results", handler: (*handlers.Handler).ListPlayerResults, summary: "Player results", auth: authUser, response: list[handlers.PlayerResultResponse]{}},
+	{method: "GET", path: "/public/disciplines", handler: (*handlers.Handler).ListDisciplines, summary: "Disciplines", auth: authUser, response: list[handlers.DisciplineResponse]{}},
+	{method: "GET", path: "/public/calendar", handler: (*handlers.Handler).GetCalendarLinks, summary: "Calendar feed links", auth: authUser, query: teamQuery{}, response: handlers.CalendarLinksResponse{}},
+	{method: "GET", path: "/public/rating", handler: (*handlers.Handler).GetRating, summary: "Team rating", auth: authUser, query: ratingQuery{}, response: list[handlers.RatingResponse]{}},
+	{method: "GET", path: "/public/rating/players", handler: (*handlers.Handler).GetPlayerRating, summary: "Player rating", auth: authUser, query: ratingQuery{}, response: list[handlers.PlayerRatingResponse]{}},
+
+	// Teams, members, players
+	{method: "POST", path: "/private/teams", handler: (*handlers.Handler).CreateTeam, summary: "Create team", auth: authUser, permission: domain.PermTeamsManage, request: handlers.CreateTeamRequest{}, response: handlers.TeamResponse{}, status: http.StatusCreated},
+	{method: "PATCH", path: "/private/teams/:id", handler: (*handlers.Handler).UpdateTeam, summary: "Rename team", auth: authUser, permission: domain.PermTeamsManage, request: handlers.UpdateTeamRequest{}, response: handlers.TeamResponse{}},
+	{method: "DELETE", path: "/private/teams/:id", handler: (*handlers.Handler).DeleteTeam, summary: "Delete team", auth: authUser, permission: domain.PermTeamsManage, request: handlers.DeleteRequest{}, response: handlers.DeletedResponse{}},
+	{method: "POST", path: "/private/teams/:id/members", handler: (*handlers.Handler).CreateMember, summary: "Add member", auth: authUser, permission: domain.PermTeamsManage, request: handlers.CreateMemberRequest{}, response: handlers.MemberResponse{}, status: http.StatusCreated},
+	{method: "PATCH", path: "/private/teams/:id/members/:member_id", handler: (*handlers.Handler).UpdateMember, summary: "Rename member", auth: authUser, permission: domain.PermTeamsManage, request: handlers.UpdateMemberRequest{}, response: handlers.MemberResponse{}},
+	{method: "DELETE", path: "/private/teams/:id/members/:member_id", handler: (*handlers.Handler).DeleteMember, summary: "Delete member", auth: authUser, permission: domain.PermTeamsManage, request: handlers.DeleteRequest{}, response: handlers.DeletedResponse{}},
+	{method: "POST", path: "/private/players", handler: (*handlers.Handler).CreatePlayer, summary: "Create player", auth: authUser, permission: domain.PermTeamsManage, request: handlers.PlayerRequest{}, response: handlers.PlayerResponse{}, status: http.StatusCreated},
+	{method: "PATCH", path: "/private/players/:id", handler: (*handlers.Handler).UpdatePlayer, summary: "Update player", auth: authUser, permission: domain.PermTeamsManage, request: handlers.UpdatePlayerRequest{}, response: handlers.PlayerResponse{}},
+	{method: "DELETE", path: "/private/players/:id", handler: (*handlers.Handler).DeletePlayer, summary: "Delete player", auth: authUser, permission: domain.PermTeamsManage, request: handlers.DeleteRequest{}, response: handlers.DeletedResponse{}},
+
+	// Team merges
+	{method: "POST", path: "/private/teams/:id/merge", handler: (*handlers.Handler).MergeTeam, summary: "Merge team into target_team_id", auth: authUser, permission: domain.PermTeamsMerge, request: handlers.MergeTeamRequest{}, response: handlers.TeamMergeResponse{}},
+	{method: "POST", path: "/private/merges/:merge_id/revert", handler: (*handlers.Handler).RevertTeamMerge, summary: "Revert merge", auth: authUser, permission: domain.PermTeamsMerge, response: handlers.TeamMergeResponse{}},
+
+	// Tournaments, registrations, venues
+	{method: "POST", path: "/private/tournaments", handler: (*handlers.Handler).CreateTournament, summary: "Create tournament", auth: authUser, permission: domain.PermTournamentsManage, request: handlers.CreateTournamentRequest{}, response: handlers.TournamentResponse{}, status: http.StatusCreated},
+	{method: "PATCH", path: "/private/tournaments/:id", handler: (*handlers.Handler).UpdateTournament, summary: "Update tournament", auth: authUser, permission: domain.PermTournamentsManage, request: handlers.UpdateTournamentRequest{}, response: handlers.TournamentResponse{}},
+	{method: "DELETE", path: "/private/tournaments/:id", handler: (*handlers.Handler).DeleteTournament, summary: "Delete tournament", auth: authUser, permission: domain.PermTournamentsManage, request: handlers.DeleteRequest{}, response: handlers.DeletedResponse{}},
+	{method: "PUT", path: "/private/tournaments/:id/status", handler: (*handlers.Handler).SetTournamentStatus, summary: "Change tournament status", auth: authUser, permission: domain.PermTournamentsManage, request: handlers.SetTournamentStatusRequest{}, response: handlers.TournamentResponse{}},
+	{method: "POST", path: "/private/tournaments/:id/registrations", handler: (*handlers.Handler).RegisterTeam, summary: "Register team", auth: authUser, permission: domain.PermTournamentsManage, request: handlers.RegisterTeamRequest{}, response: handlers.RegistrationResponse{}, status: http.StatusCreated},
+	{method: "DELETE", path: "/private/tournaments/:id/registrations/:team_id", handler: (*handlers.Handler).UnregisterTeam, summary: "Remove registration", auth: authUser, permission: domain.PermTournamentsManage, response: handlers.DeletedResponse{}},
+	{method: "POST", path: "/private/venues", handler: (*handlers.Handler).CreateVenue, summary: "Create venue", auth: authUser, permission: domain.PermTournamentsManage, request: handlers.VenueRequest{}, response: handlers.VenueResponse{}, status: http.StatusCreated},
+	{method: "PATCH", path: "/private/venues/:id", handler: (*handlers.Handler).UpdateVenue, summary: "Update venue", auth: authUser, permission: domain.PermTournamentsManage, request: handlers.UpdateVenueRequest{}, response: handlers.VenueResponse{}},
+	{method: "DELETE", path: "/private/venues/:id", handler: (*handlers.Handler).DeleteVenue, summary: "Delete venue", auth: authUser, permission: domain.PermTournamentsManage, request: handlers.DeleteRequest{}, response: handlers.DeletedResponse{}},
+	{method: "POST", path: "/private/venues/:id/merge", handler: (*handlers.Handler).MergeVenue, summary: "Merge venue into target_venue_id", auth: authUser, permission: domain.PermTournamentsManage, request: handlers.MergeVenueRequest{}, response: handlers.VenueResponse{}},
+
+	// Results
+	{method: "POST", path: "/private/tournaments/:id/results", handler: (*handlers.Handler).CreateResult, summary: "Record result", auth: authUser, permission: domain.PermResultsRecord, request: handlers.CreateResultRequest{}, response: handlers.ResultResponse{}, status: http.StatusCreated},
+	{method: "PATCH", path: "/private/tournaments/:id/results/:result_id", handler: (*handlers.Handler).UpdateResult, summary: "Change place", auth: authUser, permission: domain.PermResultsRecord, request: handlers.UpdateResultRequest{}, response: handlers.ResultResponse{}},
+	{method: "DELETE", path: "/private/tournaments/:id/results/:result_id", handler: (*handlers.Handler).DeleteResult, summary: "Delete result and shift places", auth: authUser, permission: domain.PermResultsRecord, request: handlers.DeleteRequest{}, response: handlers.DeletedResponse{}},
+
+	// Users
+	{method: "GET", path: "/private/users", handler: (*handlers.Handler).ListUsers, summary: "Users", auth: authUser, permission: domain.PermUsersManage, query: handlers.ListUsersParams{}, response: list[handlers.UserResponse]{}},
+	{method: "GET", path: "/private/users/:telegram_id", handler: (*handlers.Handler).GetUser, summary: "User with activity", auth: authUser, permission: domain.PermUsersManage, response: handlers.UserDetailsResponse{}},
+	{method: "PUT", path: "/private/users/:telegram_id/role", handler: (*handlers.Handler).UpdateUserRole, summary: "Change role", auth: authUser, permission: domain.PermUsersManage, request: handlers.UpdateUserRoleRequest{}, response: handlers.UserResponse{}},
+	{method: "POST", path: "/private/users/:telegram_id/block", handler: (*handlers.Handler).BlockUser, summary: "Block user", auth: authUser, permission: domain.PermUsersManage, request: handlers.BlockUserRequest{}, response: handlers.UserResponse{}},
+	{method: "DELETE", path: "/private/users/:telegram_id/block", handler: (*handlers.Handler).UnblockUser, summary: "Unblock user", auth: authUser, permission: domain.PermUsersManage, response: handlers.UserResponse{}},
+	{method: "GET", path: "/private/invites", handler: (*handlers.Handler).ListInvites, summary: "Invites", auth: authUser, permission: domain.PermUsersManage, query: handlers.PaginationParams{}, response: list[handlers.InviteResponse]{}},
+	{method: "POST", path: "/private/invites", handler: (*handlers.Handler).CreateInvite, summary: "Create invite", auth: authUser, permission: domain.PermUsersManage, request: handlers.CreateInviteRequest{}, response: handlers.CreatedInviteResponse{}, status: http.StatusCreated},
+	{method: "DELETE", path: "/private/invites/:id", handler: (*handlers.Handler).RevokeInvite, summary: "Revoke invite", auth: authUser, permission: domain.PermUsersManage, response: handlers.RevokedResponse{}},
+
+	// Roles
+	{method: "GET", path: "/private/permissions", handler: (*handlers.Handler).ListPermissions, summary: "Permissions", auth: authUser, permission: domain.PermRolesManage, response: handlers.PermissionsResponse{}},
+	{method: "GET", path: "/private/roles", handler: (*handlers.Handler).ListRoles, summary: "Roles", auth: authUser, permission: domain.PermRolesManage, response: list[handlers.RoleResponse]{}},
+	{method: "POST", path: "/private/roles", handler: (*handlers.Handler).CreateRole, summary: "Create role", auth: authUser, permission: domain.PermRolesManage, request: handlers.RoleRequest{}, response: handlers.RoleResponse{}, status: http.StatusCreated},
+	{method: "PATCH", path: "/private/roles/:id", handler: (*handlers.Handler).UpdateRole, summary: "Update role", auth: authUser, permission: domain.PermRolesManage, request: handlers.UpdateRoleRequest{}, response: handlers.RoleResponse{}},
+	{method: "DELETE", path: "/private/roles/:id", handler: (*handlers.Handler).DeleteRole, summary: "Delete role", auth: authUser, permission: domain.PermRolesManage, request: handlers.DeleteRequest{}, response: handlers.DeletedResponse{}},
+
+	// Settings
+	{method: "POST", path: "/private/disciplines", handler: (*handlers.Handler).CreateDiscipline, summary: "Create discipline", auth: authUser, permission: domain.PermSettingsManage, request: handlers.DisciplineRequest{}, response: handlers.DisciplineResponse{}, status: http.StatusCreated},
+	{method: "PATCH", path: "/private/disciplines/:id", handler: (*handlers.Handler).UpdateDiscipline, summary: "Rename discipline", auth: authUser, permission: domain.PermSettingsManage, request: handlers.UpdateDisciplineRequest{}, response: handlers.DisciplineResponse{}},
+	{method: "DELETE", path: "/private/disciplines/:id", handler: (*handlers.Handler).DeleteDiscipline, summary: "Delete discipline", auth: authUser, permission: domain.PermSettingsManage, request: handlers.DeleteRequest{}, response: handlers.DeletedResponse{}},
+	{method: "POST", path: "/private/badges/evaluate", handler: (*handlers.Handler).EvaluateBadges, summary: "Re-evaluate achievements", auth: authUser, permission: domain.PermSettingsManage, response: handlers.EvaluateBadgesResponse{}},
+	{method: "GET", path: "/private/tokens", handler: (*handlers.Handler).ListTokens, summary: "API tokens", auth: authUser, permission: domain.PermSettingsManage, response: list[handlers.TokenResponse]{}},
+	{method: "POST", path: "/private/tokens", handler: (*handlers.Handler).CreateToken, summary: "Create API token", auth: authUser, permission: domain.PermSettingsManage, request: handlers.CreateTokenRequest{}, response: handlers.CreatedTokenResponse{}, status: http.StatusCreated},
+	{method: "DELETE", path: "/private/tokens/:id", handler: (*handlers.Handler).RevokeToken, summary: "Revoke API token", auth: authUser, permission: domain.PermSettingsManage, response: handlers.RevokedResponse{}},
+	{method: "GET", path: "/private/webhooks", handler: (*handlers.Handler).ListWebhooks, summary: "Webhooks", auth: authUser, permission: domain.PermSettingsManage, response: list[handlers.WebhookResponse]{}},
+	{method: "POST", path: "/private/webhooks", handler: (*handlers.Handler).CreateWebhook, summary: "Create webhook", auth: authUser, permission: domain.PermSettingsManage, request: handlers.CreateWebhookRequest{}, response: handlers.CreatedWebhookResponse{}, status: http.StatusCreated},
+	{method: "PATCH", path: "/private/webhooks/:id", handler: (*handlers.Handler).UpdateWebhook, summary: "Update webhook", auth: authUser, permission: domain.PermSettingsManage, request: handlers.UpdateWebhookRequest{}, response: handlers.WebhookResponse{}},
+	{method: "DELETE", path: "/private/webhooks/:id", handler: (*handlers.Handler).DeleteWebhook, summary: "Delete webhook", auth: authUser, permission: domain.PermSettingsManage, request: handlers.DeleteRequest{}, response: handlers.DeletedResponse{}},
+	{method: "GET", path: "/private/webhooks/:id/deliveries", handler: (*handlers.Handler).ListWebhookDeliveries, summary: "Webhook delivery attempts", auth: authUser, permission: domain.PermSettingsManage, query: handlers.PaginationParams{}, response: list[handlers.WebhookDeliveryResponse]{}},
+	{method: "GET", path: "/private/audit", handler: (*handlers.Handler).ListAudit, summary: "Audit trail", auth: authUser, permission: domain.PermSettingsManage, query: handlers.ListAuditParams{}, response: list[handlers.AuditEntryResponse]{}},
+}
+
+// Spec returns the OpenAPI document of the API
+func Spec() *openapi.Document {
+	gen := openapi.NewGenerator()
+	errorResponse := openapi.Response{
+		Description: "Error",
+		Content:     jsonContent(gen.Response(handlers.ErrorResponse{})),
+	}
+
+	paths := make(map[string]openapi.PathItem)
+	for _, r := range routes {
+		op := &openapi.Operation{
+			OperationID: operationID(r.handler),
+			Summary:     r.summary,
+			Tags:        []string{routeTag(r.path)},
+			Responses:   map[string]openapi.Response{"default": errorResponse},
+			Security:    security(r.auth),
+			Permission:  string(r.permission),
+		}
+
+		for _, name := range pathParams(r.path) {
+			op.Parameters = append(op.Parameters, openapi.Parameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   &openapi.Schema{Type: "integer"},
+			})
+		}
+		if r.query != nil {
+			op.Parameters = append(op.Parameters, gen.Query(r.query)...)
+		}
+		if r.request != nil {
+			op.RequestBody = &openapi.RequestBody{Required: true, Content: jsonContent(gen.Request(r.request))}
+		}
+
+		status := r.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		resp := openapi.Response{Description: http.StatusText(status)}
+		switch {
+		case r.contentType != "":
+			resp.Content = map[string]openapi.MediaType{r.contentType: {Schema: &openapi.Schema{Type: "string"}}}
+		case r.response != nil:
+			resp.Content = jsonContent(gen.Response(r.response))
+		}
+		op.Responses[strconv.Itoa(status)] = resp
+
+		path := specPath(r.path)
+		if paths[path] == nil {
+			paths[path] = openapi.PathItem{}
+		}
+		paths[path][strings.ToLower(r.method)] = op
+	}
+
+	return &openapi.Document{
+		OpenAPI: openapi.Version,
+		Info: openapi.Info{
+			Title:       "Amber Bot API",
+			Version:     "1",
+			Description: "Mini App API. Errors carry a snake_case code in error; mutations accept Idempotency-Key.",
+		},
+		Servers: []openapi.Server{{URL: basePath}},
+		Paths:   paths,
+		Components: openapi.Components{
+			Schemas: gen.Schemas(),
+			SecuritySchemes: map[string]openapi.SecurityScheme{
+				"bearer": {
+					Type:        "http",
+					Scheme:      "bearer",
+					Description: "Session token (ses_...) from /auth/session or API token",
+				},
+				"initData": {
+					Type:        "apiKey",
+					In:          "header",
+					Name:        "Authorization",
+					Description: "TMA <Telegram Mini App initData>",
+				},
+			},
+		},
+	}
+}
+
+// Generated files, by path from the repository root
+const (
+	SpecFile        = "internal/api/openapi.json"
+	SpecClientTypes = "frontend/src/services/schema.gen.ts"
+)
+
+// SpecFiles renders the OpenAPI document and TypeScript types of its
+// schemas for the frontend; cmd/openapi writes them, the spec test
+// compares them with the committed ones
+func SpecFiles() (map[string][]byte, error) {
+	doc := Spec()
+	spec, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	header := "Code generated by cmd/openapi from the API spec. DO NOT EDIT.\nRegenerate: go run ./cmd/openapi"
+	return map[string][]byte{
+		SpecFile:        append(spec, '\n'),
+		SpecClientTypes: openapi.TypeScript(doc, header),
+	}, nil
+}
+
+// getSpec serves the OpenAPI document
+func (s *Server) getSpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", s.spec)
+}
+
+func jsonContent(schema *openapi.Schema) map[string]openapi.MediaType {
+	return map[string]openapi.MediaType{"application/json": {Schema: schema}}
+}
+
+func security(auth authKind) []openapi.SecurityRequirement {
+	switch auth {
+	case authInitData:
+		return []openapi.SecurityRequirement{{"initData": {}}}
+	case authUser:
+		return []openapi.SecurityRequirement{{"bearer": {}}, {"initData": {}}}
+	}
+	return []openapi.SecurityRequirement{}
+}
+
+// handlerName returns "Type.Method" of a method expression or method value
+func handlerName(fn any) string {
+	return shortFuncName(runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name())
+}
+
+// shortFuncName strips package path and receiver decorations:
+// ".../handlers.(*Handler).GetMe-fm" - "Handler.GetMe"
+func shortFuncName(name string) string {
+	name = strings.TrimSuffix(name, "-fm")
+	name = name[strings.LastIndex(name, "/")+1:]
+	_, name, _ = strings.Cut(name, ".")
+	return strings.NewReplacer("(*", "", ")", "").Replace(name)
+}
+
+// operationID is the handler method name in lower camel case
+func operationID(fn any) string {
+	name := handlerName(fn)
+	name = name[strings.LastIndex(name, ".")+1:]
+	r := []rune(name)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+// specPath converts gin path params to OpenAPI: /teams/:id - /teams/{id}
+func specPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		if name, ok := strings.CutPrefix(p, ":"); ok {
+			parts[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+func pathParams(path string) []string {
+	var names []string
+	for _, p := range strings.Split(path, "/") {
+		if name, ok := strings.CutPrefix(p, ":"); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// routeTag groups operations by resource: /private/teams/:id - teams
+func routeTag(path string) string {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	tag := parts[0]
+	if (tag == "public" || tag == "private") && len(parts) > 1 {
+		tag = parts[1]
+	}
+	tag, _, _ = strings.Cut(tag, ".")
+	return tag
+}
@@ -0,0 +1,65 @@
+// internal/api/openapi_test.go
+package api
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eugene-twix/amber-bot/internal/api/handlers"
+	"github.com/eugene-twix/amber-bot/internal/api/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func TestSpecMatchesRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s := &Server{engine: gin.New(), handler: &handlers.Handler{}}
+	s.setupRoutes(
+		middleware.NewAuthMiddleware("", nil, nil, nil, nil, nil),
+		middleware.NewRateLimitMiddleware(nil),
+		middleware.NewIdempotencyMiddleware(nil),
+	)
+
+	documented := make(map[string]string, len(routes))
+	for _, r := range routes {
+		key := r.method + " " + basePath + r.path
+		if _, ok := documented[key]; ok {
+			t.Errorf("%s is documented twice", key)
+		}
+		documented[key] = handlerName(r.handler)
+	}
+
+	for _, r := range s.engine.Routes() {
+		key := r.Method + " " + r.Path
+		want, ok := documented[key]
+		if !ok {
+			t.Errorf("%s is not documented in internal/api/openapi.go", key)
+			continue
+		}
+		if got := shortFuncName(r.Handler); got != want {
+			t.Errorf("%s is handled by %s, documented as %s", key, got, want)
+		}
+		delete(documented, key)
+	}
+	for key := range documented {
+		t.Errorf("%s is documented but not routed", key)
+	}
+}
+
+func TestSpecFilesUpToDate(t *testing.T) {
+	files, err := SpecFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range files {
+		got, err := os.ReadFile(filepath.Join("..", "..", filepath.FromSlash(path)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date with handler DTOs, run: go run ./cmd/openapi", path)
+		}
+	}
+}
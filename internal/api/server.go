@@ -3,28 +3,58 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/eugene-twix/amber-bot/internal/api/handlers"
 	"github.com/eugene-twix/amber-bot/internal/api/middleware"
 	"github.com/eugene-twix/amber-bot/internal/cache"
+	"github.com/eugene-twix/amber-bot/internal/calendar"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/events"
+	"github.com/eugene-twix/amber-bot/internal/live"
 	"github.com/eugene-twix/amber-bot/internal/repository"
+	"github.com/eugene-twix/amber-bot/internal/webhooks"
 	"github.com/gin-gonic/gin"
 )
 
+// requestTimeout bounds every API request except event streams
+const requestTimeout = 15 * time.Second
+
 type Config struct {
 	Port         int
 	BotToken     string
 	FrontendPath string // Path to frontend/dist
 	DevMode      bool
 	DevUserID    int64
+
+	// Session token signing secret
+	SessionSecret string
+
+	// Calendar feeds: token signing secret and Mini App t.me link for events
+	CalendarSecret string
+	MiniAppLink    string
+
+	// Bot username for invite links; empty - invites return only the token
+	BotUsername string
+
+	// ADMIN_IDS pinned to admin (ADMIN_IDS_LOCKED), nil - not locked
+	LockedAdminIDs []int64
+
+	// Default timezone of tournaments
+	Timezone *time.Location
 }
 
 type Server struct {
 	config  Config
 	engine  *gin.Engine
 	handler *handlers.Handler
+	liveHub *live.Hub
+	webhook *webhooks.Dispatcher
+	events  *events.Dispatcher
+	spec    []byte // OpenAPI document, JSON
+	stop    context.CancelFunc
 }
 
 func NewServer(cfg Config, repos *Repositories, cache *cache.Cache) *Server {
@@ -34,32 +64,71 @@ func NewServer(cfg Config, repos *Repositories, cache *cache.Cache) *Server {
 	// Global middleware
 	engine.Use(gin.Recovery())
 	engine.Use(middleware.Logger())
-	engine.Use(middleware.Timeout(15 * time.Second))
 	engine.Use(middleware.CORS())
 
+	// Live events fan-out (one Dragonfly subscription per instance)
+	liveHub := live.NewHub(cache)
+
 	// Create handler with all dependencies
-	h := handlers.NewHandler(repos.User, repos.Team, repos.Member, repos.Tournament, repos.Result, cache)
+	calendarFeeds := calendar.NewFeeds(cfg.CalendarSecret, cfg.MiniAppLink)
+	sessions := middleware.NewSessions(cfg.SessionSecret, cache)
+	h := handlers.NewHandler(repos.User, repos.Team, repos.Member, repos.Tournament, repos.Result, repos.Audit, repos.Stats, repos.Badges, repos.Webhooks, repos.Venues, repos.Disciplines, repos.Players, repos.Tokens, repos.Roles, repos.Invites, sessions, liveHub, calendarFeeds, cfg.BotUsername, cfg.LockedAdminIDs, cfg.Timezone, cache)
 
 	// Auth middleware
-	authMW := middleware.NewAuthMiddleware(cfg.BotToken, repos.User, cache)
+	authMW := middleware.NewAuthMiddleware(cfg.BotToken, repos.User, repos.Roles, repos.Tokens, sessions, cache)
 	if cfg.DevMode {
 		authMW.EnableDevMode(cfg.DevUserID)
 	}
 	rateLimitMW := middleware.NewRateLimitMiddleware(cache)
+	idempotencyMW := middleware.NewIdempotencyMiddleware(cache)
 
 	s := &Server{
 		config:  cfg,
 		engine:  engine,
 		handler: h,
+		liveHub: liveHub,
+		webhook: webhooks.NewDispatcher(repos.Webhooks, nil),
+		events:  events.NewDispatcher(repos.Events, events.Default(cache, repos.Webhooks, repos.Badges)...),
 	}
 
-	s.setupRoutes(authMW, rateLimitMW)
+	s.setupRoutes(authMW, rateLimitMW, idempotencyMW)
 
 	return s
 }
 
-func (s *Server) setupRoutes(authMW *middleware.AuthMiddleware, rateLimitMW *middleware.RateLimitMiddleware) {
-	api := s.engine.Group("/api/v1")
+func (s *Server) setupRoutes(authMW *middleware.AuthMiddleware, rateLimitMW *middleware.RateLimitMiddleware, idempotencyMW *middleware.IdempotencyMiddleware) {
+	api := s.engine.Group(basePath)
+
+	// Event streams stay open for long: the only routes without the timeout.
+	// The session token may come in ?access_token= (EventSource has no headers).
+	stream := api.Group("/public", authMW.AuthenticateStream(), rateLimitMW.LimitRead())
+	stream.GET("/tournaments/:id/live", s.handler.LiveTournament)
+
+	api = api.Group("", middleware.Timeout(requestTimeout))
+
+	// OpenAPI document of the routes below (internal/api/openapi.go)
+	spec, err := json.Marshal(Spec())
+	if err != nil {
+		panic(err)
+	}
+	s.spec = spec
+	api.GET("/openapi.json", s.getSpec)
+
+	// Calendar feeds: token in URL instead of Telegram auth, for calendar apps
+	feeds := api.Group("/public")
+	{
+		feeds.GET("/calendar.ics", s.handler.GetCalendar)
+		feeds.GET("/teams/:id/calendar.ics", s.handler.GetTeamCalendar)
+	}
+
+	// Sessions: exchange initData (Mini App) or Login Widget payload (browser)
+	// once, then use "Bearer ses_..." with refresh
+	auth := api.Group("/auth")
+	{
+		auth.POST("/session", authMW.Authenticate(), rateLimitMW.LimitWrite(), s.handler.CreateSession)
+		auth.POST("/telegram", authMW.AuthenticateLoginWidget(), rateLimitMW.LimitWrite(), s.handler.CreateSession)
+		auth.POST("/refresh", rateLimitMW.LimitIP(), s.handler.RefreshSession)
+	}
 
 	// Public routes (Viewer+)
 	public := api.Group("/public")
@@ -71,43 +140,131 @@ func (s *Server) setupRoutes(authMW *middleware.AuthMiddleware, rateLimitMW *mid
 		public.GET("/teams/:id", s.handler.GetTeam)
 		public.GET("/teams/:id/members", s.handler.ListTeamMembers)
 		public.GET("/teams/:id/results", s.handler.ListTeamResults)
+		public.GET("/teams/:id/names", s.handler.ListTeamNames)
+		public.GET("/teams/:id/vs/:other_id", s.handler.GetHeadToHead)
+		public.GET("/teams/:id/stats", s.handler.GetTeamStats)
+		public.GET("/teams/:id/badges", s.handler.ListTeamBadges)
+		public.GET("/teams/:id/members/:member_id/badges", s.handler.ListMemberBadges)
 		public.GET("/tournaments", s.handler.ListTournaments)
+		public.GET("/tournaments/next", s.handler.GetNextTournament)
 		public.GET("/tournaments/:id", s.handler.GetTournament)
 		public.GET("/tournaments/:id/results", s.handler.ListTournamentResults)
+		public.GET("/tournaments/:id/registrations", s.handler.ListTournamentRegistrations)
+		public.GET("/venues", s.handler.ListVenues)
+		public.GET("/venues/:id", s.handler.GetVenue)
+		public.GET("/venues/:id/stats", s.handler.GetVenueStats)
+		public.GET("/players", s.handler.ListPlayers)
+		public.GET("/players/:id", s.handler.GetPlayer)
+		public.GET("/players/:id/results", s.handler.ListPlayerResults)
+		public.GET("/disciplines", s.handler.ListDisciplines)
+		public.GET("/calendar", s.handler.GetCalendarLinks)
 		public.GET("/rating", s.handler.GetRating)
+		public.GET("/rating/players", s.handler.GetPlayerRating)
 	}
 
-	// Private routes (Organizer/Admin)
+	// Private routes: each group requires a permission of the user's role;
+	// mutations with Idempotency-Key replay the first response
 	private := api.Group("/private")
-	private.Use(authMW.Authenticate())
-	private.Use(authMW.RequireOrganizer())
+	private.Use(authMW.Authenticate(), idempotencyMW.Handle())
 	{
-		// Teams
-		private.POST("/teams", rateLimitMW.LimitWrite(), s.handler.CreateTeam)
-		private.PATCH("/teams/:id", rateLimitMW.LimitWrite(), s.handler.UpdateTeam)
-		private.DELETE("/teams/:id", rateLimitMW.LimitWrite(), s.handler.DeleteTeam)
+		// Teams, members, players
+		teams := private.Group("")
+		teams.Use(authMW.RequirePermission(domain.PermTeamsManage))
+		{
+			teams.POST("/teams", rateLimitMW.LimitWrite(), s.handler.CreateTeam)
+			teams.PATCH("/teams/:id", rateLimitMW.LimitWrite(), s.handler.UpdateTeam)
+			teams.DELETE("/teams/:id", rateLimitMW.LimitWrite(), s.handler.DeleteTeam)
 
-		// Members
-		private.POST("/teams/:id/members", rateLimitMW.LimitWrite(), s.handler.CreateMember)
-		private.PATCH("/teams/:id/members/:member_id", rateLimitMW.LimitWrite(), s.handler.UpdateMember)
-		private.DELETE("/teams/:id/members/:member_id", rateLimitMW.LimitWrite(), s.handler.DeleteMember)
+			teams.POST("/teams/:id/members", rateLimitMW.LimitWrite(), s.handler.CreateMember)
+			teams.PATCH("/teams/:id/members/:member_id", rateLimitMW.LimitWrite(), s.handler.UpdateMember)
+			teams.DELETE("/teams/:id/members/:member_id", rateLimitMW.LimitWrite(), s.handler.DeleteMember)
 
-		// Tournaments
-		private.POST("/tournaments", rateLimitMW.LimitWrite(), s.handler.CreateTournament)
-		private.PATCH("/tournaments/:id", rateLimitMW.LimitWrite(), s.handler.UpdateTournament)
-		private.DELETE("/tournaments/:id", rateLimitMW.LimitWrite(), s.handler.DeleteTournament)
+			teams.POST("/players", rateLimitMW.LimitWrite(), s.handler.CreatePlayer)
+			teams.PATCH("/players/:id", rateLimitMW.LimitWrite(), s.handler.UpdatePlayer)
+			teams.DELETE("/players/:id", rateLimitMW.LimitWrite(), s.handler.DeletePlayer)
+		}
+
+		// Team merges
+		merges := private.Group("")
+		merges.Use(authMW.RequirePermission(domain.PermTeamsMerge))
+		{
+			merges.POST("/teams/:id/merge", rateLimitMW.LimitWrite(), s.handler.MergeTeam)
+			merges.POST("/merges/:merge_id/revert", rateLimitMW.LimitWrite(), s.handler.RevertTeamMerge)
+		}
+
+		// Tournaments, registrations, venues
+		tournaments := private.Group("")
+		tournaments.Use(authMW.RequirePermission(domain.PermTournamentsManage))
+		{
+			tournaments.POST("/tournaments", rateLimitMW.LimitWrite(), s.handler.CreateTournament)
+			tournaments.PATCH("/tournaments/:id", rateLimitMW.LimitWrite(), s.handler.UpdateTournament)
+			tournaments.DELETE("/tournaments/:id", rateLimitMW.LimitWrite(), s.handler.DeleteTournament)
+			tournaments.PUT("/tournaments/:id/status", rateLimitMW.LimitWrite(), s.handler.SetTournamentStatus)
+			tournaments.POST("/tournaments/:id/registrations", rateLimitMW.LimitWrite(), s.handler.RegisterTeam)
+			tournaments.DELETE("/tournaments/:id/registrations/:team_id", rateLimitMW.LimitWrite(), s.handler.UnregisterTeam)
+
+			tournaments.POST("/venues", rateLimitMW.LimitWrite(), s.handler.CreateVenue)
+			tournaments.PATCH("/venues/:id", rateLimitMW.LimitWrite(), s.handler.UpdateVenue)
+			tournaments.DELETE("/venues/:id", rateLimitMW.LimitWrite(), s.handler.DeleteVenue)
+			tournaments.POST("/venues/:id/merge", rateLimitMW.LimitWrite(), s.handler.MergeVenue)
+		}
 
 		// Results
-		private.POST("/tournaments/:id/results", rateLimitMW.LimitWrite(), s.handler.CreateResult)
-		private.PATCH("/tournaments/:id/results/:result_id", rateLimitMW.LimitWrite(), s.handler.UpdateResult)
-		private.DELETE("/tournaments/:id/results/:result_id", rateLimitMW.LimitWrite(), s.handler.DeleteResult)
+		results := private.Group("")
+		results.Use(authMW.RequirePermission(domain.PermResultsRecord))
+		{
+			results.POST("/tournaments/:id/results", rateLimitMW.LimitWrite(), s.handler.CreateResult)
+			results.PATCH("/tournaments/:id/results/:result_id", rateLimitMW.LimitWrite(), s.handler.UpdateResult)
+			results.DELETE("/tournaments/:id/results/:result_id", rateLimitMW.LimitWrite(), s.handler.DeleteResult)
+		}
+
+		// Users
+		users := private.Group("")
+		users.Use(authMW.RequirePermission(domain.PermUsersManage))
+		{
+			users.GET("/users", rateLimitMW.LimitRead(), s.handler.ListUsers)
+			users.GET("/users/:telegram_id", rateLimitMW.LimitRead(), s.handler.GetUser)
+			users.PUT("/users/:telegram_id/role", rateLimitMW.LimitWrite(), s.handler.UpdateUserRole)
+			users.POST("/users/:telegram_id/block", rateLimitMW.LimitWrite(), s.handler.BlockUser)
+			users.DELETE("/users/:telegram_id/block", rateLimitMW.LimitWrite(), s.handler.UnblockUser)
+
+			users.GET("/invites", rateLimitMW.LimitRead(), s.handler.ListInvites)
+			users.POST("/invites", rateLimitMW.LimitWrite(), s.handler.CreateInvite)
+			users.DELETE("/invites/:id", rateLimitMW.LimitWrite(), s.handler.RevokeInvite)
+		}
 
-		// Admin only routes
-		admin := private.Group("")
-		admin.Use(authMW.RequireAdmin())
+		// Roles and their permissions
+		roles := private.Group("")
+		roles.Use(authMW.RequirePermission(domain.PermRolesManage))
 		{
-			admin.GET("/users", rateLimitMW.LimitRead(), s.handler.ListUsers)
-			admin.PUT("/users/:telegram_id/role", rateLimitMW.LimitWrite(), s.handler.UpdateUserRole)
+			roles.GET("/permissions", rateLimitMW.LimitRead(), s.handler.ListPermissions)
+			roles.GET("/roles", rateLimitMW.LimitRead(), s.handler.ListRoles)
+			roles.POST("/roles", rateLimitMW.LimitWrite(), s.handler.CreateRole)
+			roles.PATCH("/roles/:id", rateLimitMW.LimitWrite(), s.handler.UpdateRole)
+			roles.DELETE("/roles/:id", rateLimitMW.LimitWrite(), s.handler.DeleteRole)
+		}
+
+		// Settings: disciplines, achievements, API tokens, webhooks, audit trail
+		settings := private.Group("")
+		settings.Use(authMW.RequirePermission(domain.PermSettingsManage))
+		{
+			settings.POST("/disciplines", rateLimitMW.LimitWrite(), s.handler.CreateDiscipline)
+			settings.PATCH("/disciplines/:id", rateLimitMW.LimitWrite(), s.handler.UpdateDiscipline)
+			settings.DELETE("/disciplines/:id", rateLimitMW.LimitWrite(), s.handler.DeleteDiscipline)
+
+			settings.POST("/badges/evaluate", rateLimitMW.LimitWrite(), s.handler.EvaluateBadges)
+
+			settings.GET("/tokens", rateLimitMW.LimitRead(), s.handler.ListTokens)
+			settings.POST("/tokens", rateLimitMW.LimitWrite(), s.handler.CreateToken)
+			settings.DELETE("/tokens/:id", rateLimitMW.LimitWrite(), s.handler.RevokeToken)
+
+			settings.GET("/webhooks", rateLimitMW.LimitRead(), s.handler.ListWebhooks)
+			settings.POST("/webhooks", rateLimitMW.LimitWrite(), s.handler.CreateWebhook)
+			settings.PATCH("/webhooks/:id", rateLimitMW.LimitWrite(), s.handler.UpdateWebhook)
+			settings.DELETE("/webhooks/:id", rateLimitMW.LimitWrite(), s.handler.DeleteWebhook)
+			settings.GET("/webhooks/:id/deliveries", rateLimitMW.LimitRead(), s.handler.ListWebhookDeliveries)
+
+			settings.GET("/audit", rateLimitMW.LimitRead(), s.handler.ListAudit)
 		}
 	}
 
@@ -124,21 +281,41 @@ func (s *Server) setupRoutes(authMW *middleware.AuthMiddleware, rateLimitMW *mid
 }
 
 func (s *Server) Run() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stop = cancel
+	go s.liveHub.Run(ctx)
+	go s.webhook.Run(ctx)
+	go s.events.Run(ctx)
+
 	addr := fmt.Sprintf(":%d", s.config.Port)
 	return s.engine.Run(addr)
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
 	// Gin doesn't have built-in graceful shutdown, but we can implement it
-	// For now, just return nil
+	// For now, just stop background workers
+	if s.stop != nil {
+		s.stop()
+	}
 	return nil
 }
 
 // Repositories holds all repository interfaces
 type Repositories struct {
-	User       repository.UserRepository
-	Team       repository.TeamRepository
-	Member     repository.MemberRepository
-	Tournament repository.TournamentRepository
-	Result     repository.ResultRepository
+	User        repository.UserRepository
+	Team        repository.TeamRepository
+	Member      repository.MemberRepository
+	Tournament  repository.TournamentRepository
+	Result      repository.ResultRepository
+	Audit       repository.AuditRepository
+	Stats       repository.StatsRepository
+	Badges      repository.BadgeRepository
+	Webhooks    repository.WebhookRepository
+	Venues      repository.VenueRepository
+	Disciplines repository.DisciplineRepository
+	Players     repository.PlayerRepository
+	Tokens      repository.APITokenRepository
+	Roles       repository.RoleRepository
+	Invites     repository.InviteRepository
+	Events      repository.EventRepository
 }
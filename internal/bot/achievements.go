@@ -0,0 +1,86 @@
+// internal/bot/achievements.go
+package bot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+
+	"github.com/eugene-twix/amber-bot/internal/achievements"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/events"
+	tele "gopkg.in/telebot.v3"
+)
+
+// formatBadges - список бейджей строками "🥇 Первая победа"
+func formatBadges(badges []*domain.Badge) []string {
+	lines := make([]string, 0, len(badges))
+	for _, badge := range badges {
+		rule, ok := achievements.Find(badge.Code)
+		if !ok {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", rule.Emoji, rule.Title))
+	}
+	return lines
+}
+
+// badgeAnnouncer - объявляет новые бейджи команд в ANNOUNCE_CHAT_ID.
+// Так объявляются и бейджи, полученные через API; бейджи, выданные
+// пересчётом истории, не объявляются.
+func (b *Bot) badgeAnnouncer() events.Subscriber {
+	return events.Subscriber{
+		Name:   "badge_announcer",
+		Types:  []string{domain.EventBadgeAwarded},
+		Handle: b.announceBadge,
+	}
+}
+
+func (b *Bot) announceBadge(ctx context.Context, e *domain.Event) error {
+	badge, err := b.badgeRepo.GetByID(ctx, e.AggregateID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// Уже объявлен или выдан задним числом (POST /badges/evaluate)
+	if badge.AnnouncedAt != nil {
+		return nil
+	}
+	rule, ok := achievements.Find(badge.Code)
+	if !ok {
+		return nil
+	}
+	team, err := b.teamRepo.GetByID(ctx, badge.TeamID)
+	if err != nil {
+		// Команда удалена - объявлять некому
+		log.Printf("ERROR: failed to get team for badge %d: %v", e.AggregateID, err)
+		return nil
+	}
+
+	msg := fmt.Sprintf("🎉 Команда <b>%s</b> получает достижение %s <b>%s</b>",
+		html.EscapeString(team.Name), rule.Emoji, rule.Title)
+	if _, err := b.tg.Send(&tele.Chat{ID: b.cfg.AnnounceChatID}, msg, tele.ModeHTML); err != nil {
+		// Повторим на следующем проходе
+		return err
+	}
+
+	if err := b.badgeRepo.MarkAnnounced(ctx, []int64{e.AggregateID}); err != nil {
+		log.Printf("ERROR: failed to mark badge announced: %v", err)
+	}
+	return nil
+}
+
+// badgesSection - блок достижений для карточки команды
+func badgesSection(badges []*domain.Badge) string {
+	lines := formatBadges(badges)
+	if len(lines) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n<b>🏅 Достижения (%d)</b>\n  %s\n", len(lines), strings.Join(lines, "\n  "))
+}
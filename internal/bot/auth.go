@@ -3,8 +3,12 @@ package bot
 
 import (
 	"context"
+	"log"
+	"slices"
+	"time"
 
 	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
 	tele "gopkg.in/telebot.v3"
 )
 
@@ -13,24 +17,66 @@ func (b *Bot) authMiddleware(next tele.HandlerFunc) tele.HandlerFunc {
 		ctx := context.Background()
 		sender := c.Sender()
 
-		user, err := b.userRepo.GetOrCreate(ctx, sender.ID, sender.Username)
+		user, err := b.userRepo.GetOrCreate(ctx, sender.ID, sender.Username, sender.FirstName, sender.LastName)
 		if err != nil {
 			return c.Send("Ошибка авторизации")
 		}
 
-		// Check if user is in admin list from config
-		for _, adminID := range b.cfg.AdminIDs {
-			if sender.ID == adminID && user.Role != domain.RoleAdmin {
-				_ = b.userRepo.UpdateRole(ctx, sender.ID, domain.RoleAdmin)
-				user.Role = domain.RoleAdmin
-			}
+		if user.IsBlocked(time.Now()) {
+			return b.replyBlocked(c, user)
 		}
 
+		if user.Role != domain.RoleAdmin && slices.Contains(b.cfg.AdminIDs, sender.ID) {
+			b.bootstrapAdmin(ctx, user)
+		}
+
+		role, err := b.roleRepo.GetByName(ctx, string(user.Role))
+		if err != nil {
+			log.Printf("ERROR: failed to load role %s: %v", user.Role, err)
+			return c.Send("Ошибка авторизации")
+		}
+		user.Permissions = role.Grants()
+
 		c.Set(string(userKey), user)
 		return next(c)
 	}
 }
 
+// bootstrapAdmin - назначает админом пользователя из ADMIN_IDS, пока админов нет.
+// С ADMIN_IDS_LOCKED - всегда: роль таких пользователей закреплена.
+func (b *Bot) bootstrapAdmin(ctx context.Context, user *domain.User) {
+	if !b.cfg.AdminIDsLocked {
+		_, admins, err := b.userRepo.List(ctx, repository.UserFilter{Role: domain.RoleAdmin, Limit: 1})
+		if err != nil {
+			log.Printf("ERROR: failed to count admins: %v", err)
+			return
+		}
+		if admins > 0 {
+			return
+		}
+	}
+	if err := b.userRepo.UpdateRole(ctx, user.TelegramID, domain.RoleAdmin, 0); err != nil {
+		log.Printf("ERROR: failed to promote admin %d: %v", user.TelegramID, err)
+		return
+	}
+	user.Role = domain.RoleAdmin
+}
+
+// replyBlocked - ответ заблокированному пользователю вместо обработки апдейта
+func (b *Bot) replyBlocked(c tele.Context, user *domain.User) error {
+	msg := "⛔ Вы заблокированы"
+	if user.BlockedUntil != nil {
+		msg += " до " + user.BlockedUntil.In(b.cfg.Location).Format("02.01.2006 15:04")
+	}
+	if user.BlockedReason != "" {
+		msg += ". Причина: " + user.BlockedReason
+	}
+	if c.Callback() != nil {
+		return c.Respond(&tele.CallbackResponse{Text: msg, ShowAlert: true})
+	}
+	return c.Send(msg)
+}
+
 func (b *Bot) getUser(c tele.Context) *domain.User {
 	if u, ok := c.Get(string(userKey)).(*domain.User); ok {
 		return u
@@ -38,20 +84,12 @@ func (b *Bot) getUser(c tele.Context) *domain.User {
 	return nil
 }
 
-func (b *Bot) requireOrganizer(c tele.Context) bool {
+// requirePermission - проверка, что роль пользователя даёт право
+func (b *Bot) requirePermission(c tele.Context, perm domain.Permission) bool {
 	user := b.getUser(c)
-	if user == nil || !user.CanManage() {
+	if user == nil || !user.Can(perm) {
 		_ = c.Send("У вас нет прав для этой команды")
 		return false
 	}
 	return true
 }
-
-func (b *Bot) requireAdmin(c tele.Context) bool {
-	user := b.getUser(c)
-	if user == nil || !user.IsAdmin() {
-		_ = c.Send("Только администратор может выполнить эту команду")
-		return false
-	}
-	return true
-}
@@ -2,13 +2,18 @@
 package bot
 
 import (
+	"context"
 	"log"
 	"time"
 
+	"github.com/eugene-twix/amber-bot/internal/api/middleware"
 	"github.com/eugene-twix/amber-bot/internal/cache"
 	"github.com/eugene-twix/amber-bot/internal/config"
+	"github.com/eugene-twix/amber-bot/internal/events"
 	"github.com/eugene-twix/amber-bot/internal/fsm"
+	"github.com/eugene-twix/amber-bot/internal/repository"
 	bunrepo "github.com/eugene-twix/amber-bot/internal/repository/bun"
+	"github.com/eugene-twix/amber-bot/internal/repository/cached"
 	"github.com/uptrace/bun"
 	tele "gopkg.in/telebot.v3"
 )
@@ -19,12 +24,22 @@ type Bot struct {
 	db         *bun.DB
 	cache      *cache.Cache
 	fsm        *fsm.Manager
+	sessions   *middleware.Sessions
 	userRepo   *bunrepo.UserRepo
-	teamRepo   *bunrepo.TeamRepo
-	memberRepo *bunrepo.MemberRepo
-	tournRepo  *bunrepo.TournamentRepo
-	resultRepo *bunrepo.ResultRepo
+	roleRepo   repository.RoleRepository
+	inviteRepo repository.InviteRepository
+	teamRepo   repository.TeamRepository
+	memberRepo repository.MemberRepository
+	tournRepo  repository.TournamentRepository
+	resultRepo repository.ResultRepository
+	venueRepo  repository.VenueRepository
+	discRepo   repository.DisciplineRepository
+	playerRepo repository.PlayerRepository
+	badgeRepo  *bunrepo.BadgeRepo
+	hookRepo   *bunrepo.WebhookRepo
+	events     *events.Dispatcher
 	miniAppURL string
+	stop       context.CancelFunc
 }
 
 func New(cfg *config.Config, db *bun.DB, cache *cache.Cache) (*Bot, error) {
@@ -44,14 +59,29 @@ func New(cfg *config.Config, db *bun.DB, cache *cache.Cache) (*Bot, error) {
 		db:         db,
 		cache:      cache,
 		fsm:        fsm.NewManager(cache),
+		sessions:   middleware.NewSessions(cfg.SessionSecret, cache),
 		userRepo:   bunrepo.NewUserRepo(db),
-		teamRepo:   bunrepo.NewTeamRepo(db),
-		memberRepo: bunrepo.NewMemberRepo(db),
-		tournRepo:  bunrepo.NewTournamentRepo(db),
-		resultRepo: bunrepo.NewResultRepo(db),
+		roleRepo:   cached.NewRoleRepo(bunrepo.NewRoleRepo(db), cache),
+		inviteRepo: bunrepo.NewInviteRepo(db),
+		teamRepo:   cached.NewTeamRepo(bunrepo.NewTeamRepo(db), cache),
+		memberRepo: cached.NewMemberRepo(bunrepo.NewMemberRepo(db), cache),
+		tournRepo:  cached.NewTournamentRepo(bunrepo.NewTournamentRepo(db), cache),
+		resultRepo: cached.NewResultRepo(bunrepo.NewResultRepo(db, cfg.Location.String()), cache),
+		venueRepo:  cached.NewVenueRepo(bunrepo.NewVenueRepo(db), cache),
+		discRepo:   cached.NewDisciplineRepo(bunrepo.NewDisciplineRepo(db), cache),
+		playerRepo: cached.NewPlayerRepo(bunrepo.NewPlayerRepo(db), cache),
+		badgeRepo:  bunrepo.NewBadgeRepo(db),
+		hookRepo:   bunrepo.NewWebhookRepo(db),
 		miniAppURL: cfg.MiniAppURL,
 	}
 
+	// Те же подписчики, что и в API: кто первым возьмёт событие, тот и обработает
+	b.events = events.NewDispatcher(bunrepo.NewEventRepo(db), events.Default(cache, b.hookRepo, b.badgeRepo)...)
+	b.events.Subscribe(b.roleNotifier())
+	if cfg.AnnounceChatID != 0 {
+		b.events.Subscribe(b.badgeAnnouncer())
+	}
+
 	b.registerHandlers()
 	return b, nil
 }
@@ -60,8 +90,12 @@ func (b *Bot) registerHandlers() {
 	// Middleware
 	b.tg.Use(b.authMiddleware)
 
-	// Commands (only /start)
+	// Commands
 	b.tg.Handle("/start", b.handleStart)
+	b.tg.Handle("/next", b.handleNext)
+	b.tg.Handle("/finalize", b.handleFinalize)
+	b.tg.Handle("/block", b.handleBlock)
+	b.tg.Handle("/unblock", b.handleUnblock)
 
 	// Text messages (for buttons and FSM)
 	b.tg.Handle(tele.OnText, b.handleText)
@@ -70,12 +104,26 @@ func (b *Bot) registerHandlers() {
 	b.tg.Handle(tele.OnCallback, b.handleCallback)
 }
 
+// revokeSessions - сессии Mini App пользователя больше не действуют
+// (смена роли, блокировка)
+func (b *Bot) revokeSessions(ctx context.Context, telegramID int64) {
+	if err := b.sessions.RevokeUser(ctx, telegramID); err != nil {
+		log.Printf("ERROR: failed to revoke sessions: %v", err)
+	}
+}
+
 func (b *Bot) Start() {
 	log.Println("Bot started")
+	ctx, cancel := context.WithCancel(context.Background())
+	b.stop = cancel
+	go b.events.Run(ctx)
 	b.tg.Start()
 }
 
 func (b *Bot) Stop() {
+	if b.stop != nil {
+		b.stop()
+	}
 	b.tg.Stop()
 }
 
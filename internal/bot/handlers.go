@@ -3,12 +3,17 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"html"
 	"log"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/eugene-twix/amber-bot/internal/domain"
 	"github.com/eugene-twix/amber-bot/internal/fsm"
+	"github.com/eugene-twix/amber-bot/internal/repository"
 	tele "gopkg.in/telebot.v3"
 )
 
@@ -18,6 +23,11 @@ func (b *Bot) handleStart(c tele.Context) error {
 	// Сначала убираем Reply Keyboard (отдельным сообщением)
 	_ = c.Send("👋", &tele.ReplyMarkup{RemoveKeyboard: true})
 
+	// Deep link t.me/<bot>?start=inv_<token> - ссылка-приглашение на роль
+	if payload := strings.TrimSpace(c.Message().Payload); strings.HasPrefix(payload, domain.InvitePrefix) {
+		b.redeemInvite(c, user, payload)
+	}
+
 	msg := fmt.Sprintf(`Привет, %s!
 
 Это бот для учёта результатов квизов и настолок.
@@ -38,6 +48,82 @@ func (b *Bot) handleStart(c tele.Context) error {
 	return c.Send(msg)
 }
 
+// redeemInvite - применение ссылки-приглашения; при успехе меняет роль user
+func (b *Bot) redeemInvite(c tele.Context, user *domain.User, token string) {
+	ctx := context.Background()
+
+	invite, err := b.inviteRepo.Redeem(ctx, domain.HashInviteToken(token), user.TelegramID, time.Now())
+	switch {
+	case err == nil:
+	case errors.Is(err, domain.ErrInviteNotNeeded):
+		_ = c.Send(fmt.Sprintf("У вас уже есть роль %s", user.Role))
+		return
+	case errors.Is(err, domain.ErrInviteExpired):
+		_ = c.Send("Срок действия приглашения истёк")
+		return
+	case errors.Is(err, domain.ErrInviteUsedUp):
+		_ = c.Send("Приглашение уже использовано")
+		return
+	case errors.Is(err, domain.ErrInviteInvalid):
+		_ = c.Send("Приглашение недействительно")
+		return
+	default:
+		log.Printf("ERROR: failed to redeem invite: %v", err)
+		_ = c.Send("Ошибка сервиса. Попробуйте позже.")
+		return
+	}
+
+	// Сессии Mini App со старой ролью больше не действуют
+	b.revokeSessions(ctx, user.TelegramID)
+
+	user.Role = invite.Role
+	_ = c.Send(fmt.Sprintf("✅ Приглашение принято, ваша роль: %s", invite.Role))
+}
+
+// /next [команда] - ближайший или идущий сейчас турнир
+func (b *Bot) handleNext(c tele.Context) error {
+	ctx := context.Background()
+
+	var teamID int64
+	if name := strings.TrimSpace(c.Message().Payload); name != "" {
+		team, err := b.teamRepo.GetByName(ctx, name)
+		if err != nil {
+			return c.Send(fmt.Sprintf("Команда «%s» не найдена", name))
+		}
+		teamID = team.ID
+	}
+
+	for _, phase := range []string{domain.PhaseOngoing, domain.PhaseUpcoming} {
+		tournaments, err := b.tournRepo.ListByPhase(ctx, repository.TournamentFilter{
+			Phase:           phase,
+			TeamID:          teamID,
+			Now:             time.Now(),
+			DefaultTimezone: b.cfg.Location.String(),
+			Limit:           1,
+		})
+		if err != nil {
+			log.Printf("ERROR: failed to list tournaments: %v", err)
+			return c.Send("Ошибка получения турниров")
+		}
+		if len(tournaments) == 0 {
+			continue
+		}
+
+		t := tournaments[0]
+		title := "📅 Ближайший турнир"
+		if phase == domain.PhaseOngoing {
+			title = "🔴 Идёт сейчас"
+		}
+		msg := fmt.Sprintf("<b>%s</b>\n%s\n🕒 %s", title, html.EscapeString(t.Name), b.formatTournamentStart(t))
+		if t.Location != "" {
+			msg += "\n📍 " + html.EscapeString(t.Location)
+		}
+		return c.Send(msg, tele.ModeHTML)
+	}
+
+	return c.Send("Запланированных турниров нет")
+}
+
 func (b *Bot) handleText(c tele.Context) error {
 	ctx := context.Background()
 	text := strings.TrimSpace(c.Text())
@@ -69,6 +155,8 @@ func (b *Bot) handleText(c tele.Context) error {
 		return b.handleResult(c)
 	case BtnGrant:
 		return b.handleGrant(c)
+	case BtnMergeTeams:
+		return b.handleMergeTeams(c)
 	default:
 		user := b.getUser(c)
 		return c.Send("Используйте кнопки для работы с ботом.", MainMenu(user.Role))
@@ -85,6 +173,10 @@ func (b *Bot) handleFSMText(c tele.Context, state *fsm.UserState) error {
 		return b.processAddMemberName(c, state)
 	case fsm.StateNewTournamentName:
 		return b.processNewTournamentName(c, state)
+	case fsm.StateNewTournamentDiscipline:
+		return c.Send("Выберите вид игры кнопкой выше", CancelMenu())
+	case fsm.StateNewTournamentMode:
+		return c.Send("Выберите зачёт кнопкой выше", CancelMenu())
 	case fsm.StateNewTournamentDate:
 		return b.processNewTournamentDate(c, state)
 	case fsm.StateNewTournamentLocation:
@@ -132,31 +224,53 @@ func (b *Bot) showTeamsPage(c tele.Context, page int, edit bool) error {
 	return c.Send("Выберите команду:", kb)
 }
 
+// handleRating - рейтинг основного вида игры: места разных видов несравнимы,
+// общий рейтинг - кнопкой «Все»
 func (b *Bot) handleRating(c tele.Context) error {
-	return b.showRatingPage(c, 0, false)
+	var disciplineID int64
+	disciplines, err := b.discRepo.List(context.Background())
+	if err != nil {
+		log.Printf("ERROR: failed to list disciplines: %v", err)
+	}
+	if len(disciplines) > 0 {
+		disciplineID = disciplines[0].ID
+	}
+	return b.showRatingPage(c, disciplineID, 0, false)
 }
 
-func (b *Bot) showRatingPage(c tele.Context, page int, edit bool) error {
+// showRatingPage - рейтинг по виду игры (0 - по всем)
+func (b *Bot) showRatingPage(c tele.Context, disciplineID int64, page int, edit bool) error {
 	ctx := context.Background()
-	ratings, err := b.resultRepo.GetTeamRating(ctx)
+	ratings, err := b.resultRepo.GetTeamRating(ctx, disciplineID)
 	if err != nil {
 		log.Printf("ERROR: failed to get team rating: %v", err)
 		return c.Send("Ошибка получения рейтинга")
 	}
 
-	if len(ratings) == 0 {
+	disciplines, err := b.discRepo.List(ctx)
+	if err != nil {
+		log.Printf("ERROR: failed to list disciplines: %v", err)
+	}
+	title := "🏆 Рейтинг команд"
+	for _, d := range disciplines {
+		if d.ID == disciplineID {
+			title += " — " + d.Name
+		}
+	}
+
+	if len(ratings) == 0 && disciplineID == 0 {
 		return c.Send("Рейтинг пуст — нет результатов")
 	}
 
 	const ratingPageSize = 5
 	totalPages := (len(ratings) + ratingPageSize - 1) / ratingPageSize
 
-	if page < 0 {
-		page = 0
-	}
 	if page >= totalPages {
 		page = totalPages - 1
 	}
+	if page < 0 {
+		page = 0
+	}
 
 	start := page * ratingPageSize
 	end := start + ratingPageSize
@@ -165,8 +279,11 @@ func (b *Bot) showRatingPage(c tele.Context, page int, edit bool) error {
 	}
 
 	var sb strings.Builder
-	sb.WriteString("<b>🏆 Рейтинг команд</b>\n")
+	sb.WriteString("<b>" + html.EscapeString(title) + "</b>\n")
 	sb.WriteString(Separator + "\n\n")
+	if len(ratings) == 0 {
+		sb.WriteString("Нет результатов\n")
+	}
 	for i := start; i < end; i++ {
 		r := ratings[i]
 		medal := "    "
@@ -188,7 +305,7 @@ func (b *Bot) showRatingPage(c tele.Context, page int, edit bool) error {
 		if page > 0 {
 			navRow = append(navRow, tele.InlineButton{
 				Text: "◀️",
-				Data: fmt.Sprintf("rating_page:%d", page-1),
+				Data: fmt.Sprintf("rating_page:%d:%d", disciplineID, page-1),
 			})
 		}
 		navRow = append(navRow, tele.InlineButton{
@@ -198,26 +315,186 @@ func (b *Bot) showRatingPage(c tele.Context, page int, edit bool) error {
 		if page < totalPages-1 {
 			navRow = append(navRow, tele.InlineButton{
 				Text: "▶️",
-				Data: fmt.Sprintf("rating_page:%d", page+1),
+				Data: fmt.Sprintf("rating_page:%d:%d", disciplineID, page+1),
 			})
 		}
 	}
 
-	var kb *tele.ReplyMarkup
+	var rows [][]tele.InlineButton
 	if len(navRow) > 0 {
-		kb = &tele.ReplyMarkup{InlineKeyboard: [][]tele.InlineButton{navRow}}
+		rows = append(rows, navRow)
 	}
 
+	// Переключатель вида игры
+	if len(disciplines) > 1 {
+		discRow := []tele.InlineButton{{Text: "Все", Data: "rating_page:0:0"}}
+		for _, d := range disciplines {
+			discRow = append(discRow, tele.InlineButton{
+				Text: d.Name,
+				Data: fmt.Sprintf("rating_page:%d:0", d.ID),
+			})
+		}
+		rows = append(rows, discRow)
+	}
+	rows = append(rows, []tele.InlineButton{
+		{Text: "👤 Личный зачёт", Data: fmt.Sprintf("rating_players:%d", disciplineID)},
+	})
+
+	kb := &tele.ReplyMarkup{InlineKeyboard: rows}
+
 	if edit {
-		if kb != nil {
-			return c.Edit(sb.String(), kb, tele.ModeHTML)
+		return c.Edit(sb.String(), kb, tele.ModeHTML)
+	}
+	return c.Send(sb.String(), kb, tele.ModeHTML)
+}
+
+// showPlayerRating - топ игроков личного зачёта по виду игры (0 - по всем)
+func (b *Bot) showPlayerRating(c tele.Context, disciplineID int64) error {
+	ratings, err := b.resultRepo.GetPlayerRating(context.Background(), disciplineID)
+	if err != nil {
+		log.Printf("ERROR: failed to get player rating: %v", err)
+		return c.Send("Ошибка получения рейтинга")
+	}
+
+	const playerRatingSize = 10
+	if len(ratings) > playerRatingSize {
+		ratings = ratings[:playerRatingSize]
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<b>👤 Рейтинг игроков</b>\n")
+	sb.WriteString(Separator + "\n\n")
+	if len(ratings) == 0 {
+		sb.WriteString("Нет результатов в личном зачёте\n")
+	}
+	for i, r := range ratings {
+		sb.WriteString(fmt.Sprintf("<b>%d. %s</b>\n", i+1, html.EscapeString(r.PlayerName)))
+		sb.WriteString(fmt.Sprintf("    Побед: <code>%d</code> | Игр: <code>%d</code> | Ср: <code>%.1f</code>\n\n", r.Wins, r.TotalGames, r.AvgPlace))
+	}
+
+	kb := &tele.ReplyMarkup{InlineKeyboard: [][]tele.InlineButton{
+		{{Text: "🏆 Команды", Data: fmt.Sprintf("rating_page:%d:0", disciplineID)}},
+	}}
+	return c.Edit(sb.String(), kb, tele.ModeHTML)
+}
+
+// handleHeadToHeadPickCallback - выбор соперника для сравнения
+func (b *Bot) handleHeadToHeadPickCallback(c tele.Context, payload string) error {
+	teamID, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return c.Send("Ошибка: неверный ID команды")
+	}
+	return b.showHeadToHeadPage(c, teamID, 0, false)
+}
+
+// handleHeadToHeadPageCallback - пагинация соперников, payload: "teamID:page"
+func (b *Bot) handleHeadToHeadPageCallback(c tele.Context, payload string) error {
+	parts := strings.Split(payload, ":")
+	if len(parts) != 2 {
+		return c.Send("Ошибка формата данных")
+	}
+	teamID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return c.Send("Ошибка: неверный ID команды")
+	}
+	page, _ := strconv.Atoi(parts[1])
+	return b.showHeadToHeadPage(c, teamID, page, true)
+}
+
+func (b *Bot) showHeadToHeadPage(c tele.Context, teamID int64, page int, edit bool) error {
+	ctx := context.Background()
+	teams, err := b.teamRepo.List(ctx)
+	if err != nil {
+		log.Printf("ERROR: failed to list teams: %v", err)
+		return c.Send("Ошибка получения списка команд")
+	}
+
+	var items []PaginatedItem
+	for _, t := range teams {
+		if t.ID == teamID {
+			continue
 		}
+		items = append(items, PaginatedItem{
+			Text: t.Name,
+			Data: fmt.Sprintf("h2h:%d:%d", teamID, t.ID),
+		})
+	}
+	if len(items) == 0 {
+		return c.Send("Нет других команд для сравнения")
+	}
+
+	kb := PaginatedKeyboard(fmt.Sprintf("h2h_page:%d", teamID), items, page)
+	if edit {
+		return c.Edit("С кем сравнить?", kb)
+	}
+	return c.Send("С кем сравнить?", kb)
+}
+
+// handleHeadToHeadCallback - личные встречи двух команд, payload: "teamID:otherID"
+func (b *Bot) handleHeadToHeadCallback(c tele.Context, payload string) error {
+	parts := strings.Split(payload, ":")
+	if len(parts) != 2 {
+		return c.Send("Ошибка формата данных")
+	}
+	teamID, err1 := strconv.ParseInt(parts[0], 10, 64)
+	otherID, err2 := strconv.ParseInt(parts[1], 10, 64)
+	if err1 != nil || err2 != nil {
+		return c.Send("Ошибка: неверный ID команды")
+	}
+
+	ctx := context.Background()
+	team, err := b.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return c.Send("Команда не найдена")
+	}
+	other, err := b.teamRepo.GetByID(ctx, otherID)
+	if err != nil {
+		return c.Send("Команда не найдена")
+	}
+
+	games, err := b.resultRepo.GetHeadToHead(ctx, teamID, otherID)
+	if err != nil {
+		log.Printf("ERROR: failed to get head-to-head: %v", err)
+		return c.Send("Ошибка получения результатов")
+	}
+	h2h := repository.NewHeadToHead(teamID, otherID, games)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>⚔️ %s vs %s</b>\n", html.EscapeString(team.Name), html.EscapeString(other.Name)))
+	sb.WriteString(Separator + "\n\n")
+
+	if len(h2h.Games) == 0 {
+		sb.WriteString("<i>Команды ещё не встречались</i>")
 		return c.Edit(sb.String(), tele.ModeHTML)
 	}
-	if kb != nil {
-		return c.Send(sb.String(), kb, tele.ModeHTML)
+
+	sb.WriteString(fmt.Sprintf("Встреч: <code>%d</code>\n", len(h2h.Games)))
+	sb.WriteString(fmt.Sprintf("Выше: <code>%d</code> | Ниже: <code>%d</code> | Вровень: <code>%d</code>\n", h2h.Wins, h2h.Losses, h2h.Draws))
+	sb.WriteString(fmt.Sprintf("Средняя разница мест: <code>%+.1f</code>\n\n", h2h.AvgPlaceDiff))
+
+	// Keep message under Telegram limit
+	const maxGames = 20
+	shown := h2h.Games
+	if len(shown) > maxGames {
+		shown = shown[:maxGames]
 	}
-	return c.Send(sb.String(), tele.ModeHTML)
+
+	for _, g := range shown {
+		mark := "🤝"
+		switch {
+		case g.Place < g.OtherPlace:
+			mark = "✅"
+		case g.Place > g.OtherPlace:
+			mark = "❌"
+		}
+		sb.WriteString(fmt.Sprintf("%s %s (%s) — <code>%d : %d</code>\n",
+			mark, html.EscapeString(g.TournamentName), g.TournamentDate.Format("02.01.2006"), g.Place, g.OtherPlace))
+	}
+	if rest := len(h2h.Games) - len(shown); rest > 0 {
+		sb.WriteString(fmt.Sprintf("<i>… и ещё %d</i>\n", rest))
+	}
+
+	return c.Edit(sb.String(), tele.ModeHTML)
 }
 
 func (b *Bot) handleCancel(c tele.Context) error {
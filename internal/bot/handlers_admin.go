@@ -3,33 +3,38 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"html"
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/events"
 	"github.com/eugene-twix/amber-bot/internal/fsm"
+	"github.com/eugene-twix/amber-bot/internal/repository"
 	tele "gopkg.in/telebot.v3"
 )
 
 // handleGrant - выдать роль пользователю
 func (b *Bot) handleGrant(c tele.Context) error {
-	if !b.requireAdmin(c) {
+	if !b.requirePermission(c, domain.PermUsersManage) {
 		return nil
 	}
 	return b.showGrantUsersPage(c, 0, false)
 }
 
 func (b *Bot) showGrantUsersPage(c tele.Context, page int, edit bool) error {
-	if !b.requireAdmin(c) {
+	if !b.requirePermission(c, domain.PermUsersManage) {
 		return nil
 	}
 
 	ctx := context.Background()
 
 	// Get list of users
-	users, err := b.userRepo.List(ctx)
+	users, _, err := b.userRepo.List(ctx, repository.UserFilter{})
 	if err != nil {
 		log.Printf("ERROR: failed to list users: %v", err)
 		return c.Send("Ошибка сервиса. Попробуйте позже.", MainMenu(domain.RoleAdmin))
@@ -43,41 +48,26 @@ func (b *Bot) showGrantUsersPage(c tele.Context, page int, edit bool) error {
 			continue
 		}
 
-		displayName := u.Username
-		if displayName == "" {
-			displayName = fmt.Sprintf("ID: %d", u.TelegramID)
-		}
-
-		// Add role icon
-		roleIcon := "👀"
-		switch u.Role {
-		case domain.RoleOrganizer:
-			roleIcon = "📝"
-		case domain.RoleAdmin:
-			roleIcon = "👑"
+		icon := roleIcon(u.Role)
+		if u.IsBlocked(time.Now()) {
+			icon = "⛔"
 		}
 
 		items = append(items, PaginatedItem{
-			Text: fmt.Sprintf("%s %s", roleIcon, displayName),
+			Text: fmt.Sprintf("%s %s", icon, u.DisplayName()),
 			Data: fmt.Sprintf("grant_user:%d", u.TelegramID),
 		})
 	}
 
-	// Add option to enter ID manually at the end
+	// Add options to enter ID manually or invite by link at the end
 	items = append(items, PaginatedItem{
 		Text: "✏️ Ввести ID вручную",
 		Data: "grant_user:manual",
+	}, PaginatedItem{
+		Text: "🔗 Ссылка-приглашение",
+		Data: "grant_user:invite",
 	})
 
-	if len(items) == 1 {
-		// Only "enter manually" option
-		if err := b.fsm.Set(ctx, c.Sender().ID, fsm.StateGrantUser, fsm.Data{}); err != nil {
-			log.Printf("ERROR: failed to set FSM state: %v", err)
-			return c.Send("Ошибка сервиса. Попробуйте позже.")
-		}
-		return c.Send("Нет других пользователей. Введите Telegram ID:", CancelMenu())
-	}
-
 	kb := PaginatedKeyboard("grant_page", items, page)
 
 	if edit {
@@ -88,7 +78,7 @@ func (b *Bot) showGrantUsersPage(c tele.Context, page int, edit bool) error {
 
 // handleGrantUserCallback - обработка выбора пользователя из списка
 func (b *Bot) handleGrantUserCallback(c tele.Context, payload string) error {
-	if !b.requireAdmin(c) {
+	if !b.requirePermission(c, domain.PermUsersManage) {
 		return nil
 	}
 
@@ -100,7 +90,19 @@ func (b *Bot) handleGrantUserCallback(c tele.Context, payload string) error {
 			log.Printf("ERROR: failed to set FSM state: %v", err)
 			return c.Send("Ошибка сервиса. Попробуйте позже.")
 		}
-		return c.Edit("Введите Telegram ID пользователя:")
+		return c.Edit("Введите Telegram ID, @username или имя пользователя:")
+	}
+
+	// Invite link: the role is applied when the link is opened
+	if payload == "invite" {
+		buttons, err := b.roleButtons(ctx, func(r *domain.RoleDef) string {
+			return fmt.Sprintf("invite_role:%d", r.ID)
+		})
+		if err != nil {
+			log.Printf("ERROR: failed to list roles: %v", err)
+			return c.Respond(&tele.CallbackResponse{Text: "Ошибка сервиса"})
+		}
+		return c.Edit("Какую роль даст ссылка?", &tele.ReplyMarkup{InlineKeyboard: buttons})
 	}
 
 	// Parse user ID
@@ -118,22 +120,13 @@ func (b *Bot) handleGrantUserCallback(c tele.Context, payload string) error {
 	}
 
 	// Show role selection
-	buttons := [][]tele.InlineButton{
-		{
-			{Text: "👀 Viewer", Data: fmt.Sprintf("grant_role:%d:%s", userID, domain.RoleViewer)},
-			{Text: "📝 Organizer", Data: fmt.Sprintf("grant_role:%d:%s", userID, domain.RoleOrganizer)},
-		},
-		{
-			{Text: "👑 Admin", Data: fmt.Sprintf("grant_role:%d:%s", userID, domain.RoleAdmin)},
-		},
-	}
-
-	displayName := targetUser.Username
-	if displayName == "" {
-		displayName = fmt.Sprintf("ID: %d", userID)
+	buttons, err := b.roleButtons(ctx, grantRoleData(userID))
+	if err != nil {
+		log.Printf("ERROR: failed to list roles: %v", err)
+		return c.Respond(&tele.CallbackResponse{Text: "Ошибка сервиса"})
 	}
 
-	return c.Edit(fmt.Sprintf("Выберите роль для %s:", displayName), &tele.ReplyMarkup{InlineKeyboard: buttons})
+	return c.Edit(fmt.Sprintf("Выберите роль для %s:", targetUser.DisplayName()), &tele.ReplyMarkup{InlineKeyboard: buttons})
 }
 
 func (b *Bot) processGrantUser(c tele.Context, _ *fsm.UserState) error {
@@ -146,34 +139,486 @@ func (b *Bot) processGrantUser(c tele.Context, _ *fsm.UserState) error {
 		return c.Send("Состояние изменилось. Попробуйте снова.", MainMenu(user.Role))
 	}
 
-	idStr := strings.TrimSpace(c.Text())
-
-	userID, err := strconv.ParseInt(idStr, 10, 64)
-	if err != nil {
-		return c.Send("Неверный ID. Введите числовой Telegram ID:", CancelMenu())
+	query := strings.TrimSpace(c.Text())
+	if query == "" {
+		return c.Send("Введите Telegram ID, @username или имя пользователя:", CancelMenu())
 	}
 
-	// Check user exists
-	_, err = b.userRepo.GetByTelegramID(ctx, userID)
+	users, _, err := b.userRepo.List(ctx, repository.UserFilter{Query: query, Limit: 10})
 	if err != nil {
-		log.Printf("ERROR: failed to get user by telegram ID: %v", err)
+		log.Printf("ERROR: failed to search users: %v", err)
+		return c.Send("Ошибка сервиса. Попробуйте позже.")
+	}
+	if len(users) == 0 {
 		return c.Send("Пользователь не найден. Он должен сначала написать боту /start", CancelMenu())
 	}
 
+	// Several matches: let the admin pick one from the list
+	if len(users) > 1 {
+		if err := b.fsm.Clear(ctx, c.Sender().ID); err != nil {
+			log.Printf("ERROR: failed to clear FSM state: %v", err)
+		}
+		var rows [][]tele.InlineButton
+		for _, u := range users {
+			rows = append(rows, []tele.InlineButton{{
+				Text: fmt.Sprintf("%s %s", roleIcon(u.Role), u.DisplayName()),
+				Data: fmt.Sprintf("grant_user:%d", u.TelegramID),
+			}})
+		}
+		return c.Send("Найдено несколько пользователей, выберите:", &tele.ReplyMarkup{InlineKeyboard: rows})
+	}
+	userID := users[0].TelegramID
+
 	if err := b.fsm.Update(ctx, c.Sender().ID, fsm.StateGrantRole, "user_id", userID); err != nil {
 		log.Printf("ERROR: failed to update FSM state: %v", err)
 		return c.Send("Ошибка сервиса. Попробуйте позже.")
 	}
 
+	buttons, err := b.roleButtons(ctx, grantRoleData(userID))
+	if err != nil {
+		log.Printf("ERROR: failed to list roles: %v", err)
+		return c.Send("Ошибка сервиса. Попробуйте позже.")
+	}
+
+	return c.Send("Выберите роль:", &tele.ReplyMarkup{InlineKeyboard: buttons})
+}
+
+// roleChangeMessage - текст ошибки назначения роли
+func roleChangeMessage(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrOwnRole):
+		return "Нельзя изменить свою роль"
+	case errors.Is(err, domain.ErrAdminRequired):
+		return "Назначать и снимать админов может только админ"
+	case errors.Is(err, domain.ErrPermissionsExceeded):
+		return "У роли есть права, которых нет у вас"
+	case errors.Is(err, domain.ErrRoleLocked):
+		return "Роль закреплена в ADMIN_IDS и не меняется"
+	case errors.Is(err, domain.ErrLastAdmin):
+		return "Нельзя снять последнего админа"
+	}
+	return "Ошибка при изменении роли"
+}
+
+// roleNotifier - сообщает пользователю об изменении его роли,
+// в том числе сделанном через API
+func (b *Bot) roleNotifier() events.Subscriber {
+	return events.Subscriber{
+		Name:   "role_notifier",
+		Types:  []string{domain.EventUserRoleChanged},
+		Handle: b.notifyRoleChange,
+	}
+}
+
+func (b *Bot) notifyRoleChange(ctx context.Context, e *domain.Event) error {
+	role, _ := e.Payload["role"].(string)
+	msg := fmt.Sprintf("%s Ваша роль изменена: %s", roleIcon(domain.Role(role)), role)
+	if _, err := b.tg.Send(&tele.User{ID: e.AggregateID}, msg); err != nil {
+		// Пользователь мог остановить бота - не повторяем
+		log.Printf("ERROR: failed to notify user %d about role change: %v", e.AggregateID, err)
+	}
+	return nil
+}
+
+// roleIcon - значок роли в списках; у пользовательских ролей общий
+func roleIcon(role domain.Role) string {
+	switch role {
+	case domain.RoleViewer:
+		return "👀"
+	case domain.RoleOrganizer:
+		return "📝"
+	case domain.RoleAdmin:
+		return "👑"
+	}
+	return "🔑"
+}
+
+// grantRoleData - callback назначения роли пользователю; передаётся ID роли
+// (имя может не влезть в 64 байта)
+func grantRoleData(userID int64) func(*domain.RoleDef) string {
+	return func(r *domain.RoleDef) string {
+		return fmt.Sprintf("grant_role:%d:%d", userID, r.ID)
+	}
+}
+
+// roleButtons - кнопки всех ролей, по две в ряд
+func (b *Bot) roleButtons(ctx context.Context, data func(*domain.RoleDef) string) ([][]tele.InlineButton, error) {
+	roles, err := b.roleRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var buttons [][]tele.InlineButton
+	for i, r := range roles {
+		btn := tele.InlineButton{
+			Text: fmt.Sprintf("%s %s", roleIcon(domain.Role(r.Name)), r.Name),
+			Data: data(r),
+		}
+		if i%2 == 0 {
+			buttons = append(buttons, []tele.InlineButton{btn})
+		} else {
+			buttons[len(buttons)-1] = append(buttons[len(buttons)-1], btn)
+		}
+	}
+	return buttons, nil
+}
+
+// handleInviteRoleCallback - создание ссылки-приглашения на роль
+// (одно использование, 7 дней)
+func (b *Bot) handleInviteRoleCallback(c tele.Context, payload string) error {
+	if !b.requirePermission(c, domain.PermUsersManage) {
+		return nil
+	}
+
+	ctx := context.Background()
+
+	// Payload: roleID[:ok], ok - admin invite confirmed
+	idStr, confirmed := strings.CutSuffix(payload, ":ok")
+	roleID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "Ошибка"})
+	}
+	role, err := b.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		log.Printf("ERROR: failed to get role: %v", err)
+		return c.Respond(&tele.CallbackResponse{Text: "Роль не найдена"})
+	}
+	// Приглашение действует только на viewer
+	if role.Name == string(domain.RoleViewer) {
+		return c.Respond(&tele.CallbackResponse{Text: "Все новые пользователи уже viewer"})
+	}
+
+	// Приглашение в админы - то же назначение админом
+	if role.Name == string(domain.RoleAdmin) {
+		if !b.getUser(c).IsAdmin() {
+			return c.Edit(roleChangeMessage(domain.ErrAdminRequired))
+		}
+		if !confirmed {
+			buttons := [][]tele.InlineButton{
+				{
+					{Text: "👑 Создать ссылку", Data: fmt.Sprintf("invite_role:%d:ok", roleID)},
+					{Text: "❌ Отмена", Data: "grant_cancel:0"},
+				},
+			}
+			return c.Edit("Ссылка сделает админом любого, кто её откроет. Создать?",
+				&tele.ReplyMarkup{InlineKeyboard: buttons})
+		}
+	}
+
+	if !b.getUser(c).CanAll(role.Grants()) {
+		return c.Edit(roleChangeMessage(domain.ErrPermissionsExceeded))
+	}
+
+	token, prefix, err := domain.NewInviteToken()
+	if err != nil {
+		log.Printf("ERROR: failed to generate invite token: %v", err)
+		return c.Respond(&tele.CallbackResponse{Text: "Ошибка сервиса"})
+	}
+
+	invite := &domain.Invite{
+		Prefix:    prefix,
+		TokenHash: domain.HashInviteToken(token),
+		Role:      domain.Role(role.Name),
+		MaxUses:   1,
+		ExpiresAt: time.Now().Add(domain.InviteTTL),
+		CreatedBy: c.Sender().ID,
+	}
+	if err := b.inviteRepo.Create(ctx, invite); err != nil {
+		log.Printf("ERROR: failed to create invite: %v", err)
+		return c.Respond(&tele.CallbackResponse{Text: "Ошибка сервиса"})
+	}
+
+	return c.Edit(fmt.Sprintf("🔗 Ссылка на роль %s (одно использование, до %s):\n\n%s",
+		role.Name, invite.ExpiresAt.In(b.cfg.Location).Format("02.01.2006 15:04"),
+		domain.InviteLink(b.tg.Me.Username, token)))
+}
+
+// /block <telegram_id> [дней] <причина> - заблокировать пользователя
+// (без срока - до разблокировки)
+func (b *Bot) handleBlock(c tele.Context) error {
+	if !b.requirePermission(c, domain.PermUsersManage) {
+		return nil
+	}
+
+	ctx := context.Background()
+	usage := "Формат: /block <telegram_id> [дней] <причина>"
+
+	fields := strings.Fields(c.Message().Payload)
+	if len(fields) < 2 {
+		return c.Send(usage)
+	}
+	userID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return c.Send(usage)
+	}
+	fields = fields[1:]
+
+	var until *time.Time
+	if days, err := strconv.Atoi(fields[0]); err == nil && days > 0 {
+		t := time.Now().AddDate(0, 0, days)
+		until = &t
+		fields = fields[1:]
+	}
+	reason := strings.Join(fields, " ")
+	if reason == "" {
+		return c.Send(usage)
+	}
+
+	target, err := b.userRepo.GetByTelegramID(ctx, userID)
+	if err != nil {
+		return c.Send("Пользователь не найден")
+	}
+	if userID == c.Sender().ID {
+		return c.Send("Нельзя заблокировать себя")
+	}
+	if target.IsAdmin() {
+		return c.Send("Нельзя заблокировать администратора")
+	}
+
+	if err := b.userRepo.Block(ctx, userID, reason, until, c.Sender().ID); err != nil {
+		log.Printf("ERROR: failed to block user: %v", err)
+		return c.Send("Ошибка сервиса. Попробуйте позже.")
+	}
+
+	// Сессии Mini App заблокированного пользователя больше не действуют
+	b.revokeSessions(ctx, userID)
+
+	msg := fmt.Sprintf("⛔ Пользователь %d заблокирован", userID)
+	if until != nil {
+		msg += " до " + until.In(b.cfg.Location).Format("02.01.2006 15:04")
+	}
+	return c.Send(msg + ". Причина: " + reason)
+}
+
+// /unblock <telegram_id> - снять блокировку
+func (b *Bot) handleUnblock(c tele.Context) error {
+	if !b.requirePermission(c, domain.PermUsersManage) {
+		return nil
+	}
+
+	ctx := context.Background()
+
+	userID, err := strconv.ParseInt(strings.TrimSpace(c.Message().Payload), 10, 64)
+	if err != nil {
+		return c.Send("Формат: /unblock <telegram_id>")
+	}
+	if _, err := b.userRepo.GetByTelegramID(ctx, userID); err != nil {
+		return c.Send("Пользователь не найден")
+	}
+
+	if err := b.userRepo.Unblock(ctx, userID, c.Sender().ID); err != nil {
+		log.Printf("ERROR: failed to unblock user: %v", err)
+		return c.Send("Ошибка сервиса. Попробуйте позже.")
+	}
+
+	return c.Send(fmt.Sprintf("✅ Пользователь %d разблокирован", userID))
+}
+
+// handleMergeTeams - объединить дубликаты команд
+func (b *Bot) handleMergeTeams(c tele.Context) error {
+	if !b.requirePermission(c, domain.PermTeamsMerge) {
+		return nil
+	}
+	return b.showMergeSourcePage(c, 0, false)
+}
+
+// showMergeSourcePage - шаг 1: какую команду объединяем (она будет удалена)
+func (b *Bot) showMergeSourcePage(c tele.Context, page int, edit bool) error {
+	if !b.requirePermission(c, domain.PermTeamsMerge) {
+		return nil
+	}
+
+	ctx := context.Background()
+	teams, err := b.teamRepo.List(ctx)
+	if err != nil {
+		log.Printf("ERROR: failed to list teams: %v", err)
+		return c.Send("Ошибка получения списка команд")
+	}
+	if len(teams) < 2 {
+		return c.Send("Для объединения нужно минимум две команды")
+	}
+
+	items := make([]PaginatedItem, len(teams))
+	for i, t := range teams {
+		items[i] = PaginatedItem{
+			Text: t.Name,
+			Data: fmt.Sprintf("merge_src:%d", t.ID),
+		}
+	}
+
+	kb := PaginatedKeyboard("merge_src_page", items, page)
+	text := "Выберите команду-дубликат (она будет объединена и удалена):"
+
+	if edit {
+		return c.Edit(text, kb)
+	}
+	return c.Send(text, kb)
+}
+
+// handleMergeSourceCallback - шаг 2: в какую команду объединяем
+func (b *Bot) handleMergeSourceCallback(c tele.Context, payload string) error {
+	sourceID, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return c.Send("Ошибка: неверный ID команды")
+	}
+	return b.showMergeTargetPage(c, sourceID, 0)
+}
+
+// handleMergeTargetPageCallback - пагинация шага 2, payload: "sourceID:page"
+func (b *Bot) handleMergeTargetPageCallback(c tele.Context, payload string) error {
+	parts := strings.Split(payload, ":")
+	if len(parts) != 2 {
+		return c.Send("Ошибка формата данных")
+	}
+	sourceID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return c.Send("Ошибка: неверный ID команды")
+	}
+	page, _ := strconv.Atoi(parts[1])
+	return b.showMergeTargetPage(c, sourceID, page)
+}
+
+func (b *Bot) showMergeTargetPage(c tele.Context, sourceID int64, page int) error {
+	if !b.requirePermission(c, domain.PermTeamsMerge) {
+		return nil
+	}
+
+	ctx := context.Background()
+	source, err := b.teamRepo.GetByID(ctx, sourceID)
+	if err != nil {
+		log.Printf("ERROR: failed to get team by ID: %v", err)
+		return c.Send("Команда не найдена")
+	}
+
+	teams, err := b.teamRepo.List(ctx)
+	if err != nil {
+		log.Printf("ERROR: failed to list teams: %v", err)
+		return c.Send("Ошибка получения списка команд")
+	}
+
+	var items []PaginatedItem
+	for _, t := range teams {
+		if t.ID == sourceID {
+			continue
+		}
+		items = append(items, PaginatedItem{
+			Text: t.Name,
+			Data: fmt.Sprintf("merge_dst:%d:%d", sourceID, t.ID),
+		})
+	}
+
+	kb := PaginatedKeyboard(fmt.Sprintf("merge_dst_page:%d", sourceID), items, page)
+	return c.Edit(fmt.Sprintf("В какую команду объединить «%s»?", source.Name), kb)
+}
+
+// handleMergeTargetCallback - шаг 3: подтверждение, payload: "sourceID:targetID"
+func (b *Bot) handleMergeTargetCallback(c tele.Context, payload string) error {
+	if !b.requirePermission(c, domain.PermTeamsMerge) {
+		return nil
+	}
+
+	sourceID, targetID, err := parseMergePayload(payload)
+	if err != nil {
+		return c.Send("Ошибка формата данных")
+	}
+
+	ctx := context.Background()
+	source, err := b.teamRepo.GetByID(ctx, sourceID)
+	if err != nil {
+		return c.Send("Команда не найдена")
+	}
+	target, err := b.teamRepo.GetByID(ctx, targetID)
+	if err != nil {
+		return c.Send("Команда не найдена")
+	}
+
 	buttons := [][]tele.InlineButton{
 		{
-			{Text: "👀 Viewer", Data: fmt.Sprintf("grant_role:%d:%s", userID, domain.RoleViewer)},
-			{Text: "📝 Organizer", Data: fmt.Sprintf("grant_role:%d:%s", userID, domain.RoleOrganizer)},
-		},
-		{
-			{Text: "👑 Admin", Data: fmt.Sprintf("grant_role:%d:%s", userID, domain.RoleAdmin)},
+			{Text: "✅ Объединить", Data: fmt.Sprintf("merge_confirm:%d:%d", sourceID, targetID)},
+			{Text: "❌ Отмена", Data: "merge_cancel:0"},
 		},
 	}
 
-	return c.Send("Выберите роль:", &tele.ReplyMarkup{InlineKeyboard: buttons})
+	msg := fmt.Sprintf(`Объединить <b>%s</b> → <b>%s</b>?
+
+Участники и результаты перейдут в «%s». Если обе команды играли в одном турнире, останется лучшее место. Команда «%s» будет удалена, её название останется как псевдоним.`,
+		html.EscapeString(source.Name), html.EscapeString(target.Name),
+		html.EscapeString(target.Name), html.EscapeString(source.Name))
+
+	return c.Edit(msg, &tele.ReplyMarkup{InlineKeyboard: buttons}, tele.ModeHTML)
+}
+
+// handleMergeConfirmCallback - выполнить объединение
+func (b *Bot) handleMergeConfirmCallback(c tele.Context, payload string) error {
+	if !b.requirePermission(c, domain.PermTeamsMerge) {
+		return nil
+	}
+
+	sourceID, targetID, err := parseMergePayload(payload)
+	if err != nil {
+		return c.Send("Ошибка формата данных")
+	}
+
+	ctx := context.Background()
+	merge, err := b.teamRepo.Merge(ctx, sourceID, targetID, c.Sender().ID)
+	if err != nil {
+		log.Printf("ERROR: failed to merge teams: %v", err)
+		return c.Edit("Ошибка при объединении команд")
+	}
+
+	buttons := [][]tele.InlineButton{
+		{{Text: "↩️ Отменить объединение", Data: fmt.Sprintf("merge_revert:%d", merge.ID)}},
+	}
+
+	msg := fmt.Sprintf("✅ «%s» объединена.\nУчастников: %d, результатов: %d, конфликтов: %d",
+		merge.SourceName, len(merge.MovedMemberIDs), len(merge.MovedResultIDs), len(merge.Conflicts))
+
+	return c.Edit(msg, &tele.ReplyMarkup{InlineKeyboard: buttons})
+}
+
+// handleMergeRevertCallback - откатить объединение
+func (b *Bot) handleMergeRevertCallback(c tele.Context, payload string) error {
+	if !b.requirePermission(c, domain.PermTeamsMerge) {
+		return nil
+	}
+
+	mergeID, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return c.Send("Ошибка: неверный ID объединения")
+	}
+
+	ctx := context.Background()
+	merge, err := b.teamRepo.RevertMerge(ctx, mergeID, c.Sender().ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMergeAlreadyReverted) {
+			return c.Edit("Объединение уже отменено")
+		}
+		if errors.Is(err, repository.ErrMergeChained) {
+			return c.Edit("Команда потом объединена ещё раз — сначала отмените то объединение")
+		}
+		if errors.Is(err, repository.ErrMergeChanged) {
+			return c.Edit("Перенесённые результаты или участники с тех пор изменены, отмена невозможна")
+		}
+		log.Printf("ERROR: failed to revert merge: %v", err)
+		return c.Edit("Ошибка при отмене объединения")
+	}
+
+	return c.Edit(fmt.Sprintf("↩️ Объединение отменено, команда «%s» восстановлена", merge.SourceName))
+}
+
+// parseMergePayload parses "sourceID:targetID"
+func parseMergePayload(payload string) (int64, int64, error) {
+	parts := strings.Split(payload, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid payload: %s", payload)
+	}
+	sourceID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	targetID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return sourceID, targetID, nil
 }
@@ -3,6 +3,7 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"html"
 	"log"
@@ -12,6 +13,8 @@ import (
 
 	"github.com/eugene-twix/amber-bot/internal/domain"
 	"github.com/eugene-twix/amber-bot/internal/fsm"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+	"github.com/eugene-twix/amber-bot/internal/schedule"
 	tele "gopkg.in/telebot.v3"
 )
 
@@ -22,6 +25,7 @@ const (
 	maxTournamentNameLen = 100
 	maxLocationLen       = 200
 	maxPlace             = 1000
+	maxVenueButtons      = 20
 	minDateYearsAgo      = 1
 	maxDateYearsAhead    = 5
 )
@@ -40,7 +44,7 @@ func (b *Bot) verifyState(ctx context.Context, userID int64, expected fsm.State)
 
 // /newteam - создать команду
 func (b *Bot) handleNewTeam(c tele.Context) error {
-	if !b.requireOrganizer(c) {
+	if !b.requirePermission(c, domain.PermTeamsManage) {
 		return nil
 	}
 
@@ -112,7 +116,7 @@ func (b *Bot) processNewTeamName(c tele.Context, _ *fsm.UserState) error {
 
 // handleNewTeamAddMembersCallback - ответ на "Добавить участников?"
 func (b *Bot) handleNewTeamAddMembersCallback(c tele.Context, payload string) error {
-	if !b.requireOrganizer(c) {
+	if !b.requirePermission(c, domain.PermTeamsManage) {
 		return nil
 	}
 
@@ -201,7 +205,7 @@ func (b *Bot) processNewTeamMemberName(c tele.Context, state *fsm.UserState) err
 
 // handleNewTeamMoreCallback - ответ на "Ещё участника?"
 func (b *Bot) handleNewTeamMoreCallback(c tele.Context, payload string) error {
-	if !b.requireOrganizer(c) {
+	if !b.requirePermission(c, domain.PermTeamsManage) {
 		return nil
 	}
 
@@ -245,7 +249,7 @@ func (b *Bot) handleNewTeamMoreCallback(c tele.Context, payload string) error {
 
 // handleNewTeamResultCallback - ответ на "Записать результат?"
 func (b *Bot) handleNewTeamResultCallback(c tele.Context, payload string) error {
-	if !b.requireOrganizer(c) {
+	if !b.requirePermission(c, domain.PermResultsRecord) {
 		return nil
 	}
 
@@ -268,9 +272,11 @@ func (b *Bot) handleNewTeamResultCallback(c tele.Context, payload string) error
 	}
 
 	// Показываем список турниров для выбора
-	tournaments, err := b.tournRepo.ListRecent(ctx, 10)
+	filter := b.resultTournamentsFilter(0)
+	filter.ParticipantMode = domain.ParticipantTeams
+	tournaments, err := b.tournRepo.ListByPhase(ctx, filter)
 	if err != nil || len(tournaments) == 0 {
-		return c.Edit("Нет турниров. Сначала создайте турнир.", MainMenu(user.Role))
+		return c.Edit("Нет начавшихся турниров. Результаты записываются после старта турнира.", MainMenu(user.Role))
 	}
 
 	// Сохраняем team_id для записи результата
@@ -282,7 +288,7 @@ func (b *Bot) handleNewTeamResultCallback(c tele.Context, payload string) error
 	var buttons [][]tele.InlineButton
 	for _, t := range tournaments {
 		buttons = append(buttons, []tele.InlineButton{
-			{Text: fmt.Sprintf("%s (%s)", t.Name, t.Date.Format("02.01.2006")), Data: fmt.Sprintf("result_tourn:%d", t.ID)},
+			{Text: fmt.Sprintf("%s (%s)", t.Name, b.formatTournamentStart(t)), Data: fmt.Sprintf("result_tourn:%d", t.ID)},
 		})
 	}
 
@@ -291,7 +297,7 @@ func (b *Bot) handleNewTeamResultCallback(c tele.Context, payload string) error
 
 // /addmember - добавить участника
 func (b *Bot) handleAddMember(c tele.Context) error {
-	if !b.requireOrganizer(c) {
+	if !b.requirePermission(c, domain.PermTeamsManage) {
 		return nil
 	}
 
@@ -369,7 +375,7 @@ func (b *Bot) processAddMemberName(c tele.Context, _ *fsm.UserState) error {
 
 // handleNewTournament - создать турнир
 func (b *Bot) handleNewTournament(c tele.Context) error {
-	if !b.requireOrganizer(c) {
+	if !b.requirePermission(c, domain.PermTournamentsManage) {
 		return nil
 	}
 
@@ -402,60 +408,166 @@ func (b *Bot) processNewTournamentName(c tele.Context, _ *fsm.UserState) error {
 		return c.Send(fmt.Sprintf("Название слишком длинное (макс %d символов). Введите другое название:", maxTournamentNameLen), CancelMenu())
 	}
 
-	if err := b.fsm.Update(ctx, c.Sender().ID, fsm.StateNewTournamentDate, "name", tournamentName); err != nil {
+	// Вид игры спрашиваем, только если выбирать есть из чего
+	disciplines, err := b.discRepo.List(ctx)
+	if err != nil {
+		log.Printf("ERROR: failed to list disciplines: %v", err)
+	}
+	if len(disciplines) > 1 {
+		if err := b.fsm.Update(ctx, c.Sender().ID, fsm.StateNewTournamentDiscipline, "name", tournamentName); err != nil {
+			log.Printf("ERROR: failed to update FSM state: %v", err)
+			return c.Send("Ошибка сервиса. Попробуйте позже.")
+		}
+		return c.Send("Выберите вид игры:", disciplineButtons(disciplines, "newtourn_disc", false))
+	}
+
+	if err := b.fsm.Update(ctx, c.Sender().ID, fsm.StateNewTournamentMode, "name", tournamentName); err != nil {
 		log.Printf("ERROR: failed to update FSM state: %v", err)
 		return c.Send("Ошибка сервиса. Попробуйте позже.")
 	}
-	return c.Send("Введите дату турнира (например: 2026-01-15 или 15.01.2026):", CancelMenu())
+	return c.Send(newTournamentModePrompt, participantModeButtons())
 }
 
-func (b *Bot) processNewTournamentDate(c tele.Context, _ *fsm.UserState) error {
+const newTournamentModePrompt = "Результаты записываются командам или игрокам?"
+
+// participantModeButtons - выбор зачёта турнира
+func participantModeButtons() *tele.ReplyMarkup {
+	return &tele.ReplyMarkup{InlineKeyboard: [][]tele.InlineButton{
+		{{Text: "👥 Командный", Data: "newtourn_mode:" + string(domain.ParticipantTeams)}},
+		{{Text: "👤 Личный", Data: "newtourn_mode:" + string(domain.ParticipantPlayers)}},
+	}}
+}
+
+const newTournamentDatePrompt = "Введите дату и время турнира (например: 15.01.2026 19:30 или 2026-01-15 19:30).\n" +
+	"Можно только дату: 15.01.2026"
+
+// handleNewTournamentDisciplineCallback - вид игры выбран кнопкой
+func (b *Bot) handleNewTournamentDisciplineCallback(c tele.Context, payload string) error {
+	if !b.requirePermission(c, domain.PermTournamentsManage) {
+		return nil
+	}
+
 	ctx := context.Background()
+	if _, err := b.verifyState(ctx, c.Sender().ID, fsm.StateNewTournamentDiscipline); err != nil {
+		user := b.getUser(c)
+		return c.Send("Состояние изменилось. Попробуйте снова.", MainMenu(user.Role))
+	}
 
-	// Verify state to prevent race condition
-	if _, err := b.verifyState(ctx, c.Sender().ID, fsm.StateNewTournamentDate); err != nil {
-		log.Printf("ERROR: state verification failed: %v", err)
+	disciplineID, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return c.Send("Ошибка: неверный ID вида игры")
+	}
+	discipline, err := b.discRepo.GetByID(ctx, disciplineID)
+	if err != nil {
+		log.Printf("ERROR: failed to get discipline by ID: %v", err)
+		return c.Send("Ошибка: вид игры не найден")
+	}
+
+	if err := b.fsm.Update(ctx, c.Sender().ID, fsm.StateNewTournamentMode, "discipline_id", discipline.ID); err != nil {
+		log.Printf("ERROR: failed to update FSM state: %v", err)
+		return c.Send("Ошибка сервиса. Попробуйте позже.")
+	}
+	_ = c.Edit(fmt.Sprintf("Вид игры: %s", discipline.Name))
+	return c.Send(newTournamentModePrompt, participantModeButtons())
+}
+
+// handleNewTournamentModeCallback - зачёт выбран кнопкой
+func (b *Bot) handleNewTournamentModeCallback(c tele.Context, payload string) error {
+	if !b.requirePermission(c, domain.PermTournamentsManage) {
+		return nil
+	}
+
+	ctx := context.Background()
+	if _, err := b.verifyState(ctx, c.Sender().ID, fsm.StateNewTournamentMode); err != nil {
 		user := b.getUser(c)
 		return c.Send("Состояние изменилось. Попробуйте снова.", MainMenu(user.Role))
 	}
 
-	dateStr := strings.TrimSpace(c.Text())
+	mode := domain.ParticipantMode(payload)
+	label := "командный"
+	switch mode {
+	case domain.ParticipantTeams:
+	case domain.ParticipantPlayers:
+		label = "личный"
+	default:
+		return c.Send("Ошибка: неизвестный зачёт")
+	}
 
-	// Try to parse date in different formats
-	var date time.Time
-	var err error
+	if err := b.fsm.Update(ctx, c.Sender().ID, fsm.StateNewTournamentDate, "participant_mode", string(mode)); err != nil {
+		log.Printf("ERROR: failed to update FSM state: %v", err)
+		return c.Send("Ошибка сервиса. Попробуйте позже.")
+	}
+	_ = c.Edit(fmt.Sprintf("Зачёт: %s", label))
+	return c.Send(newTournamentDatePrompt, CancelMenu())
+}
 
-	formats := []string{
-		"2006-01-02",
-		"02.01.2006",
-		"2006/01/02",
-		"02/01/2006",
+// disciplineButtons - по кнопке на вид игры, withAll добавляет «Все» (id 0)
+func disciplineButtons(disciplines []*domain.Discipline, action string, withAll bool) *tele.ReplyMarkup {
+	var buttons [][]tele.InlineButton
+	if withAll {
+		buttons = append(buttons, []tele.InlineButton{{Text: "Все", Data: action + ":0"}})
+	}
+	for _, d := range disciplines {
+		buttons = append(buttons, []tele.InlineButton{
+			{Text: d.Name, Data: fmt.Sprintf("%s:%d", action, d.ID)},
+		})
 	}
+	return &tele.ReplyMarkup{InlineKeyboard: buttons}
+}
 
-	for _, format := range formats {
-		date, err = time.Parse(format, dateStr)
-		if err == nil {
-			break
-		}
+func (b *Bot) processNewTournamentDate(c tele.Context, _ *fsm.UserState) error {
+	ctx := context.Background()
+
+	// Verify state to prevent race condition
+	if _, err := b.verifyState(ctx, c.Sender().ID, fsm.StateNewTournamentDate); err != nil {
+		log.Printf("ERROR: state verification failed: %v", err)
+		user := b.getUser(c)
+		return c.Send("Состояние изменилось. Попробуйте снова.", MainMenu(user.Role))
 	}
 
+	// Время - в часовом поясе организации (TIMEZONE)
+	start, hasTime, err := schedule.ParseStart(c.Text(), b.cfg.Location)
 	if err != nil {
-		return c.Send("Неверный формат даты. Используйте формат: 2026-01-15 или 15.01.2026", CancelMenu())
+		return c.Send("Неверный формат. Используйте: 15.01.2026 19:30 или 15.01.2026", CancelMenu())
 	}
 
 	// Validate date range
 	now := time.Now()
 	minDate := now.AddDate(-minDateYearsAgo, 0, 0)
 	maxDate := now.AddDate(maxDateYearsAhead, 0, 0)
-	if date.Before(minDate) || date.After(maxDate) {
+	if start.Before(minDate) || start.After(maxDate) {
 		return c.Send(fmt.Sprintf("Дата должна быть от %d года назад до %d лет вперёд",
 			minDateYearsAgo, maxDateYearsAhead), CancelMenu())
 	}
 
-	if err := b.fsm.Update(ctx, c.Sender().ID, fsm.StateNewTournamentLocation, "date", date.Format("2006-01-02")); err != nil {
+	// Без времени сохраняем только дату - турнир на весь день
+	value := start.Format("2006-01-02")
+	if hasTime {
+		value = start.Format(time.RFC3339)
+	}
+	if err := b.fsm.Update(ctx, c.Sender().ID, fsm.StateNewTournamentLocation, "date", value); err != nil {
 		log.Printf("ERROR: failed to update FSM state: %v", err)
 		return c.Send("Ошибка сервиса. Попробуйте позже.")
 	}
+
+	// Площадки - кнопками, новое место - текстом
+	venues, err := b.venueRepo.List(ctx)
+	if err != nil {
+		log.Printf("ERROR: failed to list venues: %v", err)
+	}
+	if len(venues) > maxVenueButtons {
+		venues = venues[:maxVenueButtons]
+	}
+	if len(venues) > 0 {
+		var buttons [][]tele.InlineButton
+		for _, v := range venues {
+			buttons = append(buttons, []tele.InlineButton{
+				{Text: v.Name, Data: fmt.Sprintf("newtourn_venue:%d", v.ID)},
+			})
+		}
+		_ = c.Send("Выберите площадку:", &tele.ReplyMarkup{InlineKeyboard: buttons})
+		return c.Send("…или введите другое место (или '-' если не указываете):", CancelMenu())
+	}
 	return c.Send("Введите место проведения (или отправьте '-' если не указываете):", CancelMenu())
 }
 
@@ -481,22 +593,77 @@ func (b *Bot) processNewTournamentLocation(c tele.Context, _ *fsm.UserState) err
 		return c.Send(fmt.Sprintf("Место проведения слишком длинное (макс %d символов). Введите другое:", maxLocationLen), CancelMenu())
 	}
 
+	// Введённое место совпало с площадкой - привязываем к ней
+	if location != "" {
+		venues, err := b.venueRepo.List(ctx)
+		if err != nil {
+			log.Printf("ERROR: failed to list venues: %v", err)
+		}
+		for _, v := range venues {
+			if strings.EqualFold(v.Name, location) {
+				return b.createTournament(c, state, v.Name, &v.ID)
+			}
+		}
+	}
+
+	return b.createTournament(c, state, location, nil)
+}
+
+// handleNewTournamentVenueCallback - площадка выбрана кнопкой
+func (b *Bot) handleNewTournamentVenueCallback(c tele.Context, payload string) error {
+	if !b.requirePermission(c, domain.PermTournamentsManage) {
+		return nil
+	}
+
+	ctx := context.Background()
+	state, err := b.verifyState(ctx, c.Sender().ID, fsm.StateNewTournamentLocation)
+	if err != nil {
+		user := b.getUser(c)
+		return c.Send("Состояние изменилось. Попробуйте снова.", MainMenu(user.Role))
+	}
+
+	venueID, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return c.Send("Ошибка: неверный ID площадки")
+	}
+	venue, err := b.venueRepo.GetByID(ctx, venueID)
+	if err != nil {
+		log.Printf("ERROR: failed to get venue by ID: %v", err)
+		return c.Send("Ошибка: площадка не найдена")
+	}
+
+	_ = c.Edit(fmt.Sprintf("Площадка: %s", venue.Name))
+	return b.createTournament(c, state, venue.Name, &venue.ID)
+}
+
+// createTournament - последний шаг создания турнира
+func (b *Bot) createTournament(c tele.Context, state *fsm.UserState, location string, venueID *int64) error {
+	ctx := context.Background()
 	tournamentName := state.Data.GetString("name")
 	dateStr := state.Data.GetString("date")
 
-	date, err := time.Parse("2006-01-02", dateStr)
+	start, hasTime, err := schedule.ParseStart(dateStr, b.cfg.Location)
 	if err != nil {
 		_ = b.fsm.Clear(ctx, c.Sender().ID)
 		user := b.getUser(c)
 		return c.Send("Ошибка: неверная дата", MainMenu(user.Role))
 	}
 
+	mode := domain.ParticipantMode(state.Data.GetString("participant_mode"))
+	if mode == "" {
+		mode = domain.ParticipantTeams
+	}
+
 	tournament := &domain.Tournament{
-		Name:      tournamentName,
-		Date:      date,
-		Location:  location,
-		CreatedBy: c.Sender().ID,
+		Name:            tournamentName,
+		Location:        location,
+		VenueID:         venueID,
+		DisciplineID:    state.Data.GetInt64("discipline_id"), // 0 - вид по умолчанию
+		ParticipantMode: mode,
+		Timezone:        b.cfg.Location.String(),
+		CreatedBy:       c.Sender().ID,
 	}
+	tournament.SetStart(start, hasTime)
 
 	if err := b.tournRepo.Create(ctx, tournament); err != nil {
 		log.Printf("ERROR: failed to create tournament: %v", err)
@@ -510,20 +677,129 @@ func (b *Bot) processNewTournamentLocation(c tele.Context, _ *fsm.UserState) err
 	return c.Send(fmt.Sprintf("✅ Турнир '%s' создан!", tournamentName), MainMenu(user.Role))
 }
 
+// formatTournamentStart - "15.01.2026 19:30" или "15.01.2026" для турнира без времени
+func (b *Bot) formatTournamentStart(t *domain.Tournament) string {
+	if t.StartsAt == nil {
+		return t.Date.Format("02.01.2006")
+	}
+	return t.Start(b.cfg.Location).Format("02.01.2006 15:04")
+}
+
+// resultTournamentsFilter - турниры, в которые можно записать результат:
+// уже начавшиеся и не завершённые; disciplineID 0 - любого вида игры
+func (b *Bot) resultTournamentsFilter(disciplineID int64) repository.TournamentFilter {
+	return repository.TournamentFilter{
+		Phase:           repository.PhaseStarted,
+		Statuses:        []domain.TournamentStatus{domain.TournamentPlanned, domain.TournamentInProgress},
+		DisciplineID:    disciplineID,
+		Now:             time.Now(),
+		DefaultTimezone: b.cfg.Location.String(),
+		Limit:           10,
+	}
+}
+
+// /finalize - завершить идущий турнир: результаты больше не меняются (кроме админа)
+func (b *Bot) handleFinalize(c tele.Context) error {
+	if !b.requirePermission(c, domain.PermTournamentsManage) {
+		return nil
+	}
+
+	tournaments, err := b.tournRepo.ListByPhase(context.Background(), repository.TournamentFilter{
+		Statuses:        []domain.TournamentStatus{domain.TournamentInProgress},
+		Now:             time.Now(),
+		DefaultTimezone: b.cfg.Location.String(),
+		Limit:           10,
+	})
+	if err != nil {
+		log.Printf("ERROR: failed to list tournaments: %v", err)
+		return c.Send("Ошибка получения списка турниров")
+	}
+	if len(tournaments) == 0 {
+		return c.Send("Нет идущих турниров")
+	}
+
+	var buttons [][]tele.InlineButton
+	for _, t := range tournaments {
+		buttons = append(buttons, []tele.InlineButton{
+			{Text: fmt.Sprintf("%s (%s)", t.Name, b.formatTournamentStart(t)), Data: fmt.Sprintf("finalize:%d", t.ID)},
+		})
+	}
+	return c.Send("Какой турнир завершить?", &tele.ReplyMarkup{InlineKeyboard: buttons})
+}
+
+// handleFinalizeCallback - турнир для завершения выбран
+func (b *Bot) handleFinalizeCallback(c tele.Context, payload string) error {
+	if !b.requirePermission(c, domain.PermTournamentsManage) {
+		return nil
+	}
+
+	id, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return c.Send("Ошибка: неверный ID турнира")
+	}
+
+	ctx := context.Background()
+	t, err := b.tournRepo.GetByID(ctx, id)
+	if err != nil {
+		return c.Edit("Турнир не найден")
+	}
+	if err := t.CheckTransition(domain.TournamentFinalized, b.getUser(c).IsAdmin()); err != nil {
+		return c.Edit(fmt.Sprintf("Турнир «%s» уже не идёт", t.Name))
+	}
+
+	now := time.Now()
+	actorID := c.Sender().ID
+	t.SetStatus(domain.TournamentFinalized, now)
+	t.UpdatedAt = &now
+	t.UpdatedBy = &actorID
+	t.Version++
+	if err := b.tournRepo.Update(ctx, t); err != nil {
+		log.Printf("ERROR: failed to finalize tournament: %v", err)
+		return c.Edit("Ошибка при завершении турнира")
+	}
+
+	return c.Edit(fmt.Sprintf("🏁 Турнир «%s» завершён", t.Name))
+}
+
 // handleResult - записать результат
 func (b *Bot) handleResult(c tele.Context) error {
-	if !b.requireOrganizer(c) {
+	if !b.requirePermission(c, domain.PermResultsRecord) {
+		return nil
+	}
+
+	// При нескольких видах игр сначала выбираем вид - список турниров короче
+	disciplines, err := b.discRepo.List(context.Background())
+	if err != nil {
+		log.Printf("ERROR: failed to list disciplines: %v", err)
+	}
+	if len(disciplines) > 1 {
+		return c.Send("Выберите вид игры:", disciplineButtons(disciplines, "result_disc", true))
+	}
+	return b.showResultTournaments(c, 0, false)
+}
+
+// handleResultDisciplineCallback - вид игры для записи результата выбран
+func (b *Bot) handleResultDisciplineCallback(c tele.Context, payload string) error {
+	if !b.requirePermission(c, domain.PermResultsRecord) {
 		return nil
 	}
+	disciplineID, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return c.Send("Ошибка: неверный ID вида игры")
+	}
+	return b.showResultTournaments(c, disciplineID, true)
+}
 
+// showResultTournaments - выбор турнира для записи результата
+func (b *Bot) showResultTournaments(c tele.Context, disciplineID int64, edit bool) error {
 	ctx := context.Background()
-	tournaments, err := b.tournRepo.ListRecent(ctx, 10)
+	tournaments, err := b.tournRepo.ListByPhase(ctx, b.resultTournamentsFilter(disciplineID))
 	if err != nil {
 		log.Printf("ERROR: failed to list tournaments: %v", err)
 		return c.Send("Ошибка получения списка турниров")
 	}
 	if len(tournaments) == 0 {
-		return c.Send("Сначала создайте турнир через кнопку «🎯 Турнир»")
+		return c.Send("Нет начавшихся турниров. Создайте турнир через кнопку «🎯 Турнир» или дождитесь его старта")
 	}
 
 	if err := b.fsm.Set(ctx, c.Sender().ID, fsm.StateResultTournament, fsm.Data{}); err != nil {
@@ -534,13 +810,15 @@ func (b *Bot) handleResult(c tele.Context) error {
 	// Create inline keyboard with tournaments
 	var buttons [][]tele.InlineButton
 	for _, t := range tournaments {
-		dateStr := t.Date.Format("02.01.2006")
-		buttonText := fmt.Sprintf("%s (%s)", t.Name, dateStr)
+		buttonText := fmt.Sprintf("%s (%s)", t.Name, b.formatTournamentStart(t))
 		buttons = append(buttons, []tele.InlineButton{
 			{Text: buttonText, Data: fmt.Sprintf("result_tourn:%d", t.ID)},
 		})
 	}
 
+	if edit {
+		return c.Edit("Выберите турнир:", &tele.ReplyMarkup{InlineKeyboard: buttons})
+	}
 	return c.Send("Выберите турнир:", &tele.ReplyMarkup{InlineKeyboard: buttons})
 }
 
@@ -588,15 +866,33 @@ func (b *Bot) processResultPlace(c tele.Context, _ *fsm.UserState) error {
 
 	tournamentID := state.Data.GetInt64("tournament_id")
 	teamID := state.Data.GetInt64("team_id")
+	playerID := state.Data.GetInt64("player_id")
 
-	if tournamentID == 0 || teamID == 0 {
+	if tournamentID == 0 || (teamID == 0 && playerID == 0) {
+		_ = b.fsm.Clear(ctx, c.Sender().ID)
+		user := b.getUser(c)
+		return c.Send("Ошибка: турнир или участник не выбраны", MainMenu(user.Role))
+	}
+
+	tournament, err := b.tournRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		log.Printf("ERROR: failed to get tournament by ID: %v", err)
 		_ = b.fsm.Clear(ctx, c.Sender().ID)
 		user := b.getUser(c)
-		return c.Send("Ошибка: турнир или команда не выбраны", MainMenu(user.Role))
+		return c.Send("Турнир не найден", MainMenu(user.Role))
+	}
+	if err := tournament.CheckResults(time.Now(), b.cfg.Location, b.getUser(c).IsAdmin()); err != nil {
+		_ = b.fsm.Clear(ctx, c.Sender().ID)
+		user := b.getUser(c)
+		if errors.Is(err, domain.ErrTournamentNotStarted) {
+			return c.Send("Турнир ещё не начался — результаты можно записать после старта", MainMenu(user.Role))
+		}
+		return c.Send("Турнир завершён, результаты изменять нельзя", MainMenu(user.Role))
 	}
 
 	result := &domain.Result{
 		TeamID:       teamID,
+		PlayerID:     playerID,
 		TournamentID: tournamentID,
 		Place:        place,
 		RecordedBy:   c.Sender().ID,
@@ -609,25 +905,27 @@ func (b *Bot) processResultPlace(c tele.Context, _ *fsm.UserState) error {
 		return c.Send("Ошибка при сохранении результата", MainMenu(user.Role))
 	}
 
-	// Get team and tournament names for confirmation
-	team, teamErr := b.teamRepo.GetByID(ctx, teamID)
-	if teamErr != nil {
-		log.Printf("ERROR: failed to get team by ID: %v", teamErr)
-	}
-	tournament, tournamentErr := b.tournRepo.GetByID(ctx, tournamentID)
-	if tournamentErr != nil {
-		log.Printf("ERROR: failed to get tournament by ID: %v", tournamentErr)
-	}
-
 	_ = b.fsm.Clear(ctx, c.Sender().ID)
 	user := b.getUser(c)
 
+	// Get participant name for confirmation
 	msg := "✅ Результат записан."
-	if team != nil && tournament != nil {
-		msg = fmt.Sprintf("✅ Результат записан: %s заняла %d место в турнире '%s'",
-			team.Name, place, tournament.Name)
-	} else if team != nil {
-		msg = fmt.Sprintf("✅ Результат записан: %s заняла %d место", team.Name, place)
+	if playerID != 0 {
+		player, playerErr := b.playerRepo.GetByID(ctx, playerID)
+		if playerErr != nil {
+			log.Printf("ERROR: failed to get player by ID: %v", playerErr)
+		} else {
+			msg = fmt.Sprintf("✅ Результат записан: %s занял(а) %d место в турнире '%s'",
+				player.Name, place, tournament.Name)
+		}
+	} else {
+		team, teamErr := b.teamRepo.GetByID(ctx, teamID)
+		if teamErr != nil {
+			log.Printf("ERROR: failed to get team by ID: %v", teamErr)
+		} else {
+			msg = fmt.Sprintf("✅ Результат записан: %s заняла %d место в турнире '%s'",
+				team.Name, place, tournament.Name)
+		}
 	}
 
 	return c.Send(msg, MainMenu(user.Role))
@@ -661,10 +959,22 @@ func (b *Bot) handleCallback(c tele.Context) error {
 		page, _ := strconv.Atoi(payload)
 		return b.showTeamsPage(c, page, true)
 	case "rating_page":
-		page, _ := strconv.Atoi(payload)
-		return b.showRatingPage(c, page, true)
+		// rating_page:<discipline_id>:<page>
+		discStr, pageStr, _ := strings.Cut(payload, ":")
+		disciplineID, _ := strconv.ParseInt(discStr, 10, 64)
+		page, _ := strconv.Atoi(pageStr)
+		return b.showRatingPage(c, disciplineID, page, true)
+	case "rating_players":
+		disciplineID, _ := strconv.ParseInt(payload, 10, 64)
+		return b.showPlayerRating(c, disciplineID)
 	case "team_info":
 		return b.handleTeamInfoCallback(c, payload)
+	case "h2h_pick":
+		return b.handleHeadToHeadPickCallback(c, payload)
+	case "h2h_page":
+		return b.handleHeadToHeadPageCallback(c, payload)
+	case "h2h":
+		return b.handleHeadToHeadCallback(c, payload)
 	case "newteam_addmembers":
 		return b.handleNewTeamAddMembersCallback(c, payload)
 	case "newteam_more":
@@ -673,10 +983,22 @@ func (b *Bot) handleCallback(c tele.Context) error {
 		return b.handleNewTeamResultCallback(c, payload)
 	case "addmember_team":
 		return b.handleAddMemberTeamCallback(c, payload)
+	case "newtourn_disc":
+		return b.handleNewTournamentDisciplineCallback(c, payload)
+	case "result_disc":
+		return b.handleResultDisciplineCallback(c, payload)
+	case "newtourn_mode":
+		return b.handleNewTournamentModeCallback(c, payload)
+	case "newtourn_venue":
+		return b.handleNewTournamentVenueCallback(c, payload)
 	case "result_tourn":
 		return b.handleResultTournamentCallback(c, payload)
+	case "finalize":
+		return b.handleFinalizeCallback(c, payload)
 	case "result_team":
 		return b.handleResultTeamCallback(c, payload)
+	case "result_player":
+		return b.handleResultPlayerCallback(c, payload)
 	case "grant_page":
 		page, _ := strconv.Atoi(payload)
 		return b.showGrantUsersPage(c, page, true)
@@ -684,6 +1006,25 @@ func (b *Bot) handleCallback(c tele.Context) error {
 		return b.handleGrantUserCallback(c, payload)
 	case "grant_role":
 		return b.handleGrantRoleCallback(c, payload)
+	case "invite_role":
+		return b.handleInviteRoleCallback(c, payload)
+	case "grant_cancel":
+		return c.Edit("Назначение роли отменено")
+	case "merge_src_page":
+		page, _ := strconv.Atoi(payload)
+		return b.showMergeSourcePage(c, page, true)
+	case "merge_src":
+		return b.handleMergeSourceCallback(c, payload)
+	case "merge_dst_page":
+		return b.handleMergeTargetPageCallback(c, payload)
+	case "merge_dst":
+		return b.handleMergeTargetCallback(c, payload)
+	case "merge_confirm":
+		return b.handleMergeConfirmCallback(c, payload)
+	case "merge_revert":
+		return b.handleMergeRevertCallback(c, payload)
+	case "merge_cancel":
+		return c.Edit("Объединение отменено")
 	default:
 		return c.Send("Неизвестное действие")
 	}
@@ -704,6 +1045,7 @@ func (b *Bot) handleTeamInfoCallback(c tele.Context, payload string) error {
 
 	members, _ := b.memberRepo.GetByTeamID(ctx, team.ID)
 	results, _ := b.resultRepo.GetByTeamID(ctx, team.ID)
+	badges, _ := b.badgeRepo.GetByTeamID(ctx, team.ID)
 
 	var sb strings.Builder
 
@@ -759,12 +1101,18 @@ func (b *Bot) handleTeamInfoCallback(c tele.Context, payload string) error {
 		sb.WriteString(fmt.Sprintf("  Игр: <code>%d</code> | Побед: <code>%d</code> | Ср: <code>%.1f</code>\n", len(results), wins, avgPlace))
 	}
 
-	return c.Send(sb.String(), tele.ModeHTML)
+	sb.WriteString(badgesSection(badges))
+
+	kb := &tele.ReplyMarkup{InlineKeyboard: [][]tele.InlineButton{
+		{{Text: "⚔️ Сравнить с командой", Data: fmt.Sprintf("h2h_pick:%d", team.ID)}},
+	}}
+
+	return c.Send(sb.String(), kb, tele.ModeHTML)
 }
 
 func (b *Bot) handleAddMemberTeamCallback(c tele.Context, payload string) error {
 	// Check permissions
-	if !b.requireOrganizer(c) {
+	if !b.requirePermission(c, domain.PermTeamsManage) {
 		return nil
 	}
 
@@ -789,7 +1137,7 @@ func (b *Bot) handleAddMemberTeamCallback(c tele.Context, payload string) error
 
 func (b *Bot) handleResultTournamentCallback(c tele.Context, payload string) error {
 	// Check permissions
-	if !b.requireOrganizer(c) {
+	if !b.requirePermission(c, domain.PermResultsRecord) {
 		return nil
 	}
 
@@ -799,12 +1147,21 @@ func (b *Bot) handleResultTournamentCallback(c tele.Context, payload string) err
 		return c.Send("Ошибка: неверный ID турнира")
 	}
 
-	_, err = b.tournRepo.GetByID(ctx, tournamentID)
+	tournament, err := b.tournRepo.GetByID(ctx, tournamentID)
 	if err != nil {
 		log.Printf("ERROR: failed to get tournament by ID: %v", err)
 		return c.Send("Ошибка: турнир не найден")
 	}
 
+	// Личный зачёт - выбираем игрока
+	if tournament.IsIndividual() {
+		if err := b.fsm.Update(ctx, c.Sender().ID, fsm.StateResultPlayer, "tournament_id", tournamentID); err != nil {
+			log.Printf("ERROR: failed to update FSM state: %v", err)
+			return c.Send("Ошибка сервиса. Попробуйте позже.")
+		}
+		return b.processResultPlayer(c)
+	}
+
 	// Проверяем, есть ли уже team_id (из flow создания команды)
 	state, _ := b.fsm.Get(ctx, c.Sender().ID)
 	if state != nil && state.Data.GetInt64("team_id") != 0 {
@@ -827,7 +1184,7 @@ func (b *Bot) handleResultTournamentCallback(c tele.Context, payload string) err
 
 func (b *Bot) handleResultTeamCallback(c tele.Context, payload string) error {
 	// Check permissions
-	if !b.requireOrganizer(c) {
+	if !b.requirePermission(c, domain.PermResultsRecord) {
 		return nil
 	}
 
@@ -851,17 +1208,70 @@ func (b *Bot) handleResultTeamCallback(c tele.Context, payload string) error {
 	return c.Send(fmt.Sprintf("Команда '%s' выбрана. Введите место (число):", team.Name), CancelMenu())
 }
 
+// processResultPlayer - выбор игрока для личного зачёта
+func (b *Bot) processResultPlayer(c tele.Context) error {
+	ctx := context.Background()
+	players, err := b.playerRepo.List(ctx)
+	if err != nil {
+		log.Printf("ERROR: failed to list players: %v", err)
+		_ = b.fsm.Clear(ctx, c.Sender().ID)
+		return c.Send("Ошибка получения списка игроков")
+	}
+	if len(players) == 0 {
+		_ = b.fsm.Clear(ctx, c.Sender().ID)
+		return c.Send("Нет игроков. Добавьте их через API (POST /api/v1/private/players)")
+	}
+
+	var buttons [][]tele.InlineButton
+	for _, p := range players {
+		buttons = append(buttons, []tele.InlineButton{
+			{Text: p.Name, Data: fmt.Sprintf("result_player:%d", p.ID)},
+		})
+	}
+
+	return c.Send("Выберите игрока:", &tele.ReplyMarkup{InlineKeyboard: buttons})
+}
+
+func (b *Bot) handleResultPlayerCallback(c tele.Context, payload string) error {
+	if !b.requirePermission(c, domain.PermResultsRecord) {
+		return nil
+	}
+
+	ctx := context.Background()
+	if _, err := b.verifyState(ctx, c.Sender().ID, fsm.StateResultPlayer); err != nil {
+		user := b.getUser(c)
+		return c.Send("Состояние изменилось. Попробуйте снова.", MainMenu(user.Role))
+	}
+
+	playerID, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return c.Send("Ошибка: неверный ID игрока")
+	}
+
+	player, err := b.playerRepo.GetByID(ctx, playerID)
+	if err != nil {
+		log.Printf("ERROR: failed to get player by ID: %v", err)
+		return c.Send("Ошибка: игрок не найден")
+	}
+
+	if err := b.fsm.Update(ctx, c.Sender().ID, fsm.StateResultPlace, "player_id", playerID); err != nil {
+		log.Printf("ERROR: failed to update FSM state: %v", err)
+		return c.Send("Ошибка сервиса. Попробуйте позже.")
+	}
+
+	return c.Send(fmt.Sprintf("Игрок '%s' выбран. Введите место (число):", player.Name), CancelMenu())
+}
+
 func (b *Bot) handleGrantRoleCallback(c tele.Context, payload string) error {
-	// Check permissions - only admin can grant roles
-	if !b.requireAdmin(c) {
+	if !b.requirePermission(c, domain.PermUsersManage) {
 		return nil
 	}
 
 	ctx := context.Background()
 
-	// Parse payload: userID:role
-	parts := strings.SplitN(payload, ":", 2)
-	if len(parts) != 2 {
+	// Parse payload: userID:roleID[:ok], ok - admin promotion confirmed
+	parts := strings.SplitN(payload, ":", 3)
+	if len(parts) < 2 {
 		return c.Send("Ошибка: неверный формат данных")
 	}
 
@@ -870,23 +1280,69 @@ func (b *Bot) handleGrantRoleCallback(c tele.Context, payload string) error {
 		return c.Send("Ошибка: неверный ID пользователя")
 	}
 
-	role := domain.Role(parts[1])
+	roleID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return c.Send("Ошибка: неверный формат данных")
+	}
+	roleDef, err := b.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		log.Printf("ERROR: failed to get role: %v", err)
+		return c.Respond(&tele.CallbackResponse{Text: "Роль не найдена"})
+	}
+	role := domain.Role(roleDef.Name)
+
+	target, err := b.userRepo.GetByTelegramID(ctx, userID)
+	if err != nil {
+		log.Printf("ERROR: failed to get user: %v", err)
+		return c.Edit("Пользователь не найден")
+	}
+
+	current, err := b.roleRepo.GetByName(ctx, string(target.Role))
+	if err != nil {
+		log.Printf("ERROR: failed to get role: %v", err)
+		return c.Edit("Ошибка при изменении роли")
+	}
+
+	user := b.getUser(c)
+	change := domain.RoleChange{
+		Actor:        user,
+		Target:       target,
+		Role:         role,
+		Grants:       roleDef.Grants(),
+		TargetGrants: current.Grants(),
+		Locked:       b.cfg.LockedAdminIDs(),
+		Confirmed:    len(parts) == 3 && parts[2] == "ok",
+	}
+	if err := change.Check(); errors.Is(err, domain.ErrConfirmationRequired) {
+		buttons := [][]tele.InlineButton{
+			{
+				{Text: "👑 Назначить админом", Data: fmt.Sprintf("grant_role:%d:%d:ok", userID, roleID)},
+				{Text: "❌ Отмена", Data: "grant_cancel:0"},
+			},
+		}
+		return c.Edit(fmt.Sprintf("Назначить %s админом? Админ получит все права, включая управление ролями.",
+			target.DisplayName()), &tele.ReplyMarkup{InlineKeyboard: buttons})
+	} else if err != nil {
+		_ = b.fsm.Clear(ctx, c.Sender().ID)
+		return c.Edit(roleChangeMessage(err))
+	}
 
 	// Update role
-	if err := b.userRepo.UpdateRole(ctx, userID, role); err != nil {
+	if err := b.userRepo.UpdateRole(ctx, userID, role, user.TelegramID); err != nil {
 		log.Printf("ERROR: failed to update user role: %v", err)
 		_ = b.fsm.Clear(ctx, c.Sender().ID)
-		user := b.getUser(c)
-		return c.Send("Ошибка при изменении роли", MainMenu(user.Role))
+		return c.Send(roleChangeMessage(err), MainMenu(user.Role))
 	}
 
+	// Сессии Mini App со старой ролью больше не действуют
+	b.revokeSessions(ctx, userID)
+
 	_ = b.fsm.Clear(ctx, c.Sender().ID)
 
 	// Edit the message to remove buttons and show result
-	if err := c.Edit(fmt.Sprintf("✅ Роль '%s' назначена пользователю %d", role, userID)); err != nil {
+	if err := c.Edit(fmt.Sprintf("✅ Роль '%s' назначена пользователю %s", role, target.DisplayName())); err != nil {
 		log.Printf("WARN: failed to edit message: %v", err)
 	}
 
-	user := b.getUser(c)
 	return c.Send("Готово!", MainMenu(user.Role))
 }
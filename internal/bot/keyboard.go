@@ -17,6 +17,7 @@ const (
 	BtnNewTournament = "🎯 Новый турнир"
 	BtnResult        = "🏅 Записать место"
 	BtnGrant         = "👑 Права"
+	BtnMergeTeams    = "🔀 Объединить команды"
 	BtnCancel        = "❌ Отмена"
 )
 
@@ -4,6 +4,7 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -46,10 +47,66 @@ func (c *Cache) Get(ctx context.Context, key string, dest any) error {
 	return json.Unmarshal(data, dest)
 }
 
+// GetDel reads key into dest and deletes it in one step: only one of
+// concurrent callers gets the value
+func (c *Cache) GetDel(ctx context.Context, key string, dest any) error {
+	data, err := c.client.GetDel(ctx, key).Bytes()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dest)
+}
+
+// IsMiss reports whether err means the key doesn't exist
+func IsMiss(err error) bool {
+	return errors.Is(err, redis.Nil)
+}
+
 func (c *Cache) Delete(ctx context.Context, keys ...string) error {
 	return c.client.Del(ctx, keys...).Err()
 }
 
+// SetTagged stores value like Set and registers key under tags, so that
+// InvalidateTags drops it. ttl must not exceed TagTTL.
+func (c *Cache) SetTagged(ctx context.Context, key string, value any, ttl time.Duration, tags ...string) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	pipe := c.client.TxPipeline()
+	pipe.Set(ctx, key, data, ttl)
+	for _, tag := range tags {
+		pipe.SAdd(ctx, TagKey(tag), key)
+		pipe.Expire(ctx, TagKey(tag), TagTTL)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// InvalidateTags deletes all keys registered under tags
+func (c *Cache) InvalidateTags(ctx context.Context, tags ...string) error {
+	for _, tag := range tags {
+		keys, err := c.client.SMembers(ctx, TagKey(tag)).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		members := make([]any, len(keys))
+		for i, k := range keys {
+			members[i] = k
+		}
+		pipe := c.client.TxPipeline()
+		pipe.Del(ctx, keys...)
+		pipe.SRem(ctx, TagKey(tag), members...)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SetNX sets key only if it doesn't exist (for replay protection)
 func (c *Cache) SetNX(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
 	return c.client.SetNX(ctx, key, value, ttl).Result()
@@ -68,3 +125,52 @@ func (c *Cache) Expire(ctx context.Context, key string, ttl time.Duration) error
 func (c *Cache) Close() error {
 	return c.client.Close()
 }
+
+// Message is a pub/sub message
+type Message struct {
+	Channel string
+	Payload []byte
+}
+
+// Publish sends JSON-encoded value to a pub/sub channel
+func (c *Cache) Publish(ctx context.Context, channel string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.client.Publish(ctx, channel, data).Err()
+}
+
+// PSubscribe subscribes to channels matching pattern. Messages are delivered
+// until ctx is done, then the returned channel is closed.
+func (c *Cache) PSubscribe(ctx context.Context, pattern string) (<-chan Message, error) {
+	ps := c.client.PSubscribe(ctx, pattern)
+	if _, err := ps.Receive(ctx); err != nil {
+		_ = ps.Close()
+		return nil, err
+	}
+
+	out := make(chan Message)
+	go func() {
+		defer close(out)
+		defer ps.Close()
+
+		in := ps.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case out <- Message{Channel: msg.Channel, Payload: []byte(msg.Payload)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
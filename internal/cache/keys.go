@@ -0,0 +1,74 @@
+// internal/cache/keys.go
+package cache
+
+import (
+	"fmt"
+	"time"
+)
+
+// Keys of cached lists
+const (
+	TeamsListKey       = "teams:list"
+	TournamentsListKey = "tournaments:list"
+	VenuesListKey      = "venues:list"
+	DisciplinesListKey = "disciplines:list"
+	PlayersListKey     = "players:list"
+	RolesListKey       = "roles:list"
+)
+
+// RoleKey - cached role with permissions, read on every authenticated request
+func RoleKey(name string) string {
+	return "roles:" + name
+}
+
+// RatingKey - cached team rating of a discipline (0 - all)
+func RatingKey(disciplineID int64) string {
+	return fmt.Sprintf("api:rating:%d", disciplineID)
+}
+
+// PlayerRatingKey - cached player rating of a discipline (0 - all)
+func PlayerRatingKey(disciplineID int64) string {
+	return fmt.Sprintf("api:player_rating:%d", disciplineID)
+}
+
+// TeamStatsKey - cached team stats in a discipline (0 - all)
+func TeamStatsKey(teamID, disciplineID int64) string {
+	return fmt.Sprintf("api:team_stats:%d:%d", teamID, disciplineID)
+}
+
+// VenueStatsKey - cached venue stats in a discipline (0 - all)
+func VenueStatsKey(venueID, disciplineID int64) string {
+	return fmt.Sprintf("api:venue_stats:%d:%d", venueID, disciplineID)
+}
+
+// SessionGenerationKey - counter of a user's sessions; incrementing it
+// revokes every session token issued before
+func SessionGenerationKey(telegramID int64) string {
+	return fmt.Sprintf("session:gen:%d", telegramID)
+}
+
+// IdempotencyKey - stored first response of a mutation; scope is the user ID
+// or "token:<id>" for API tokens
+func IdempotencyKey(scope, key string) string {
+	return fmt.Sprintf("idempotency:%s:%s", scope, key)
+}
+
+// Invalidation tags: a write to the data drops every key tagged with it
+const (
+	TagTeams       = "teams"
+	TagTournaments = "tournaments"
+	TagResults     = "results"
+	TagVenues      = "venues"
+	TagDisciplines = "disciplines"
+	TagPlayers     = "players"
+	TagMembers     = "members"
+	TagRoles       = "roles"
+)
+
+// TagTTL - lifetime of a tag's key set, longer than any tagged key
+const TagTTL = 24 * time.Hour
+
+// TagKey returns the set holding keys registered under tag
+func TagKey(tag string) string {
+	return "tag:" + tag
+}
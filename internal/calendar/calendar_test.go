@@ -0,0 +1,99 @@
+// internal/calendar/calendar_test.go
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	date := time.Date(2026, 3, 14, 0, 0, 0, 0, moscow)
+	start := time.Date(2026, 3, 19, 19, 30, 0, 0, moscow)
+	out := string(Render("Турниры", []Event{{
+		UID:      TournamentUID(7),
+		Start:    date,
+		AllDay:   true,
+		Summary:  "Квиз, финал; сезон 2",
+		Location: "Бар \"Янтарь\"",
+		URL:      "https://t.me/amber_bot/app?startapp=tournament_7",
+		Updated:  date,
+	}, {
+		UID:     TournamentUID(8),
+		Start:   start,
+		End:     start.Add(2 * time.Hour),
+		Summary: "Квиз",
+		Updated: date,
+	}}))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:tournament-7@amber-bot\r\n",
+		"DTSTART;VALUE=DATE:20260314\r\n",
+		"DTEND;VALUE=DATE:20260315\r\n",
+		`SUMMARY:Квиз\, финал\; сезон 2` + "\r\n",
+		"URL:https://t.me/amber_bot/app?startapp=tournament_7\r\n",
+		"DTSTART:20260319T163000Z\r\n",
+		"DTEND:20260319T183000Z\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{"short", "SUMMARY:Квиз"},
+		{"ascii", "DESCRIPTION:" + strings.Repeat("a", 200)},
+		{"cyrillic", "DESCRIPTION:" + strings.Repeat("я", 100)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded := fold(tt.line)
+			for i, part := range strings.Split(folded, "\r\n") {
+				if len(part) > maxLineBytes {
+					t.Errorf("line %d is %d bytes", i, len(part))
+				}
+			}
+			if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != tt.line {
+				t.Errorf("unfolded line differs: %q", unfolded)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	f := NewFeeds("secret", "")
+	token := f.Token(Scope(5))
+
+	tests := []struct {
+		name  string
+		scope string
+		token string
+		want  bool
+	}{
+		{"valid", Scope(5), token, true},
+		{"other team", Scope(6), token, false},
+		{"all tournaments", Scope(0), token, false},
+		{"empty", Scope(5), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.Verify(tt.scope, tt.token); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if NewFeeds("other", "").Verify(Scope(5), token) {
+		t.Error("token must not verify with another secret")
+	}
+}
@@ -0,0 +1,105 @@
+// internal/calendar/ical.go
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Event is a VEVENT; AllDay events use the local date of Start
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Summary     string
+	Location    string
+	Description string
+	URL         string
+	Updated     time.Time
+}
+
+const (
+	prodID       = "-//Amber Bot//Tournaments//RU"
+	maxLineBytes = 75
+	utcLayout    = "20060102T150405Z"
+)
+
+// Render returns an iCalendar (RFC 5545) document with the events
+func Render(name string, events []Event) []byte {
+	var b strings.Builder
+	line := func(s string) {
+		b.WriteString(fold(s))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:" + prodID)
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + escape(name))
+	for _, e := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + e.UID)
+		line("DTSTAMP:" + e.Updated.UTC().Format(utcLayout))
+		if e.AllDay {
+			line("DTSTART;VALUE=DATE:" + e.Start.Format("20060102"))
+			line("DTEND;VALUE=DATE:" + e.Start.AddDate(0, 0, 1).Format("20060102"))
+		} else {
+			line("DTSTART:" + e.Start.UTC().Format(utcLayout))
+			line("DTEND:" + e.End.UTC().Format(utcLayout))
+		}
+		line("SUMMARY:" + escape(e.Summary))
+		if e.Location != "" {
+			line("LOCATION:" + escape(e.Location))
+		}
+		if e.Description != "" {
+			line("DESCRIPTION:" + escape(e.Description))
+		}
+		if e.URL != "" {
+			line("URL:" + e.URL)
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// TournamentUID returns a stable event UID for a tournament
+func TournamentUID(tournamentID int64) string {
+	return fmt.Sprintf("tournament-%d@amber-bot", tournamentID)
+}
+
+// escape escapes TEXT values
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// fold splits a content line into 75-octet chunks without breaking UTF-8 runes
+func fold(s string) string {
+	if len(s) <= maxLineBytes {
+		return s
+	}
+	var b strings.Builder
+	n := 0
+	limit := maxLineBytes
+	for _, r := range s {
+		size := len(string(r))
+		if n+size > limit {
+			b.WriteString("\r\n ")
+			n = 0
+			limit = maxLineBytes - 1 // continuation lines start with a space
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	return b.String()
+}
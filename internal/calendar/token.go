@@ -0,0 +1,50 @@
+// internal/calendar/token.go
+package calendar
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Feeds signs subscription URLs, so calendar apps can fetch feeds
+// without Telegram auth
+type Feeds struct {
+	secret     []byte
+	miniAppURL string
+}
+
+// NewFeeds creates feed signer. miniAppURL is the t.me link of the Mini App
+// (https://t.me/<bot>/<app>); empty disables event links.
+func NewFeeds(secret, miniAppURL string) *Feeds {
+	return &Feeds{secret: []byte(secret), miniAppURL: miniAppURL}
+}
+
+// Scope identifies a feed: all tournaments or one team's
+func Scope(teamID int64) string {
+	if teamID == 0 {
+		return "all"
+	}
+	return fmt.Sprintf("team:%d", teamID)
+}
+
+// Token returns the subscription token of a feed
+func (f *Feeds) Token(scope string) string {
+	mac := hmac.New(sha256.New, f.secret)
+	mac.Write([]byte("calendar:" + scope))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+// Verify checks the subscription token of a feed
+func (f *Feeds) Verify(scope, token string) bool {
+	return hmac.Equal([]byte(f.Token(scope)), []byte(token))
+}
+
+// TournamentLink returns the Mini App deep link to a tournament
+func (f *Feeds) TournamentLink(tournamentID int64) string {
+	if f.miniAppURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s?startapp=tournament_%d", f.miniAppURL, tournamentID)
+}
@@ -2,8 +2,11 @@
 package config
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
+	_ "time/tzdata" // timezones without system tzdata (scratch images)
 
 	"github.com/caarlos0/env/v10"
 	"github.com/joho/godotenv"
@@ -16,6 +19,10 @@ type Config struct {
 	AdminIDsRaw   string  `env:"ADMIN_IDS" envDefault:""`
 	AdminIDs      []int64 `env:"-"`
 
+	// ADMIN_IDS are promoted only while there are no admins; locked - always
+	// admins, their role can't be changed from the bot or API
+	AdminIDsLocked bool `env:"ADMIN_IDS_LOCKED" envDefault:"false"`
+
 	// API server config
 	APIPort      int    `env:"API_PORT" envDefault:"8080"`
 	FrontendPath string `env:"FRONTEND_PATH" envDefault:"./frontend/dist"`
@@ -23,6 +30,25 @@ type Config struct {
 	// Mini App URL (for bot button)
 	MiniAppURL string `env:"MINI_APP_URL" envDefault:""`
 
+	// Mini App t.me link for deep links (https://t.me/<bot>/<app>)
+	MiniAppLink string `env:"MINI_APP_LINK" envDefault:""`
+
+	// Bot username without @, for invite links created via API
+	BotUsername string `env:"BOT_USERNAME" envDefault:""`
+
+	// Secret for Mini App session tokens, defaults to TELEGRAM_TOKEN
+	SessionSecret string `env:"SESSION_SECRET" envDefault:""`
+
+	// Secret for calendar feed tokens, defaults to TELEGRAM_TOKEN
+	CalendarSecret string `env:"CALENDAR_SECRET" envDefault:""`
+
+	// Default timezone of tournaments (IANA name)
+	Timezone string         `env:"TIMEZONE" envDefault:"Europe/Moscow"`
+	Location *time.Location `env:"-"`
+
+	// Chat for bot announcements (badges), 0 disables
+	AnnounceChatID int64 `env:"ANNOUNCE_CHAT_ID" envDefault:"0"`
+
 	// Dev mode (bypasses Telegram auth)
 	DevMode      bool  `env:"DEV_MODE" envDefault:"false"`
 	DevUserID    int64 `env:"DEV_USER_ID" envDefault:"123456789"`
@@ -39,6 +65,18 @@ func Load() (*Config, error) {
 	}
 
 	cfg.AdminIDs = parseAdminIDs(cfg.AdminIDsRaw)
+
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TIMEZONE: %w", err)
+	}
+	cfg.Location = loc
+	if cfg.CalendarSecret == "" {
+		cfg.CalendarSecret = cfg.TelegramToken
+	}
+	if cfg.SessionSecret == "" {
+		cfg.SessionSecret = cfg.TelegramToken
+	}
 	return cfg, nil
 }
 
@@ -55,3 +93,11 @@ func parseAdminIDs(raw string) []int64 {
 	}
 	return ids
 }
+
+// LockedAdminIDs returns ADMIN_IDS pinned to admin, nil unless ADMIN_IDS_LOCKED
+func (c *Config) LockedAdminIDs() []int64 {
+	if !c.AdminIDsLocked {
+		return nil
+	}
+	return c.AdminIDs
+}
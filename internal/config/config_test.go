@@ -30,3 +30,50 @@ func TestLoad(t *testing.T) {
 		t.Errorf("expected 2 admin IDs, got %d", len(cfg.AdminIDs))
 	}
 }
+
+func TestLoadTimezone(t *testing.T) {
+	os.Setenv("TELEGRAM_TOKEN", "test_token")
+	os.Setenv("DATABASE_URL", "postgres://test")
+	os.Setenv("REDIS_URL", "redis://localhost:6379")
+	defer func() {
+		os.Unsetenv("TELEGRAM_TOKEN")
+		os.Unsetenv("DATABASE_URL")
+		os.Unsetenv("REDIS_URL")
+		os.Unsetenv("TIMEZONE")
+	}()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"default", "", "Europe/Moscow", false},
+		{"custom", "Asia/Yekaterinburg", "Asia/Yekaterinburg", false},
+		{"invalid", "Mars/Olympus", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.value == "" {
+				os.Unsetenv("TIMEZONE")
+			} else {
+				os.Setenv("TIMEZONE", tt.value)
+			}
+
+			cfg, err := Load()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.Location.String() != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, cfg.Location)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// internal/domain/api_token.go
+package domain
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// API token scopes
+const (
+	ScopeRead         = "read"          // GET endpoints
+	ScopeWriteResults = "write:results" // + recording tournament results
+	ScopeAdmin        = "admin"         // everything the creator may do
+)
+
+// APIToken lets scripts and services call the API as its creator, limited
+// by scopes. Only the SHA-256 hash of the token is stored.
+type APIToken struct {
+	bun.BaseModel `bun:"table:api_tokens"`
+
+	ID         int64      `bun:"id,pk,autoincrement"`
+	Name       string     `bun:"name,notnull"`
+	Prefix     string     `bun:"prefix,notnull"` // first characters, to recognise the token in lists
+	TokenHash  string     `bun:"token_hash,notnull"`
+	Scopes     []string   `bun:"scopes,array"`
+	ExpiresAt  *time.Time `bun:"expires_at"`
+	LastUsedAt *time.Time `bun:"last_used_at"`
+	CreatedBy  int64      `bun:"created_by,notnull"`
+	CreatedAt  time.Time  `bun:"created_at,default:current_timestamp"`
+
+	// Revocation
+	RevokedAt *time.Time `bun:"revoked_at"`
+	RevokedBy *int64     `bun:"revoked_by"`
+}
+
+// IsKnownScope reports whether scope can be granted to a token
+func IsKnownScope(scope string) bool {
+	switch scope {
+	case ScopeRead, ScopeWriteResults, ScopeAdmin:
+		return true
+	}
+	return false
+}
+
+// HasScope reports whether token was granted the scope; admin implies all
+func (t *APIToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// Active reports whether token is neither revoked nor expired
+func (t *APIToken) Active(now time.Time) bool {
+	if t.RevokedAt != nil {
+		return false
+	}
+	return t.ExpiresAt == nil || now.Before(*t.ExpiresAt)
+}
+
+// Role caps creator's role by token scopes
+func (t *APIToken) Role(creator Role) Role {
+	switch {
+	case t.HasScope(ScopeAdmin):
+		return creator
+	case t.HasScope(ScopeWriteResults) && creator != RoleViewer:
+		return RoleOrganizer
+	default:
+		return RoleViewer
+	}
+}
+
+// Permissions caps creator's permissions by token scopes: write:results keeps
+// only results.record
+func (t *APIToken) Permissions(creator []Permission) []Permission {
+	if t.HasScope(ScopeAdmin) {
+		return creator
+	}
+	if t.HasScope(ScopeWriteResults) {
+		for _, p := range creator {
+			if p == PermResultsRecord {
+				return []Permission{PermResultsRecord}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// internal/domain/audit.go
+package domain
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Audit actions
+const (
+	AuditTeamMerge       = "team.merge"
+	AuditTeamMergeRevert = "team.merge_revert"
+	AuditRoleCreate      = "role.create"
+	AuditRoleUpdate      = "role.update"
+	AuditRoleDelete      = "role.delete"
+	AuditInviteCreate    = "invite.create"
+	AuditInviteRevoke    = "invite.revoke"
+	AuditInviteRedeem    = "invite.redeem"
+	AuditUserBlock       = "user.block"
+	AuditUserUnblock     = "user.unblock"
+	AuditUserRoleChange  = "user.role_change"
+	AuditVenueDelete     = "venue.delete"
+	AuditVenueMerge      = "venue.merge"
+)
+
+// Audit/event entity types
+const (
+	EntityTeam       = "team"
+	EntityMember     = "member"
+	EntityTournament = "tournament"
+	EntityResult     = "result"
+	EntityBadge      = "badge"
+	EntityVenue      = "venue"
+	EntityDiscipline = "discipline"
+	EntityPlayer     = "player"
+	EntityRole       = "role"
+	EntityInvite     = "invite"
+	EntityUser       = "user"
+)
+
+type AuditEntry struct {
+	bun.BaseModel `bun:"table:audit_log"`
+
+	ID         int64          `bun:"id,pk,autoincrement"`
+	ActorID    int64          `bun:"actor_id,notnull"`
+	Action     string         `bun:"action,notnull"`
+	EntityType string         `bun:"entity_type,notnull"`
+	EntityID   int64          `bun:"entity_id,notnull"`
+	Details    map[string]any `bun:"details,type:jsonb"`
+	CreatedAt  time.Time      `bun:"created_at,default:current_timestamp"`
+}
@@ -0,0 +1,34 @@
+// internal/domain/badge.go
+package domain
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Badge codes
+const (
+	BadgeFirstWin      = "first_win"
+	BadgePodiumStreak5 = "podium_streak_5"
+	BadgeTournaments10 = "tournaments_10"
+	BadgeTournaments50 = "tournaments_50"
+	BadgeBeatLeader    = "beat_leader"
+)
+
+// Badge is an achievement awarded to a team, or to a team member
+// (MemberID set) who was on the team when it was earned
+type Badge struct {
+	bun.BaseModel `bun:"table:badges"`
+
+	ID          int64      `bun:"id,pk,autoincrement"`
+	TeamID      int64      `bun:"team_id,notnull"`
+	MemberID    *int64     `bun:"member_id"`
+	Code        string     `bun:"code,notnull"`
+	ResultID    *int64     `bun:"result_id"`
+	AwardedAt   time.Time  `bun:"awarded_at,default:current_timestamp"`
+	AnnouncedAt *time.Time `bun:"announced_at"`
+
+	// Relations
+	Team *Team `bun:"rel:belongs-to,join:team_id=id"`
+}
@@ -0,0 +1,33 @@
+// internal/domain/discipline.go
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Discipline - game type (quiz, board games, ...); ratings are kept per discipline
+type Discipline struct {
+	bun.BaseModel `bun:"table:disciplines"`
+
+	ID        int64     `bun:"id,pk,autoincrement"`
+	Name      string    `bun:"name,notnull"`
+	CreatedAt time.Time `bun:"created_at,default:current_timestamp"`
+	CreatedBy int64     `bun:"created_by"`
+
+	// Metadata for updates
+	UpdatedAt *time.Time `bun:"updated_at"`
+	UpdatedBy *int64     `bun:"updated_by"`
+
+	// Soft delete
+	DeletedAt *time.Time `bun:"deleted_at,soft_delete"`
+	DeletedBy *int64     `bun:"deleted_by"`
+
+	// Optimistic locking
+	Version int `bun:"version,default:1"`
+}
+
+// ErrDisciplineInUse - discipline still has tournaments
+var ErrDisciplineInUse = errors.New("discipline has tournaments")
@@ -0,0 +1,67 @@
+// internal/domain/event.go
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Domain event types
+const (
+	EventTeamCreated       = "team.created"
+	EventTeamUpdated       = "team.updated"
+	EventTeamDeleted       = "team.deleted"
+	EventTeamMerged        = "team.merged"
+	EventTeamMergeReverted = "team.merge_reverted"
+	EventMemberCreated     = "member.created"
+	EventMemberUpdated     = "member.updated"
+	EventMemberDeleted     = "member.deleted"
+	EventTournamentCreated = "tournament.created"
+	EventTournamentUpdated = "tournament.updated"
+	EventTournamentDeleted = "tournament.deleted"
+	EventResultCreated     = "result.created"
+	EventResultUpdated     = "result.updated"
+	EventResultDeleted     = "result.deleted"
+	EventBadgeAwarded      = "badge.awarded"
+	EventVenueCreated      = "venue.created"
+	EventVenueUpdated      = "venue.updated"
+	EventVenueDeleted      = "venue.deleted"
+	EventDisciplineCreated = "discipline.created"
+	EventDisciplineUpdated = "discipline.updated"
+	EventDisciplineDeleted = "discipline.deleted"
+	EventPlayerCreated     = "player.created"
+	EventPlayerUpdated     = "player.updated"
+	EventPlayerDeleted     = "player.deleted"
+	EventUserRoleChanged   = "user.role_changed"
+)
+
+// Event is a domain event written to the outbox in the same transaction as the change
+type Event struct {
+	bun.BaseModel `bun:"table:domain_events,alias:event"`
+
+	ID            int64          `bun:"id,pk,autoincrement"`
+	Type          string         `bun:"type,notnull"`
+	AggregateType string         `bun:"aggregate_type,notnull"`
+	AggregateID   int64          `bun:"aggregate_id,notnull"`
+	ActorID       int64          `bun:"actor_id"`
+	Payload       map[string]any `bun:"payload,type:jsonb"`
+	OccurredAt    time.Time      `bun:"occurred_at,default:current_timestamp"`
+}
+
+// Int64 reads a numeric payload field (JSON numbers decode as float64)
+func (e *Event) Int64(key string) int64 {
+	switch v := e.Payload[key].(type) {
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case json.Number:
+		n, _ := v.Int64()
+		return n
+	}
+	return 0
+}
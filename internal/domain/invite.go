@@ -0,0 +1,84 @@
+// internal/domain/invite.go
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	// InvitePrefix starts the /start payload of an invite deep link
+	InvitePrefix = "inv_"
+	// InviteTTL - default invite lifetime
+	InviteTTL = 7 * 24 * time.Hour
+)
+
+// Invite - deep link t.me/<bot>?start=inv_<token> that grants a role on
+// redemption. Only the token hash is stored.
+type Invite struct {
+	bun.BaseModel `bun:"table:invites"`
+
+	ID        int64      `bun:"id,pk,autoincrement"`
+	Prefix    string     `bun:"prefix,notnull"`
+	TokenHash string     `bun:"token_hash,notnull"`
+	Role      Role       `bun:"role,notnull"`
+	MaxUses   int        `bun:"max_uses,notnull"`
+	Uses      int        `bun:"uses,notnull"`
+	ExpiresAt time.Time  `bun:"expires_at,notnull"`
+	CreatedBy int64      `bun:"created_by,notnull"`
+	CreatedAt time.Time  `bun:"created_at,default:current_timestamp"`
+	RevokedAt *time.Time `bun:"revoked_at"`
+	RevokedBy *int64     `bun:"revoked_by"`
+}
+
+var (
+	// ErrInviteInvalid - unknown or revoked invite
+	ErrInviteInvalid = errors.New("invalid invite")
+	// ErrInviteExpired - invite expired
+	ErrInviteExpired = errors.New("invite expired")
+	// ErrInviteUsedUp - invite reached max uses
+	ErrInviteUsedUp = errors.New("invite used up")
+	// ErrInviteNotNeeded - user already has a role other than viewer
+	ErrInviteNotNeeded = errors.New("invite not needed")
+)
+
+// NewInviteToken returns a random invite token and its display prefix.
+// The token fits Telegram's 64-char start parameter.
+func NewInviteToken() (token, prefix string, err error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = InvitePrefix + hex.EncodeToString(b)
+	return token, token[:len(InvitePrefix)+6], nil
+}
+
+// HashInviteToken returns hex SHA-256 of token, as stored in the database
+func HashInviteToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// InviteLink returns the deep link of token for the bot
+func InviteLink(botUsername, token string) string {
+	return fmt.Sprintf("https://t.me/%s?start=%s", botUsername, token)
+}
+
+// Check returns why invite can't be redeemed now, or nil
+func (i *Invite) Check(now time.Time) error {
+	switch {
+	case i.RevokedAt != nil:
+		return ErrInviteInvalid
+	case !now.Before(i.ExpiresAt):
+		return ErrInviteExpired
+	case i.Uses >= i.MaxUses:
+		return ErrInviteUsedUp
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// internal/domain/invite_test.go
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInviteCheck(t *testing.T) {
+	now := time.Date(2026, 3, 19, 12, 0, 0, 0, time.UTC)
+	revoked := now.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		invite *Invite
+		want   error
+	}{
+		{"active", &Invite{MaxUses: 1, ExpiresAt: now.Add(time.Hour)}, nil},
+		{"revoked", &Invite{MaxUses: 1, ExpiresAt: now.Add(time.Hour), RevokedAt: &revoked}, ErrInviteInvalid},
+		{"expired", &Invite{MaxUses: 1, ExpiresAt: now}, ErrInviteExpired},
+		{"used up", &Invite{MaxUses: 2, Uses: 2, ExpiresAt: now.Add(time.Hour)}, ErrInviteUsedUp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.invite.Check(now); !errors.Is(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewInviteToken(t *testing.T) {
+	token, prefix, err := NewInviteToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Telegram start parameter: up to 64 chars
+	if len(token) > 64 || !strings.HasPrefix(token, InvitePrefix) {
+		t.Errorf("invalid token %q", token)
+	}
+	if !strings.HasPrefix(token, prefix) {
+		t.Errorf("prefix %q doesn't match token %q", prefix, token)
+	}
+	if HashInviteToken(token) == HashInviteToken(token+"x") {
+		t.Error("hash collision")
+	}
+}
@@ -0,0 +1,84 @@
+// internal/domain/permission.go
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Permission - action a role may perform
+type Permission string
+
+const (
+	PermTeamsManage       Permission = "teams.manage"       // teams, members, players
+	PermTeamsMerge        Permission = "teams.merge"        // merge and revert merges
+	PermTournamentsManage Permission = "tournaments.manage" // tournaments, status, registrations, venues
+	PermResultsRecord     Permission = "results.record"     // record, edit and delete results
+	PermUsersManage       Permission = "users.manage"       // list users, assign roles
+	PermRolesManage       Permission = "roles.manage"       // custom roles
+	PermSettingsManage    Permission = "settings.manage"    // disciplines, badges, webhooks, API tokens, audit
+)
+
+// AllPermissions lists permissions in display order
+var AllPermissions = []Permission{
+	PermTeamsManage,
+	PermTeamsMerge,
+	PermTournamentsManage,
+	PermResultsRecord,
+	PermUsersManage,
+	PermRolesManage,
+	PermSettingsManage,
+}
+
+// IsKnownPermission reports whether permission can be granted to a role
+func IsKnownPermission(p string) bool {
+	for _, known := range AllPermissions {
+		if string(known) == p {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleDef - role and its permissions. Users reference it by name; built-in
+// roles (viewer, organizer, admin) can't be deleted, admin has every permission.
+type RoleDef struct {
+	bun.BaseModel `bun:"table:roles"`
+
+	ID          int64     `bun:"id,pk,autoincrement"`
+	Name        string    `bun:"name,notnull"`
+	Description string    `bun:"description,notnull"`
+	Permissions []string  `bun:"permissions,array"`
+	Builtin     bool      `bun:"builtin,notnull"`
+	CreatedAt   time.Time `bun:"created_at,default:current_timestamp"`
+	CreatedBy   int64     `bun:"created_by"`
+
+	// Metadata for updates
+	UpdatedAt *time.Time `bun:"updated_at"`
+	UpdatedBy *int64     `bun:"updated_by"`
+
+	// Optimistic locking
+	Version int `bun:"version,default:1"`
+}
+
+// Grants returns permissions of the role; admin gets all, including ones
+// added after the role was stored
+func (r *RoleDef) Grants() []Permission {
+	if Role(r.Name) == RoleAdmin {
+		return AllPermissions
+	}
+	perms := make([]Permission, 0, len(r.Permissions))
+	for _, p := range r.Permissions {
+		perms = append(perms, Permission(p))
+	}
+	return perms
+}
+
+var (
+	// ErrRoleInUse - role is still assigned to users
+	ErrRoleInUse = errors.New("role is assigned to users")
+	// ErrRoleBuiltin - built-in roles can't be deleted
+	ErrRoleBuiltin = errors.New("built-in role")
+)
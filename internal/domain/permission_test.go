@@ -0,0 +1,86 @@
+// internal/domain/permission_test.go
+package domain
+
+import "testing"
+
+func TestUserCan(t *testing.T) {
+	scorekeeper := &RoleDef{Name: "scorekeeper", Permissions: []string{string(PermResultsRecord)}}
+	admin := &RoleDef{Name: string(RoleAdmin), Builtin: true}
+
+	tests := []struct {
+		name string
+		user *User
+		perm Permission
+		want bool
+	}{
+		{"granted", &User{Role: "scorekeeper", Permissions: scorekeeper.Grants()}, PermResultsRecord, true},
+		{"not granted", &User{Role: "scorekeeper", Permissions: scorekeeper.Grants()}, PermTournamentsManage, false},
+		{"viewer", &User{Role: RoleViewer}, PermResultsRecord, false},
+		{"admin role grants all", &User{Role: RoleAdmin, Permissions: admin.Grants()}, PermRolesManage, true},
+		{"admin without loaded permissions", &User{Role: RoleAdmin}, PermSettingsManage, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.Can(tt.perm); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUserCanAll(t *testing.T) {
+	moderator := &User{Role: "moderator", Permissions: []Permission{PermUsersManage, PermResultsRecord}}
+
+	tests := []struct {
+		name  string
+		user  *User
+		perms []Permission
+		want  bool
+	}{
+		{"no permissions", moderator, nil, true},
+		{"subset", moderator, []Permission{PermResultsRecord}, true},
+		{"all held", moderator, []Permission{PermResultsRecord, PermUsersManage}, true},
+		{"one missing", moderator, []Permission{PermResultsRecord, PermRolesManage}, false},
+		{"admin holds all", &User{Role: RoleAdmin}, AllPermissions, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.CanAll(tt.perms); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAPITokenPermissions(t *testing.T) {
+	organizer := []Permission{PermTeamsManage, PermTournamentsManage, PermResultsRecord}
+
+	tests := []struct {
+		name    string
+		scopes  []string
+		creator []Permission
+		want    []Permission
+	}{
+		{"admin scope keeps creator's", []string{ScopeAdmin}, organizer, organizer},
+		{"write:results keeps results.record", []string{ScopeWriteResults}, organizer, []Permission{PermResultsRecord}},
+		{"write:results without creator's permission", []string{ScopeWriteResults}, []Permission{PermTeamsMerge}, nil},
+		{"read", []string{ScopeRead}, organizer, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := &APIToken{Scopes: tt.scopes}
+			got := token.Permissions(tt.creator)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
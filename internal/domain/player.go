@@ -0,0 +1,32 @@
+// internal/domain/player.go
+package domain
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Player - participant of individual tournaments, optionally linked to a
+// team member or a Telegram user
+type Player struct {
+	bun.BaseModel `bun:"table:players"`
+
+	ID         int64     `bun:"id,pk,autoincrement"`
+	Name       string    `bun:"name,notnull"`
+	MemberID   *int64    `bun:"member_id"`
+	TelegramID *int64    `bun:"telegram_id"`
+	CreatedAt  time.Time `bun:"created_at,default:current_timestamp"`
+	CreatedBy  int64     `bun:"created_by"`
+
+	// Metadata for updates
+	UpdatedAt *time.Time `bun:"updated_at"`
+	UpdatedBy *int64     `bun:"updated_by"`
+
+	// Soft delete
+	DeletedAt *time.Time `bun:"deleted_at,soft_delete"`
+	DeletedBy *int64     `bun:"deleted_by"`
+
+	// Optimistic locking
+	Version int `bun:"version,default:1"`
+}
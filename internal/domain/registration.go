@@ -0,0 +1,20 @@
+// internal/domain/registration.go
+package domain
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Registration - team signed up for a tournament
+type Registration struct {
+	bun.BaseModel `bun:"table:tournament_registrations,alias:reg"`
+
+	TournamentID int64     `bun:"tournament_id,pk"`
+	TeamID       int64     `bun:"team_id,pk"`
+	RegisteredBy int64     `bun:"registered_by,notnull"`
+	RegisteredAt time.Time `bun:"registered_at,default:current_timestamp"`
+
+	Team *Team `bun:"rel:belongs-to,join:team_id=id"`
+}
@@ -11,7 +11,8 @@ type Result struct {
 	bun.BaseModel `bun:"table:results"`
 
 	ID           int64     `bun:"id,pk,autoincrement"`
-	TeamID       int64     `bun:"team_id,notnull"`
+	TeamID       int64     `bun:"team_id,nullzero"`   // team tournaments
+	PlayerID     int64     `bun:"player_id,nullzero"` // individual tournaments
 	TournamentID int64     `bun:"tournament_id,notnull"`
 	Place        int       `bun:"place,notnull"`
 	RecordedBy   int64     `bun:"recorded_by"`
@@ -28,7 +29,33 @@ type Result struct {
 	// Optimistic locking
 	Version int `bun:"version,default:1"`
 
+	// Name the team used on the tournament date (from name history), if it differs
+	TeamNameAtDate *string `bun:"team_name_at_date,scanonly"`
+
 	// Relations
 	Team       *Team       `bun:"rel:belongs-to,join:team_id=id"`
+	Player     *Player     `bun:"rel:belongs-to,join:player_id=id"`
 	Tournament *Tournament `bun:"rel:belongs-to,join:tournament_id=id"`
 }
+
+// DisplayName returns player name for individual results, team name otherwise
+func (r *Result) DisplayName() string {
+	if r.PlayerID != 0 {
+		if r.Player != nil {
+			return r.Player.Name
+		}
+		return ""
+	}
+	return r.DisplayTeamName()
+}
+
+// DisplayTeamName returns the team name as it was on the tournament date
+func (r *Result) DisplayTeamName() string {
+	if r.TeamNameAtDate != nil {
+		return *r.TeamNameAtDate
+	}
+	if r.Team != nil {
+		return r.Team.Name
+	}
+	return ""
+}
@@ -26,3 +26,25 @@ type Team struct {
 	// Optimistic locking
 	Version int `bun:"version,default:1"`
 }
+
+// Alias kinds
+const (
+	AliasKindMerge  = "merge"  // name of a team merged into this one
+	AliasKindRename = "rename" // previous name of this team
+)
+
+// TeamAlias - alternative name that resolves to a team.
+// For renames ValidFrom/ValidTo hold the period the name was in use.
+type TeamAlias struct {
+	bun.BaseModel `bun:"table:team_aliases"`
+
+	ID        int64      `bun:"id,pk,autoincrement"`
+	TeamID    int64      `bun:"team_id,notnull"`
+	Name      string     `bun:"name,notnull"`
+	Kind      string     `bun:"kind,notnull"`
+	ValidFrom *time.Time `bun:"valid_from"`
+	ValidTo   *time.Time `bun:"valid_to"`
+	MergeID   *int64     `bun:"merge_id"`
+	CreatedBy int64      `bun:"created_by"`
+	CreatedAt time.Time  `bun:"created_at,default:current_timestamp"`
+}
@@ -0,0 +1,42 @@
+// internal/domain/team_merge.go
+package domain
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// TeamMerge records everything moved from source to target so the merge can be reverted
+type TeamMerge struct {
+	bun.BaseModel `bun:"table:team_merges"`
+
+	ID             int64           `bun:"id,pk,autoincrement"`
+	SourceTeamID   int64           `bun:"source_team_id,notnull"`
+	TargetTeamID   int64           `bun:"target_team_id,notnull"`
+	SourceName     string          `bun:"source_name,notnull"`
+	AliasID        *int64          `bun:"alias_id"`
+	MovedMemberIDs []int64         `bun:"moved_member_ids,array"`
+	MovedResultIDs []int64         `bun:"moved_result_ids,array"`
+	MovedAliasIDs  []int64         `bun:"moved_alias_ids,array"`
+	Conflicts      []MergeConflict `bun:"conflicts,type:jsonb"`
+	MergedBy       int64           `bun:"merged_by,notnull"`
+	MergedAt       time.Time       `bun:"merged_at,default:current_timestamp"`
+
+	RevertedAt *time.Time `bun:"reverted_at"`
+	RevertedBy *int64     `bun:"reverted_by"`
+}
+
+// MergeConflict - both teams have a result in the same tournament.
+// The best place is kept on the target result, the source result is soft-deleted.
+type MergeConflict struct {
+	TournamentID   int64 `json:"tournament_id"`
+	SourceResultID int64 `json:"source_result_id"`
+	TargetResultID int64 `json:"target_result_id"`
+	SourcePlace    int   `json:"source_place"`
+	TargetPlace    int   `json:"target_place"`
+}
+
+func (m *TeamMerge) IsReverted() bool {
+	return m.RevertedAt != nil
+}
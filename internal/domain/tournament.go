@@ -2,6 +2,7 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -12,11 +13,22 @@ type Tournament struct {
 
 	ID        int64     `bun:"id,pk,autoincrement"`
 	Name      string    `bun:"name,notnull"`
-	Date      time.Time `bun:"date,notnull"`
-	Location  string    `bun:"location"`
+	Date      time.Time `bun:"date,notnull"` // local day of StartsAt
+	Location  string    `bun:"location"`     // venue name when VenueID is set
+	VenueID   *int64    `bun:"venue_id"`
 	CreatedBy int64     `bun:"created_by"`
 	CreatedAt time.Time `bun:"created_at,default:current_timestamp"`
 
+	// Game type; ratings are computed per discipline
+	DisciplineID int64 `bun:"discipline_id,notnull"`
+	// Who takes places: teams or individual players
+	ParticipantMode ParticipantMode `bun:"participant_mode,notnull,default:'team'"`
+
+	// Schedule: StartsAt nil - time not set (all-day), Timezone "" - org default
+	StartsAt        *time.Time `bun:"starts_at"`
+	DurationMinutes int        `bun:"duration_minutes,notnull,default:0"`
+	Timezone        string     `bun:"timezone,notnull,default:''"`
+
 	// Metadata for updates
 	UpdatedAt *time.Time `bun:"updated_at"`
 	UpdatedBy *int64     `bun:"updated_by"`
@@ -25,6 +37,160 @@ type Tournament struct {
 	DeletedAt *time.Time `bun:"deleted_at,soft_delete"`
 	DeletedBy *int64     `bun:"deleted_by"`
 
+	// Lifecycle: planned -> in_progress -> finalized
+	Status      TournamentStatus `bun:"status,notnull,default:'planned'"`
+	FinalizedAt *time.Time       `bun:"finalized_at"`
+
 	// Optimistic locking
 	Version int `bun:"version,default:1"`
 }
+
+type TournamentStatus string
+
+const (
+	TournamentPlanned    TournamentStatus = "planned"
+	TournamentInProgress TournamentStatus = "in_progress"
+	TournamentFinalized  TournamentStatus = "finalized"
+)
+
+// ParticipantMode - who results are recorded for
+type ParticipantMode string
+
+const (
+	ParticipantTeams   ParticipantMode = "team"
+	ParticipantPlayers ParticipantMode = "individual"
+)
+
+// IsIndividual reports whether results reference players instead of teams
+func (t *Tournament) IsIndividual() bool {
+	return t.ParticipantMode == ParticipantPlayers
+}
+
+// Phases: where the tournament is relative to now
+const (
+	PhaseUpcoming = "upcoming"
+	PhaseOngoing  = "ongoing"
+	PhaseFinished = "finished"
+)
+
+var (
+	ErrTournamentNotStarted   = errors.New("tournament has not started")
+	ErrTournamentFinalized    = errors.New("tournament is finalized")
+	ErrInvalidStatusChange    = errors.New("invalid tournament status transition")
+	ErrStatusChangeNeedsAdmin = errors.New("tournament status transition requires admin")
+)
+
+// DefaultTournamentDuration - expected duration when not set
+const DefaultTournamentDuration = 2 * time.Hour
+
+// Zone returns tournament timezone, def if unset or unknown
+func (t *Tournament) Zone(def *time.Location) *time.Location {
+	if t.Timezone == "" {
+		return def
+	}
+	loc, err := time.LoadLocation(t.Timezone)
+	if err != nil {
+		return def
+	}
+	return loc
+}
+
+// Start returns start in the tournament timezone; midnight of Date if time is not set
+func (t *Tournament) Start(def *time.Location) time.Time {
+	loc := t.Zone(def)
+	if t.StartsAt != nil {
+		return t.StartsAt.In(loc)
+	}
+	return time.Date(t.Date.Year(), t.Date.Month(), t.Date.Day(), 0, 0, 0, 0, loc)
+}
+
+// End returns expected end: start plus duration, next midnight if time is not set
+func (t *Tournament) End(def *time.Location) time.Time {
+	start := t.Start(def)
+	if t.StartsAt == nil {
+		return start.AddDate(0, 0, 1)
+	}
+	return start.Add(t.Duration())
+}
+
+// Duration returns expected duration, default if not set
+func (t *Tournament) Duration() time.Duration {
+	if t.DurationMinutes > 0 {
+		return time.Duration(t.DurationMinutes) * time.Minute
+	}
+	return DefaultTournamentDuration
+}
+
+// SetStart sets start and Date to its local day; hasTime false makes the tournament all-day
+func (t *Tournament) SetStart(start time.Time, hasTime bool) {
+	t.Date = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	t.StartsAt = nil
+	if hasTime {
+		t.StartsAt = &start
+	}
+}
+
+// Phase returns upcoming, ongoing or finished. Status wins over time: an
+// in-progress tournament is ongoing and a finalized one finished.
+func (t *Tournament) Phase(now time.Time, def *time.Location) string {
+	switch t.Status {
+	case TournamentInProgress:
+		return PhaseOngoing
+	case TournamentFinalized:
+		return PhaseFinished
+	}
+	switch {
+	case now.Before(t.Start(def)):
+		return PhaseUpcoming
+	case now.Before(t.End(def)):
+		return PhaseOngoing
+	default:
+		return PhaseFinished
+	}
+}
+
+// CheckEditable reports whether the tournament and its results can be changed
+func (t *Tournament) CheckEditable(isAdmin bool) error {
+	if t.Status == TournamentFinalized && !isAdmin {
+		return ErrTournamentFinalized
+	}
+	return nil
+}
+
+// CheckResults reports whether results can be recorded now: the tournament
+// must have started and, unless admin, not be finalized
+func (t *Tournament) CheckResults(now time.Time, def *time.Location, isAdmin bool) error {
+	if err := t.CheckEditable(isAdmin); err != nil {
+		return err
+	}
+	if t.Status == TournamentPlanned && now.Before(t.Start(def)) {
+		return ErrTournamentNotStarted
+	}
+	return nil
+}
+
+// CheckTransition validates status change; reopening a finalized tournament
+// is admin only
+func (t *Tournament) CheckTransition(to TournamentStatus, isAdmin bool) error {
+	switch {
+	case t.Status == TournamentPlanned && to == TournamentInProgress,
+		t.Status == TournamentInProgress && to == TournamentPlanned,
+		t.Status == TournamentInProgress && to == TournamentFinalized:
+		return nil
+	case t.Status == TournamentFinalized && to == TournamentInProgress:
+		if !isAdmin {
+			return ErrStatusChangeNeedsAdmin
+		}
+		return nil
+	}
+	return ErrInvalidStatusChange
+}
+
+// SetStatus changes status and finalization time
+func (t *Tournament) SetStatus(to TournamentStatus, now time.Time) {
+	t.Status = to
+	t.FinalizedAt = nil
+	if to == TournamentFinalized {
+		t.FinalizedAt = &now
+	}
+}
@@ -0,0 +1,97 @@
+// internal/domain/tournament_test.go
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTournamentPhase(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	start := time.Date(2026, 3, 19, 19, 30, 0, 0, loc)
+	timed := func(status TournamentStatus) *Tournament {
+		tr := &Tournament{Status: status}
+		tr.SetStart(start, true)
+		return tr
+	}
+	allDay := &Tournament{Status: TournamentPlanned}
+	allDay.SetStart(start, false)
+
+	tests := []struct {
+		name       string
+		tournament *Tournament
+		now        time.Time
+		want       string
+	}{
+		{"planned before start", timed(TournamentPlanned), start.Add(-time.Minute), PhaseUpcoming},
+		{"planned during game", timed(TournamentPlanned), start.Add(time.Hour), PhaseOngoing},
+		{"planned after default duration", timed(TournamentPlanned), start.Add(3 * time.Hour), PhaseFinished},
+		{"in progress after end", timed(TournamentInProgress), start.Add(5 * time.Hour), PhaseOngoing},
+		{"finalized early", timed(TournamentFinalized), start.Add(time.Hour), PhaseFinished},
+		{"all-day during the day", allDay, start.Add(3 * time.Hour), PhaseOngoing},
+		{"all-day next day", allDay, start.Add(5 * time.Hour), PhaseFinished},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tournament.Phase(tt.now, loc); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTournamentCheckResults(t *testing.T) {
+	start := time.Date(2026, 3, 19, 19, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		status  TournamentStatus
+		now     time.Time
+		isAdmin bool
+		want    error
+	}{
+		{"planned before start", TournamentPlanned, start.Add(-time.Hour), false, ErrTournamentNotStarted},
+		{"planned after start", TournamentPlanned, start.Add(time.Hour), false, nil},
+		{"in progress", TournamentInProgress, start.Add(-time.Hour), false, nil},
+		{"finalized organizer", TournamentFinalized, start.Add(time.Hour), false, ErrTournamentFinalized},
+		{"finalized admin", TournamentFinalized, start.Add(time.Hour), true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &Tournament{Status: tt.status}
+			tr.SetStart(start, true)
+			if err := tr.CheckResults(tt.now, time.UTC, tt.isAdmin); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestTournamentCheckTransition(t *testing.T) {
+	tests := []struct {
+		from    TournamentStatus
+		to      TournamentStatus
+		isAdmin bool
+		want    error
+	}{
+		{TournamentPlanned, TournamentInProgress, false, nil},
+		{TournamentInProgress, TournamentPlanned, false, nil},
+		{TournamentInProgress, TournamentFinalized, false, nil},
+		{TournamentPlanned, TournamentFinalized, false, ErrInvalidStatusChange},
+		{TournamentFinalized, TournamentInProgress, false, ErrStatusChangeNeedsAdmin},
+		{TournamentFinalized, TournamentInProgress, true, nil},
+		{TournamentFinalized, TournamentPlanned, true, ErrInvalidStatusChange},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			tr := &Tournament{Status: tt.from}
+			if err := tr.CheckTransition(tt.to, tt.isAdmin); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
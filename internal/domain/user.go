@@ -2,11 +2,16 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/uptrace/bun"
 )
 
+// Role - name of a RoleDef; built-in roles below
 type Role string
 
 const (
@@ -18,16 +23,117 @@ const (
 type User struct {
 	bun.BaseModel `bun:"table:users"`
 
-	TelegramID int64     `bun:"telegram_id,pk"`
-	Username   string    `bun:"username"`
-	Role       Role      `bun:"role,default:'viewer'"`
-	CreatedAt  time.Time `bun:"created_at,default:current_timestamp"`
+	TelegramID int64      `bun:"telegram_id,pk"`
+	Username   string     `bun:"username"`
+	FirstName  string     `bun:"first_name"`
+	LastName   string     `bun:"last_name"`
+	Role       Role       `bun:"role,default:'viewer'"`
+	CreatedAt  time.Time  `bun:"created_at,default:current_timestamp"`
+	LastSeenAt *time.Time `bun:"last_seen_at"`
+
+	// Blocking: set by moderators, BlockedUntil nil - until unblocked
+	BlockedAt     *time.Time `bun:"blocked_at"`
+	BlockedUntil  *time.Time `bun:"blocked_until"`
+	BlockedReason string     `bun:"blocked_reason"`
+	BlockedBy     *int64     `bun:"blocked_by"`
+
+	// Permissions of the role, loaded on authentication
+	Permissions []Permission `bun:"-"`
+}
+
+// Can reports whether user's role grants the permission
+func (u *User) Can(p Permission) bool {
+	if u.Role == RoleAdmin {
+		return true
+	}
+	for _, granted := range u.Permissions {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAll reports whether user's role grants every permission in perms
+func (u *User) CanAll(perms []Permission) bool {
+	for _, p := range perms {
+		if !u.Can(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsBlocked reports whether user is blocked at now
+func (u *User) IsBlocked(now time.Time) bool {
+	if u.BlockedAt == nil {
+		return false
+	}
+	return u.BlockedUntil == nil || now.Before(*u.BlockedUntil)
 }
 
-func (u *User) CanManage() bool {
-	return u.Role == RoleOrganizer || u.Role == RoleAdmin
+// DisplayName returns @username, else first and last name, else the ID
+func (u *User) DisplayName() string {
+	if u.Username != "" {
+		return "@" + u.Username
+	}
+	if name := strings.TrimSpace(u.FirstName + " " + u.LastName); name != "" {
+		return name
+	}
+	return fmt.Sprintf("ID: %d", u.TelegramID)
 }
 
 func (u *User) IsAdmin() bool {
 	return u.Role == RoleAdmin
 }
+
+var (
+	// ErrLastAdmin - the change would leave no unblocked admins
+	ErrLastAdmin = errors.New("last admin")
+	// ErrOwnRole - users can't change their own role
+	ErrOwnRole = errors.New("own role")
+	// ErrAdminRequired - only admins grant or revoke admin
+	ErrAdminRequired = errors.New("admin required")
+	// ErrRoleLocked - role is pinned by ADMIN_IDS_LOCKED
+	ErrRoleLocked = errors.New("role locked")
+	// ErrConfirmationRequired - promotion to admin must be confirmed
+	ErrConfirmationRequired = errors.New("confirmation required")
+	// ErrPermissionsExceeded - a role carries permissions the actor doesn't have
+	ErrPermissionsExceeded = errors.New("permissions exceeded")
+)
+
+// RoleChange - actor sets target's role. The last-admin rule needs
+// the database and is enforced by UserRepository.UpdateRole.
+type RoleChange struct {
+	Actor        *User
+	Target       *User
+	Role         Role
+	Grants       []Permission // permissions of Role
+	TargetGrants []Permission // permissions of Target's current role
+	Locked       []int64      // ADMIN_IDS when they are locked
+	Confirmed    bool
+}
+
+// Check applies role governance rules
+func (rc RoleChange) Check() error {
+	if rc.Actor.TelegramID == rc.Target.TelegramID {
+		return ErrOwnRole
+	}
+	if rc.Role == rc.Target.Role {
+		return nil
+	}
+	if slices.Contains(rc.Locked, rc.Target.TelegramID) {
+		return ErrRoleLocked
+	}
+	if (rc.Role == RoleAdmin || rc.Target.IsAdmin()) && !rc.Actor.IsAdmin() {
+		return ErrAdminRequired
+	}
+	// Nobody hands out or takes away more than they hold themselves
+	if !rc.Actor.CanAll(rc.Grants) || !rc.Actor.CanAll(rc.TargetGrants) {
+		return ErrPermissionsExceeded
+	}
+	if rc.Role == RoleAdmin && !rc.Confirmed {
+		return ErrConfirmationRequired
+	}
+	return nil
+}
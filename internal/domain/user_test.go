@@ -0,0 +1,93 @@
+// internal/domain/user_test.go
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUserIsBlocked(t *testing.T) {
+	now := time.Date(2026, 3, 19, 12, 0, 0, 0, time.UTC)
+	blockedAt := now.Add(-24 * time.Hour)
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name string
+		user *User
+		want bool
+	}{
+		{"not blocked", &User{}, false},
+		{"until unblocked", &User{BlockedAt: &blockedAt}, true},
+		{"until later", &User{BlockedAt: &blockedAt, BlockedUntil: &later}, true},
+		{"expired", &User{BlockedAt: &blockedAt, BlockedUntil: &earlier}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.IsBlocked(now); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUserDisplayName(t *testing.T) {
+	tests := []struct {
+		name string
+		user *User
+		want string
+	}{
+		{"username", &User{TelegramID: 1, Username: "amber", FirstName: "Anna"}, "@amber"},
+		{"full name", &User{TelegramID: 1, FirstName: "Anna", LastName: "Ivanova"}, "Anna Ivanova"},
+		{"first name only", &User{TelegramID: 1, FirstName: "Anna"}, "Anna"},
+		{"no profile", &User{TelegramID: 42}, "ID: 42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.DisplayName(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRoleChangeCheck(t *testing.T) {
+	admin := &User{TelegramID: 1, Role: RoleAdmin}
+	moderator := &User{TelegramID: 2, Role: "moderator", Permissions: []Permission{PermUsersManage, PermResultsRecord}}
+	viewer := &User{TelegramID: 3, Role: RoleViewer}
+	otherAdmin := &User{TelegramID: 4, Role: RoleAdmin}
+	manager := &User{TelegramID: 5, Role: "manager"}
+	organizer := []Permission{PermTeamsManage, PermTournamentsManage, PermResultsRecord}
+	settings := []Permission{PermUsersManage, PermSettingsManage}
+
+	tests := []struct {
+		name   string
+		change RoleChange
+		want   error
+	}{
+		{"own role", RoleChange{Actor: admin, Target: admin, Role: RoleViewer}, ErrOwnRole},
+		{"regular role", RoleChange{Actor: moderator, Target: viewer, Role: RoleOrganizer}, nil},
+		{"same role", RoleChange{Actor: moderator, Target: otherAdmin, Role: RoleAdmin}, nil},
+		{"promote unconfirmed", RoleChange{Actor: admin, Target: viewer, Role: RoleAdmin}, ErrConfirmationRequired},
+		{"promote confirmed", RoleChange{Actor: admin, Target: viewer, Role: RoleAdmin, Confirmed: true}, nil},
+		{"promote by non-admin", RoleChange{Actor: moderator, Target: viewer, Role: RoleAdmin, Confirmed: true}, ErrAdminRequired},
+		{"demote by non-admin", RoleChange{Actor: moderator, Target: otherAdmin, Role: RoleViewer}, ErrAdminRequired},
+		{"demote by admin", RoleChange{Actor: admin, Target: otherAdmin, Role: RoleViewer}, nil},
+		{"locked", RoleChange{Actor: admin, Target: otherAdmin, Role: RoleViewer, Locked: []int64{4}}, ErrRoleLocked},
+		{"grant held permissions", RoleChange{Actor: moderator, Target: viewer, Role: "scorekeeper", Grants: []Permission{PermResultsRecord}}, nil},
+		{"grant more than held", RoleChange{Actor: moderator, Target: viewer, Role: RoleOrganizer, Grants: organizer}, ErrPermissionsExceeded},
+		{"grant manage permissions", RoleChange{Actor: moderator, Target: viewer, Role: "manager", Grants: settings}, ErrPermissionsExceeded},
+		{"demote stronger role", RoleChange{Actor: moderator, Target: manager, Role: RoleViewer, TargetGrants: settings}, ErrPermissionsExceeded},
+		{"admin grants any role", RoleChange{Actor: admin, Target: viewer, Role: "manager", Grants: settings}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.change.Check(); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
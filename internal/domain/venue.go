@@ -0,0 +1,34 @@
+// internal/domain/venue.go
+package domain
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Venue - place where tournaments are held
+type Venue struct {
+	bun.BaseModel `bun:"table:venues"`
+
+	ID        int64    `bun:"id,pk,autoincrement"`
+	Name      string   `bun:"name,notnull"`
+	Address   string   `bun:"address,notnull,default:''"`
+	Latitude  *float64 `bun:"latitude"`
+	Longitude *float64 `bun:"longitude"`
+	Notes     string   `bun:"notes,notnull,default:''"`
+
+	CreatedAt time.Time `bun:"created_at,default:current_timestamp"`
+	CreatedBy int64     `bun:"created_by"`
+
+	// Metadata for updates
+	UpdatedAt *time.Time `bun:"updated_at"`
+	UpdatedBy *int64     `bun:"updated_by"`
+
+	// Soft delete
+	DeletedAt *time.Time `bun:"deleted_at,soft_delete"`
+	DeletedBy *int64     `bun:"deleted_by"`
+
+	// Optimistic locking
+	Version int `bun:"version,default:1"`
+}
@@ -0,0 +1,82 @@
+// internal/domain/webhook.go
+package domain
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Webhook job statuses
+const (
+	WebhookJobPending   = "pending"
+	WebhookJobDelivered = "delivered"
+	WebhookJobFailed    = "failed"
+)
+
+// Webhook is an external URL notified about events
+type Webhook struct {
+	bun.BaseModel `bun:"table:webhooks"`
+
+	ID        int64     `bun:"id,pk,autoincrement"`
+	URL       string    `bun:"url,notnull"`
+	Secret    string    `bun:"secret,notnull"`
+	Events    []string  `bun:"events,array"`
+	Active    bool      `bun:"active,notnull"`
+	CreatedBy int64     `bun:"created_by"`
+	CreatedAt time.Time `bun:"created_at,default:current_timestamp"`
+
+	// Metadata for updates
+	UpdatedAt *time.Time `bun:"updated_at"`
+	UpdatedBy *int64     `bun:"updated_by"`
+
+	// Soft delete
+	DeletedAt *time.Time `bun:"deleted_at,soft_delete"`
+	DeletedBy *int64     `bun:"deleted_by"`
+
+	// Optimistic locking
+	Version int `bun:"version,default:1"`
+}
+
+// Subscribed reports whether webhook wants the event
+func (w *Webhook) Subscribed(event string) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookJob is an outbox row: one event to deliver to one webhook
+type WebhookJob struct {
+	bun.BaseModel `bun:"table:webhook_outbox,alias:job"`
+
+	ID            int64          `bun:"id,pk,autoincrement"`
+	WebhookID     int64          `bun:"webhook_id,notnull"`
+	Event         string         `bun:"event,notnull"`
+	Payload       map[string]any `bun:"payload,type:jsonb"`
+	Status        string         `bun:"status,notnull"`
+	Attempts      int            `bun:"attempts,notnull"`
+	NextAttemptAt time.Time      `bun:"next_attempt_at,notnull"`
+	LastError     *string        `bun:"last_error"`
+	CreatedAt     time.Time      `bun:"created_at,default:current_timestamp"`
+	DeliveredAt   *time.Time     `bun:"delivered_at"`
+
+	// Relations
+	Webhook *Webhook `bun:"rel:belongs-to,join:webhook_id=id"`
+}
+
+// WebhookDelivery is one delivery attempt
+type WebhookDelivery struct {
+	bun.BaseModel `bun:"table:webhook_deliveries"`
+
+	ID         int64     `bun:"id,pk,autoincrement"`
+	JobID      int64     `bun:"job_id,notnull"`
+	WebhookID  int64     `bun:"webhook_id,notnull"`
+	Attempt    int       `bun:"attempt,notnull"`
+	StatusCode *int      `bun:"status_code"`
+	Error      *string   `bun:"error"`
+	DurationMs int64     `bun:"duration_ms,notnull"`
+	CreatedAt  time.Time `bun:"created_at,default:current_timestamp"`
+}
@@ -0,0 +1,89 @@
+// internal/events/dispatcher.go
+package events
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+const (
+	pollInterval    = 1 * time.Second
+	batchSize       = 100
+	cleanupInterval = 1 * time.Hour
+
+	// Retention - events older than this are neither dispatched nor kept
+	Retention = 7 * 24 * time.Hour
+)
+
+// Handler reacts to a domain event. It runs outside any transaction and may
+// be repeated, so it must be idempotent. A failed event is retried with
+// backoff while later events go on.
+type Handler func(ctx context.Context, e *domain.Event) error
+
+// Subscriber is a named consumer of domain events. Progress is tracked per
+// name, so a subscriber registered in several processes handles each event once.
+// A new name starts with events recorded after its first run.
+type Subscriber struct {
+	Name   string
+	Types  []string // empty - all events
+	Handle Handler
+}
+
+// Dispatcher polls the outbox and feeds events to subscribers
+type Dispatcher struct {
+	repo repository.EventRepository
+	subs []Subscriber
+}
+
+func NewDispatcher(repo repository.EventRepository, subs ...Subscriber) *Dispatcher {
+	return &Dispatcher{repo: repo, subs: subs}
+}
+
+// Subscribe adds subscribers
+func (d *Dispatcher) Subscribe(subs ...Subscriber) {
+	d.subs = append(d.subs, subs...)
+}
+
+// Run dispatches events until ctx is done
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	lastCleanup := time.Time{}
+	for {
+		d.DispatchPending(ctx)
+
+		if time.Since(lastCleanup) > cleanupInterval {
+			if err := d.repo.DeleteBefore(ctx, time.Now().Add(-Retention)); err != nil && ctx.Err() == nil {
+				log.Printf("ERROR: failed to clean up domain events: %v", err)
+			}
+			lastCleanup = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending runs every subscriber once over pending events
+func (d *Dispatcher) DispatchPending(ctx context.Context) {
+	since := time.Now().Add(-Retention)
+	for _, s := range d.subs {
+		for {
+			n, err := d.repo.Process(ctx, s.Name, s.Types, batchSize, since, s.Handle)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("ERROR: event subscriber %s: %v", s.Name, err)
+			}
+			if n < batchSize {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// internal/events/subscribers.go
+package events
+
+import (
+	"context"
+
+	"github.com/eugene-twix/amber-bot/internal/achievements"
+	"github.com/eugene-twix/amber-bot/internal/cache"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/live"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+	"github.com/eugene-twix/amber-bot/internal/webhooks"
+)
+
+// Default returns subscribers shared by cmd/api and cmd/bot
+func Default(c *cache.Cache, webhookRepo repository.WebhookRepository, badgeRepo repository.BadgeRepository) []Subscriber {
+	return []Subscriber{
+		LivePublisher(c),
+		WebhookOutbox(webhookRepo),
+		Achievements(achievements.NewEngine(badgeRepo)),
+	}
+}
+
+// LivePublisher pushes tournament changes to live SSE subscribers
+func LivePublisher(c *cache.Cache) Subscriber {
+	return Subscriber{
+		Name: "live",
+		Types: []string{
+			domain.EventTournamentUpdated,
+			domain.EventTournamentDeleted,
+			domain.EventResultCreated,
+			domain.EventResultUpdated,
+			domain.EventResultDeleted,
+		},
+		Handle: func(ctx context.Context, e *domain.Event) error {
+			return live.Publish(ctx, c, live.FromDomainEvent(e))
+		},
+	}
+}
+
+// WebhookOutbox queues webhook deliveries for events webhooks can subscribe to
+func WebhookOutbox(repo repository.WebhookRepository) Subscriber {
+	return Subscriber{
+		Name:  "webhooks",
+		Types: webhooks.Events,
+		Handle: func(ctx context.Context, e *domain.Event) error {
+			return webhooks.Emit(ctx, repo, e.Type, e.Payload)
+		},
+	}
+}
+
+// Achievements evaluates badge rules for teams whose results changed
+func Achievements(engine *achievements.Engine) Subscriber {
+	return Subscriber{
+		Name: "achievements",
+		Types: []string{
+			domain.EventResultCreated,
+			domain.EventResultUpdated,
+			domain.EventTeamMerged,
+		},
+		Handle: func(ctx context.Context, e *domain.Event) error {
+			teamID := e.Int64("team_id")
+			if e.Type == domain.EventTeamMerged {
+				teamID = e.Int64("target_team_id")
+			}
+			if teamID == 0 {
+				return nil // player result, badges are per team
+			}
+			_, err := engine.EvaluateTeam(ctx, teamID)
+			return err
+		},
+	}
+}
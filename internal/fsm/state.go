@@ -17,13 +17,16 @@ const (
 	StateAddMemberName State = "add_member:name"
 
 	// NewTournament flow
-	StateNewTournamentName     State = "new_tournament:name"
-	StateNewTournamentDate     State = "new_tournament:date"
-	StateNewTournamentLocation State = "new_tournament:location"
+	StateNewTournamentName       State = "new_tournament:name"
+	StateNewTournamentDiscipline State = "new_tournament:discipline" // если видов игр больше одного
+	StateNewTournamentMode       State = "new_tournament:mode"       // командный или личный зачёт
+	StateNewTournamentDate       State = "new_tournament:date"
+	StateNewTournamentLocation   State = "new_tournament:location"
 
 	// Result flow
 	StateResultTournament State = "result:tournament"
 	StateResultTeam       State = "result:team"
+	StateResultPlayer     State = "result:player" // личный зачёт
 	StateResultPlace      State = "result:place"
 
 	// Grant flow
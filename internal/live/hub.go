@@ -0,0 +1,104 @@
+// internal/live/hub.go
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/cache"
+)
+
+const (
+	// subscriberBuffer - events a slow client may lag behind before it is dropped
+	subscriberBuffer = 16
+	resubscribeDelay = 5 * time.Second
+)
+
+// Hub keeps one Dragonfly subscription per API instance and fans events
+// out to local subscribers (SSE connections)
+type Hub struct {
+	cache *cache.Cache
+
+	mu   sync.Mutex
+	subs map[int64]map[chan Event]struct{}
+}
+
+func NewHub(c *cache.Cache) *Hub {
+	return &Hub{
+		cache: c,
+		subs:  make(map[int64]map[chan Event]struct{}),
+	}
+}
+
+// Run receives events from Dragonfly until ctx is done
+func (h *Hub) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		messages, err := h.cache.PSubscribe(ctx, channelPrefix+"*")
+		if err != nil {
+			log.Printf("ERROR: live subscribe failed: %v", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(resubscribeDelay):
+			}
+			continue
+		}
+
+		for msg := range messages {
+			var e Event
+			if err := json.Unmarshal(msg.Payload, &e); err != nil {
+				continue
+			}
+			h.dispatch(e)
+		}
+	}
+}
+
+// Subscribe returns channel with events of a tournament and a function to
+// unsubscribe. The channel is closed if the subscriber falls behind; the
+// client should reconnect and reload standings.
+func (h *Hub) Subscribe(tournamentID int64) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subs[tournamentID] == nil {
+		h.subs[tournamentID] = make(map[chan Event]struct{})
+	}
+	h.subs[tournamentID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() { h.remove(tournamentID, ch) }
+}
+
+func (h *Hub) dispatch(e Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subs[e.TournamentID] {
+		select {
+		case ch <- e:
+		default:
+			h.removeLocked(e.TournamentID, ch)
+		}
+	}
+}
+
+func (h *Hub) remove(tournamentID int64, ch chan Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(tournamentID, ch)
+}
+
+func (h *Hub) removeLocked(tournamentID int64, ch chan Event) {
+	subs := h.subs[tournamentID]
+	if _, ok := subs[ch]; !ok {
+		return
+	}
+	delete(subs, ch)
+	close(ch)
+	if len(subs) == 0 {
+		delete(h.subs, tournamentID)
+	}
+}
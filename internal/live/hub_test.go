@@ -0,0 +1,57 @@
+// internal/live/hub_test.go
+package live
+
+import "testing"
+
+func TestHubDispatch(t *testing.T) {
+	h := NewHub(nil)
+
+	events, unsubscribe := h.Subscribe(1)
+	other, unsubscribeOther := h.Subscribe(2)
+	defer unsubscribeOther()
+
+	h.dispatch(Event{Type: EventResultCreated, TournamentID: 1, Place: 3})
+
+	select {
+	case e := <-events:
+		if e.Place != 3 {
+			t.Errorf("expected place 3, got %d", e.Place)
+		}
+	default:
+		t.Fatal("expected event for tournament 1")
+	}
+
+	select {
+	case e := <-other:
+		t.Fatalf("unexpected event for tournament 2: %+v", e)
+	default:
+	}
+
+	unsubscribe()
+	unsubscribe() // must be safe to call twice
+	if _, ok := <-events; ok {
+		t.Error("expected channel to be closed after unsubscribe")
+	}
+}
+
+func TestHubDropsSlowSubscriber(t *testing.T) {
+	h := NewHub(nil)
+
+	events, unsubscribe := h.Subscribe(1)
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBuffer+1; i++ {
+		h.dispatch(Event{Type: EventResultUpdated, TournamentID: 1})
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Errorf("expected %d buffered events, got %d", subscriberBuffer, received)
+	}
+	if len(h.subs) != 0 {
+		t.Errorf("expected slow subscriber to be removed, got %d tournaments", len(h.subs))
+	}
+}
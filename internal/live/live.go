@@ -0,0 +1,61 @@
+// internal/live/live.go
+package live
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/cache"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+)
+
+// Event types
+const (
+	EventResultCreated     = "result.created"
+	EventResultUpdated     = "result.updated"
+	EventResultDeleted     = "result.deleted" // places below the deleted one moved up by one
+	EventTournamentUpdated = "tournament.updated"
+	EventTournamentDeleted = "tournament.deleted"
+)
+
+const channelPrefix = "live:tournament:"
+
+// Event is a change in a tournament pushed to live subscribers
+type Event struct {
+	Type         string    `json:"type"`
+	TournamentID int64     `json:"tournament_id"`
+	ResultID     int64     `json:"result_id,omitempty"`
+	TeamID       int64     `json:"team_id,omitempty"`
+	PlayerID     int64     `json:"player_id,omitempty"`
+	Place        int       `json:"place,omitempty"`
+	Version      int       `json:"version,omitempty"`
+	At           time.Time `json:"at"`
+}
+
+// FromDomainEvent builds live event from a result or tournament domain event
+func FromDomainEvent(e *domain.Event) Event {
+	if e.AggregateType == domain.EntityTournament {
+		return Event{Type: e.Type, TournamentID: e.AggregateID, At: e.OccurredAt}
+	}
+	return Event{
+		Type:         e.Type,
+		TournamentID: e.Int64("tournament_id"),
+		ResultID:     e.AggregateID,
+		TeamID:       e.Int64("team_id"),
+		PlayerID:     e.Int64("player_id"),
+		Place:        int(e.Int64("place")),
+		Version:      int(e.Int64("version")),
+		At:           e.OccurredAt,
+	}
+}
+
+// Channel returns pub/sub channel of a tournament
+func Channel(tournamentID int64) string {
+	return fmt.Sprintf("%s%d", channelPrefix, tournamentID)
+}
+
+// Publish sends event to all API instances via Dragonfly pub/sub
+func Publish(ctx context.Context, c *cache.Cache, e Event) error {
+	return c.Publish(ctx, Channel(e.TournamentID), e)
+}
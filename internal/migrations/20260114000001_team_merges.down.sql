@@ -0,0 +1,18 @@
+-- Rollback: team merges, aliases and audit log
+DROP TABLE IF EXISTS audit_log;
+DROP TABLE IF EXISTS team_aliases;
+DROP TABLE IF EXISTS team_merges;
+
+-- Restore UNIQUE(tournament_id, team_id) over all rows
+DROP INDEX IF EXISTS idx_results_tournament_team_not_deleted;
+DO $$ BEGIN
+    IF NOT EXISTS (
+        SELECT 1
+        FROM pg_constraint
+        WHERE conrelid = 'results'::regclass
+          AND conname = 'results_tournament_id_team_id_key'
+    ) THEN
+        ALTER TABLE results
+            ADD CONSTRAINT results_tournament_id_team_id_key UNIQUE (tournament_id, team_id);
+    END IF;
+END $$;
@@ -0,0 +1,61 @@
+-- Migration: team merges, aliases and audit log
+
+-- =====================
+-- RESULTS: unique only among live rows
+-- =====================
+-- Soft-deleted results must not block moving a result to another team
+ALTER TABLE results DROP CONSTRAINT IF EXISTS results_tournament_id_team_id_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_results_tournament_team_not_deleted
+    ON results(tournament_id, team_id) WHERE deleted_at IS NULL;
+
+-- =====================
+-- TEAM MERGES
+-- =====================
+CREATE TABLE IF NOT EXISTS team_merges (
+    id BIGSERIAL PRIMARY KEY,
+    source_team_id BIGINT NOT NULL REFERENCES teams(id),
+    target_team_id BIGINT NOT NULL REFERENCES teams(id),
+    source_name VARCHAR(255) NOT NULL,
+    alias_id BIGINT,
+    moved_member_ids BIGINT[] NOT NULL DEFAULT '{}',
+    moved_result_ids BIGINT[] NOT NULL DEFAULT '{}',
+    moved_alias_ids BIGINT[] NOT NULL DEFAULT '{}',
+    conflicts JSONB NOT NULL DEFAULT '[]',
+    merged_by BIGINT NOT NULL,
+    merged_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reverted_at TIMESTAMPTZ,
+    reverted_by BIGINT
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_merges_source ON team_merges(source_team_id);
+CREATE INDEX IF NOT EXISTS idx_team_merges_target ON team_merges(target_team_id);
+
+-- =====================
+-- TEAM ALIASES
+-- =====================
+CREATE TABLE IF NOT EXISTS team_aliases (
+    id BIGSERIAL PRIMARY KEY,
+    team_id BIGINT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    merge_id BIGINT REFERENCES team_merges(id) ON DELETE SET NULL,
+    created_by BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_aliases_team_id ON team_aliases(team_id);
+
+-- =====================
+-- AUDIT LOG
+-- =====================
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id BIGINT NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    entity_type VARCHAR(64) NOT NULL,
+    entity_id BIGINT NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(entity_type, entity_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at DESC);
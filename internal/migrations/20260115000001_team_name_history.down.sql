@@ -0,0 +1,20 @@
+-- Rollback: team name history
+DELETE FROM team_aliases WHERE kind = 'rename';
+
+DROP INDEX IF EXISTS idx_team_aliases_team_kind;
+DROP INDEX IF EXISTS idx_team_aliases_name;
+
+ALTER TABLE team_aliases DROP COLUMN IF EXISTS valid_to;
+ALTER TABLE team_aliases DROP COLUMN IF EXISTS valid_from;
+ALTER TABLE team_aliases DROP COLUMN IF EXISTS kind;
+
+DO $$ BEGIN
+    IF NOT EXISTS (
+        SELECT 1
+        FROM pg_constraint
+        WHERE conrelid = 'team_aliases'::regclass
+          AND conname = 'team_aliases_name_key'
+    ) THEN
+        ALTER TABLE team_aliases ADD CONSTRAINT team_aliases_name_key UNIQUE (name);
+    END IF;
+END $$;
@@ -0,0 +1,11 @@
+-- Migration: team name history
+-- team_aliases now stores both merge aliases and previous names (renames)
+-- with the period each name was in use: [valid_from, valid_to)
+ALTER TABLE team_aliases ADD COLUMN IF NOT EXISTS kind VARCHAR(16) NOT NULL DEFAULT 'merge';
+ALTER TABLE team_aliases ADD COLUMN IF NOT EXISTS valid_from TIMESTAMPTZ;
+ALTER TABLE team_aliases ADD COLUMN IF NOT EXISTS valid_to TIMESTAMPTZ;
+
+-- A team can return to an old name, and two teams can use the same name at different times
+ALTER TABLE team_aliases DROP CONSTRAINT IF EXISTS team_aliases_name_key;
+CREATE INDEX IF NOT EXISTS idx_team_aliases_name ON team_aliases(name);
+CREATE INDEX IF NOT EXISTS idx_team_aliases_team_kind ON team_aliases(team_id, kind, valid_to);
@@ -0,0 +1,2 @@
+-- Rollback: badges
+DROP TABLE IF EXISTS badges;
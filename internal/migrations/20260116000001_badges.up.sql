@@ -0,0 +1,15 @@
+-- Migration: achievements / badges
+-- Team badges have member_id NULL; member copies are written when a team earns a badge
+CREATE TABLE IF NOT EXISTS badges (
+    id BIGSERIAL PRIMARY KEY,
+    team_id BIGINT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    member_id BIGINT REFERENCES members(id) ON DELETE CASCADE,
+    code VARCHAR(64) NOT NULL,
+    result_id BIGINT REFERENCES results(id) ON DELETE SET NULL,
+    awarded_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    announced_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_badges_team_code ON badges(team_id, code) WHERE member_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_badges_member_code ON badges(member_id, code) WHERE member_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_badges_unannounced ON badges(awarded_at) WHERE announced_at IS NULL;
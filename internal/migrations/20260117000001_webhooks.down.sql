@@ -0,0 +1,4 @@
+-- Rollback: outgoing webhooks
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_outbox;
+DROP TABLE IF EXISTS webhooks;
@@ -0,0 +1,3 @@
+-- Rollback: domain events outbox
+DROP TABLE IF EXISTS event_receipts;
+DROP TABLE IF EXISTS domain_events;
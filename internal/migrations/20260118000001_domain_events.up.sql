@@ -0,0 +1,21 @@
+-- Migration: domain events outbox
+-- Events are written in the same transaction as the change they describe
+CREATE TABLE IF NOT EXISTS domain_events (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(64) NOT NULL,
+    aggregate_type VARCHAR(32) NOT NULL,
+    aggregate_id BIGINT NOT NULL,
+    actor_id BIGINT,
+    payload JSONB NOT NULL DEFAULT '{}',
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_domain_events_occurred ON domain_events(occurred_at);
+
+-- Which subscriber has handled which event
+CREATE TABLE IF NOT EXISTS event_receipts (
+    event_id BIGINT NOT NULL REFERENCES domain_events(id) ON DELETE CASCADE,
+    subscriber VARCHAR(64) NOT NULL,
+    handled_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (event_id, subscriber)
+);
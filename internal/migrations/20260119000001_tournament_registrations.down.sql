@@ -0,0 +1,2 @@
+-- Rollback: tournament registrations
+DROP TABLE IF EXISTS tournament_registrations;
@@ -0,0 +1,10 @@
+-- Migration: teams registered for upcoming tournaments (team calendar feeds)
+CREATE TABLE IF NOT EXISTS tournament_registrations (
+    tournament_id BIGINT NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE,
+    team_id BIGINT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    registered_by BIGINT NOT NULL,
+    registered_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tournament_id, team_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tournament_registrations_team ON tournament_registrations(team_id);
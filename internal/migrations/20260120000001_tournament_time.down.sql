@@ -0,0 +1,5 @@
+-- Rollback: tournament time
+DROP INDEX IF EXISTS idx_tournaments_starts_at;
+ALTER TABLE tournaments DROP COLUMN IF EXISTS timezone;
+ALTER TABLE tournaments DROP COLUMN IF EXISTS duration_minutes;
+ALTER TABLE tournaments DROP COLUMN IF EXISTS starts_at;
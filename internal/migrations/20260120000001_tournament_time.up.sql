@@ -0,0 +1,7 @@
+-- Migration: tournament start time, duration and timezone
+-- starts_at NULL keeps a date-only (all-day) tournament; date stays the local day of starts_at
+ALTER TABLE tournaments ADD COLUMN IF NOT EXISTS starts_at TIMESTAMPTZ;
+ALTER TABLE tournaments ADD COLUMN IF NOT EXISTS duration_minutes INT NOT NULL DEFAULT 0;
+ALTER TABLE tournaments ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_tournaments_starts_at ON tournaments(starts_at) WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+-- Rollback: tournament status
+DROP INDEX IF EXISTS idx_tournaments_status;
+ALTER TABLE tournaments DROP COLUMN IF EXISTS finalized_at;
+ALTER TABLE tournaments DROP COLUMN IF EXISTS status;
@@ -0,0 +1,11 @@
+-- Migration: tournament lifecycle (planned -> in_progress -> finalized)
+ALTER TABLE tournaments ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'planned';
+ALTER TABLE tournaments ADD COLUMN IF NOT EXISTS finalized_at TIMESTAMPTZ;
+
+-- Past tournaments with results are done
+UPDATE tournaments t
+SET status = 'finalized', finalized_at = NOW()
+WHERE t.date < CURRENT_DATE
+  AND EXISTS (SELECT 1 FROM results r WHERE r.tournament_id = t.id AND r.deleted_at IS NULL);
+
+CREATE INDEX IF NOT EXISTS idx_tournaments_status ON tournaments(status) WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+-- Rollback: venues
+DROP INDEX IF EXISTS idx_tournaments_venue;
+ALTER TABLE tournaments DROP COLUMN IF EXISTS venue_id;
+DROP TABLE IF EXISTS venues;
@@ -0,0 +1,35 @@
+-- Migration: venues referenced by tournaments instead of free-text location
+CREATE TABLE IF NOT EXISTS venues (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    address VARCHAR(500) NOT NULL DEFAULT '',
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by BIGINT,
+    updated_at TIMESTAMPTZ,
+    updated_by BIGINT,
+    deleted_at TIMESTAMPTZ,
+    deleted_by BIGINT,
+    version INT NOT NULL DEFAULT 1
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_venues_name ON venues(LOWER(name)) WHERE deleted_at IS NULL;
+
+ALTER TABLE tournaments ADD COLUMN IF NOT EXISTS venue_id BIGINT REFERENCES venues(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_tournaments_venue ON tournaments(venue_id) WHERE deleted_at IS NULL;
+
+-- One venue per distinct location (case and surrounding spaces ignored)
+INSERT INTO venues (name)
+SELECT DISTINCT ON (LOWER(TRIM(location))) TRIM(location)
+FROM tournaments
+WHERE TRIM(COALESCE(location, '')) <> ''
+ORDER BY LOWER(TRIM(location)), id
+ON CONFLICT DO NOTHING;
+
+UPDATE tournaments t
+SET venue_id = v.id
+FROM venues v
+WHERE v.deleted_at IS NULL
+  AND LOWER(v.name) = LOWER(TRIM(t.location));
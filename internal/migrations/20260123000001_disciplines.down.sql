@@ -0,0 +1,4 @@
+-- Rollback: disciplines
+DROP INDEX IF EXISTS idx_tournaments_discipline;
+ALTER TABLE tournaments DROP COLUMN IF EXISTS discipline_id;
+DROP TABLE IF EXISTS disciplines;
@@ -0,0 +1,23 @@
+-- Migration: disciplines (game types), one per tournament
+CREATE TABLE IF NOT EXISTS disciplines (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by BIGINT,
+    updated_at TIMESTAMPTZ,
+    updated_by BIGINT,
+    deleted_at TIMESTAMPTZ,
+    deleted_by BIGINT,
+    version INT NOT NULL DEFAULT 1
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_disciplines_name ON disciplines(LOWER(name)) WHERE deleted_at IS NULL;
+
+-- Existing tournaments are quizzes; the first discipline is the default
+INSERT INTO disciplines (name) VALUES ('Квиз'), ('Настольные игры');
+
+ALTER TABLE tournaments ADD COLUMN IF NOT EXISTS discipline_id BIGINT REFERENCES disciplines(id);
+UPDATE tournaments SET discipline_id = (SELECT MIN(id) FROM disciplines) WHERE discipline_id IS NULL;
+ALTER TABLE tournaments ALTER COLUMN discipline_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tournaments_discipline ON tournaments(discipline_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,8 @@
+-- Rollback: individual tournaments (player results are removed)
+DROP INDEX IF EXISTS idx_results_tournament_player_not_deleted;
+ALTER TABLE results DROP CONSTRAINT IF EXISTS results_team_or_player;
+DELETE FROM results WHERE team_id IS NULL;
+ALTER TABLE results DROP COLUMN IF EXISTS player_id;
+ALTER TABLE results ALTER COLUMN team_id SET NOT NULL;
+ALTER TABLE tournaments DROP COLUMN IF EXISTS participant_mode;
+DROP TABLE IF EXISTS players;
@@ -0,0 +1,27 @@
+-- Migration: individual tournaments, results of players instead of teams
+CREATE TABLE IF NOT EXISTS players (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    member_id BIGINT REFERENCES members(id) ON DELETE SET NULL,
+    telegram_id BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by BIGINT,
+    updated_at TIMESTAMPTZ,
+    updated_by BIGINT,
+    deleted_at TIMESTAMPTZ,
+    deleted_by BIGINT,
+    version INT NOT NULL DEFAULT 1
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_players_member ON players(member_id) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_players_telegram ON players(telegram_id) WHERE deleted_at IS NULL;
+
+ALTER TABLE tournaments ADD COLUMN IF NOT EXISTS participant_mode VARCHAR(16) NOT NULL DEFAULT 'team';
+
+ALTER TABLE results ALTER COLUMN team_id DROP NOT NULL;
+ALTER TABLE results ADD COLUMN IF NOT EXISTS player_id BIGINT REFERENCES players(id);
+ALTER TABLE results ADD CONSTRAINT results_team_or_player
+    CHECK ((team_id IS NULL) <> (player_id IS NULL));
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_results_tournament_player_not_deleted
+    ON results(tournament_id, player_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+-- Rollback: API tokens
+DROP TABLE IF EXISTS api_tokens;
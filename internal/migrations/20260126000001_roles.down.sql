@@ -0,0 +1,8 @@
+-- Rollback: roles
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_fkey;
+UPDATE users SET role = 'viewer' WHERE role NOT IN ('viewer', 'organizer', 'admin');
+CREATE TYPE user_role AS ENUM ('viewer', 'organizer', 'admin');
+ALTER TABLE users ALTER COLUMN role DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN role TYPE user_role USING role::user_role;
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'viewer';
+DROP TABLE IF EXISTS roles;
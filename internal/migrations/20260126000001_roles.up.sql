@@ -0,0 +1,29 @@
+-- Migration: roles stored in the database with permissions; users.role references roles.name
+CREATE TABLE IF NOT EXISTS roles (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    builtin BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by BIGINT,
+    updated_at TIMESTAMPTZ,
+    updated_by BIGINT,
+    version INT NOT NULL DEFAULT 1
+);
+
+-- Built-in roles keep the previous rights; admin implicitly has every permission
+INSERT INTO roles (name, description, permissions, builtin) VALUES
+    ('viewer', 'Просмотр', '{}', TRUE),
+    ('organizer', 'Организатор', '{teams.manage,tournaments.manage,results.record}', TRUE),
+    ('admin', 'Администратор', '{teams.manage,teams.merge,tournaments.manage,results.record,users.manage,roles.manage,settings.manage}', TRUE),
+    ('scorekeeper', 'Ведущий протокола: только результаты', '{results.record}', FALSE),
+    ('host', 'Организатор турниров: только турниры', '{tournaments.manage}', FALSE),
+    ('moderator', 'Модератор: объединение команд', '{teams.merge}', FALSE)
+ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE users ALTER COLUMN role DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN role TYPE VARCHAR(50) USING role::text;
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'viewer';
+ALTER TABLE users ADD CONSTRAINT users_role_fkey FOREIGN KEY (role) REFERENCES roles(name) ON UPDATE CASCADE;
+DROP TYPE IF EXISTS user_role;
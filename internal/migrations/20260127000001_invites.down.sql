@@ -0,0 +1,2 @@
+-- Rollback: invites
+DROP TABLE IF EXISTS invites;
@@ -0,0 +1,5 @@
+-- Rollback: user blocking
+ALTER TABLE users DROP COLUMN IF EXISTS blocked_by;
+ALTER TABLE users DROP COLUMN IF EXISTS blocked_reason;
+ALTER TABLE users DROP COLUMN IF EXISTS blocked_until;
+ALTER TABLE users DROP COLUMN IF EXISTS blocked_at;
@@ -0,0 +1,2 @@
+-- Rollback: failed domain events
+DROP TABLE IF EXISTS event_failures;
@@ -0,0 +1,12 @@
+-- Migration: failed domain events are set aside per subscriber
+-- A failing event no longer blocks the subscriber: it is retried with
+-- backoff and parked after the last attempt
+CREATE TABLE IF NOT EXISTS event_failures (
+    event_id BIGINT NOT NULL REFERENCES domain_events(id) ON DELETE CASCADE,
+    subscriber VARCHAR(64) NOT NULL,
+    attempts INT NOT NULL DEFAULT 1,
+    last_error TEXT NOT NULL DEFAULT '',
+    failed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    retry_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (event_id, subscriber)
+);
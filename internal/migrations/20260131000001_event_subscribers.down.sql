@@ -0,0 +1,2 @@
+-- Rollback: event subscriber start
+DROP TABLE IF EXISTS event_subscribers;
@@ -0,0 +1,14 @@
+-- Migration: where each event subscriber starts
+-- A new subscriber starts at the newest event instead of replaying the
+-- retention window; subscribers that already handled events keep going
+CREATE TABLE IF NOT EXISTS event_subscribers (
+    name VARCHAR(64) PRIMARY KEY,
+    start_event_id BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO event_subscribers (name, start_event_id)
+SELECT subscriber, 0 FROM event_receipts
+UNION
+SELECT subscriber, 0 FROM event_failures
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,267 @@
+// internal/openapi/schema.go
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Generator derives schemas from Go types the way encoding/json and gin
+// binding see them. Named structs become component schemas.
+//
+// Struct tags besides json, form and binding:
+//
+//	format:"date-time"    // format of a string field
+//	enum:"a,b"            // allowed values of a string field
+type Generator struct {
+	schemas map[string]*Schema
+	types   map[string]reflect.Type
+}
+
+func NewGenerator() *Generator {
+	return &Generator{
+		schemas: make(map[string]*Schema),
+		types:   make(map[string]reflect.Type),
+	}
+}
+
+// Schemas returns component schemas registered so far
+func (g *Generator) Schemas() map[string]*Schema {
+	return g.schemas
+}
+
+// Response returns the schema of a response body: every field without
+// omitempty is always present
+func (g *Generator) Response(v any) *Schema {
+	return g.schema(reflect.TypeOf(v), false)
+}
+
+// Request returns the schema of a JSON request body: fields with
+// binding:"required" are required
+func (g *Generator) Request(v any) *Schema {
+	return g.schema(reflect.TypeOf(v), true)
+}
+
+// Query returns query parameters of a struct with form tags
+func (g *Generator) Query(v any) []Parameter {
+	var params []Parameter
+	g.queryFields(reflect.TypeOf(v), &params)
+	return params
+}
+
+func (g *Generator) queryFields(t reflect.Type, params *[]Parameter) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			g.queryFields(f.Type, params)
+			continue
+		}
+		tag, ok := f.Tag.Lookup("form")
+		if !ok || !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		p := g.schema(f.Type, true)
+		applyTags(p, f.Tag)
+		if def, ok := strings.CutPrefix(opts, "default="); ok {
+			p.Default = def
+			if n, err := strconv.Atoi(def); err == nil && p.Type == "integer" {
+				p.Default = n
+			}
+		}
+		*params = append(*params, Parameter{
+			Name:     name,
+			In:       "query",
+			Required: hasRule(f.Tag.Get("binding"), "required"),
+			Schema:   p,
+		})
+	}
+}
+
+func (g *Generator) schema(t reflect.Type, request bool) *Schema {
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := g.schema(t.Elem(), request)
+		if s.Ref != "" {
+			// 3.0 ignores siblings of $ref
+			return &Schema{AllOf: []*Schema{s}, Nullable: true}
+		}
+		s.Nullable = true
+		return s
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem(), request)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem(), request)}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t, request)
+		}
+		return g.component(t, request)
+	}
+	panic(fmt.Sprintf("openapi: unsupported type %s", t))
+}
+
+// component registers a named struct once and returns a reference to it
+func (g *Generator) component(t reflect.Type, request bool) *Schema {
+	name := schemaName(t)
+	if known, ok := g.types[name]; ok {
+		if known != t {
+			panic(fmt.Sprintf("openapi: schema %s is both %s and %s", name, known, t))
+		}
+		return Ref(name)
+	}
+
+	// Placeholder first: the struct may refer to itself
+	s := &Schema{}
+	g.types[name] = t
+	g.schemas[name] = s
+	*s = *g.object(t, request)
+	return Ref(name)
+}
+
+// schemaName is the Go type name; instances of generic types are named
+// after the type argument: ListResponse[TeamResponse] - TeamResponseList
+func schemaName(t reflect.Type) string {
+	base, arg, ok := strings.Cut(t.Name(), "[")
+	if !ok {
+		return base
+	}
+	arg = strings.TrimSuffix(arg, "]")
+	arg = arg[strings.LastIndex(arg, ".")+1:]
+	return arg + strings.TrimSuffix(base, "Response")
+}
+
+func (g *Generator) object(t reflect.Type, request bool) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.fields(s, t, request)
+	return s
+}
+
+// fields adds struct fields as properties; embedded structs are flattened
+// like encoding/json does
+func (g *Generator) fields(s *Schema, t reflect.Type, request bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			g.fields(s, f.Type, request)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		omitempty := slices.Contains(strings.Split(opts, ","), "omitempty")
+		required := !omitempty
+		if request {
+			required = hasRule(f.Tag.Get("binding"), "required")
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer && (omitempty && !request || required && request) {
+			// nil is left out of the response or rejected in the request
+			ft = ft.Elem()
+		}
+		p := g.schema(ft, request)
+		if p.Ref == "" {
+			applyTags(p, f.Tag)
+		}
+
+		if required {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = p
+		s.order = append(s.order, name)
+	}
+}
+
+// applyTags sets format and enum tags and gin binding rules that map to
+// schema keywords
+func applyTags(s *Schema, tag reflect.StructTag) {
+	if format := tag.Get("format"); format != "" {
+		s.Format = format
+	}
+	if enum := tag.Get("enum"); enum != "" {
+		s.Enum = strings.Split(enum, ",")
+	}
+
+	for _, rule := range strings.Split(tag.Get("binding"), ",") {
+		if rule == "dive" {
+			// Following rules apply to elements
+			return
+		}
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "url":
+			s.Format = "uri"
+		case "oneof":
+			s.Enum = strings.Fields(value)
+		case "min", "gte":
+			n, _ := strconv.ParseFloat(value, 64)
+			switch s.Type {
+			case "string":
+				s.MinLength = intPtr(n)
+			case "array":
+				s.MinItems = intPtr(n)
+			default:
+				s.Minimum = &n
+			}
+		case "max", "lte":
+			n, _ := strconv.ParseFloat(value, 64)
+			switch s.Type {
+			case "string":
+				s.MaxLength = intPtr(n)
+			case "integer", "number":
+				s.Maximum = &n
+			}
+		}
+	}
+}
+
+func hasRule(binding, rule string) bool {
+	for _, r := range strings.Split(binding, ",") {
+		if r == "dive" {
+			return false
+		}
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+func intPtr(n float64) *int {
+	i := int(n)
+	return &i
+}
@@ -0,0 +1,144 @@
+// internal/openapi/schema_test.go
+package openapi
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testBase struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testItem struct {
+	testBase
+	Name    string     `json:"name"`
+	Status  string     `json:"status" enum:"draft,open"`
+	Link    string     `json:"link,omitempty" format:"uri"`
+	EndsAt  *time.Time `json:"ends_at"`
+	Parent  *testItem  `json:"parent"`
+	Skipped string     `json:"-"`
+}
+
+type testList[T any] struct {
+	Items []T `json:"items"`
+}
+
+type testRequest struct {
+	Name   string   `json:"name" binding:"required,min=1,max=100"`
+	Kind   string   `json:"kind" binding:"omitempty,oneof=a b"`
+	Active *bool    `json:"active" binding:"required"`
+	Score  *int     `json:"score" binding:"omitempty,gte=0"`
+	Tags   []string `json:"tags" binding:"required,min=1,dive,max=20"`
+}
+
+type testQuery struct {
+	Limit int    `form:"limit,default=20" binding:"omitempty,min=1,max=100"`
+	Phase string `form:"phase" binding:"required,oneof=upcoming finished"`
+}
+
+func TestResponse(t *testing.T) {
+	g := NewGenerator()
+	ref := g.Response(testItem{})
+	if ref.RefName() != "testItem" {
+		t.Fatalf("expected ref to testItem, got %+v", ref)
+	}
+
+	s := g.Schemas()["testItem"]
+	wantOrder := []string{"id", "created_at", "name", "status", "link", "ends_at", "parent"}
+	if !slices.Equal(s.order, wantOrder) {
+		t.Errorf("expected properties %v, got %v", wantOrder, s.order)
+	}
+	if slices.Contains(s.Required, "link") || !slices.Contains(s.Required, "ends_at") {
+		t.Errorf("unexpected required %v", s.Required)
+	}
+
+	tests := []struct {
+		name  string
+		check bool
+	}{
+		{"time is date-time", s.Properties["created_at"].Format == "date-time"},
+		{"enum tag", slices.Equal(s.Properties["status"].Enum, []string{"draft", "open"})},
+		{"format tag", s.Properties["link"].Format == "uri"},
+		{"pointer is nullable", s.Properties["ends_at"].Nullable},
+		{"nullable ref", s.Properties["parent"].Nullable && s.Properties["parent"].AllOf[0].RefName() == "testItem"},
+	}
+	for _, tt := range tests {
+		if !tt.check {
+			t.Errorf("%s: got %+v", tt.name, s)
+		}
+	}
+}
+
+func TestRequest(t *testing.T) {
+	g := NewGenerator()
+	g.Request(testRequest{})
+	s := g.Schemas()["testRequest"].Properties
+
+	if got := s["name"]; *got.MinLength != 1 || *got.MaxLength != 100 {
+		t.Errorf("expected name length 1..100, got %+v", got)
+	}
+	if got := s["kind"].Enum; !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("expected kind enum [a b], got %v", got)
+	}
+	if s["active"].Nullable {
+		t.Error("required pointer should not be nullable")
+	}
+	if got := s["score"]; !got.Nullable || *got.Minimum != 0 {
+		t.Errorf("expected nullable score >= 0, got %+v", got)
+	}
+	if got := s["tags"]; *got.MinItems != 1 || got.MaxLength != nil {
+		t.Errorf("rules after dive should not apply to the array, got %+v", got)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	params := NewGenerator().Query(testQuery{})
+	if len(params) != 2 {
+		t.Fatalf("expected 2 params, got %d", len(params))
+	}
+	if p := params[0]; p.Name != "limit" || p.Required || p.Schema.Default != 20 {
+		t.Errorf("unexpected limit %+v", p)
+	}
+	if p := params[1]; p.Name != "phase" || !p.Required || len(p.Schema.Enum) != 2 {
+		t.Errorf("unexpected phase %+v", p)
+	}
+}
+
+func TestSchemaName(t *testing.T) {
+	g := NewGenerator()
+	g.Response(testList[testItem]{})
+	if _, ok := g.Schemas()["testItemtestList"]; !ok {
+		t.Errorf("expected testItemtestList schema, got %v", keys(g.Schemas()))
+	}
+}
+
+func TestTypeScript(t *testing.T) {
+	g := NewGenerator()
+	g.Response(testItem{})
+	out := string(TypeScript(&Document{Components: Components{Schemas: g.Schemas()}}, "generated"))
+
+	for _, want := range []string{
+		"// generated\n",
+		"export interface testItem {\n  id: number;\n  created_at: string;\n",
+		"  status: 'draft' | 'open';\n",
+		"  link?: string;\n",
+		"  ends_at: string | null;\n",
+		"  parent: testItem | null;\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func keys(m map[string]*Schema) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
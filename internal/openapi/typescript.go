@@ -0,0 +1,114 @@
+// internal/openapi/typescript.go
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// TypeScript renders component schemas of the document as TypeScript
+// interfaces, one per schema in name order. header is written on top as
+// a line comment.
+func TypeScript(doc *Document, header string) []byte {
+	var b bytes.Buffer
+	for _, line := range strings.Split(header, "\n") {
+		fmt.Fprintf(&b, "// %s\n", line)
+	}
+
+	names := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s := doc.Components.Schemas[name]
+		b.WriteString("\n")
+		if s.Type != "object" || s.Properties == nil {
+			fmt.Fprintf(&b, "export type %s = %s;\n", name, tsType(s))
+			continue
+		}
+		fmt.Fprintf(&b, "export interface %s {\n", name)
+		for _, prop := range propertyNames(s) {
+			optional := "?"
+			if slices.Contains(s.Required, prop) {
+				optional = ""
+			}
+			fmt.Fprintf(&b, "  %s%s: %s;\n", prop, optional, tsType(s.Properties[prop]))
+		}
+		b.WriteString("}\n")
+	}
+	return b.Bytes()
+}
+
+// propertyNames returns properties in struct field order, sorted if unknown
+func propertyNames(s *Schema) []string {
+	if len(s.order) == len(s.Properties) {
+		return s.order
+	}
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func tsType(s *Schema) string {
+	t := tsBaseType(s)
+	if s.Nullable {
+		t += " | null"
+	}
+	return t
+}
+
+func tsBaseType(s *Schema) string {
+	if name := s.RefName(); name != "" {
+		return name
+	}
+	if len(s.AllOf) == 1 {
+		return tsBaseType(s.AllOf[0])
+	}
+	if len(s.Enum) > 0 {
+		values := make([]string, 0, len(s.Enum))
+		for _, v := range s.Enum {
+			values = append(values, fmt.Sprintf("'%s'", v))
+		}
+		return strings.Join(values, " | ")
+	}
+
+	switch s.Type {
+	case "string":
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		item := tsType(s.Items)
+		if strings.Contains(item, " | ") {
+			item = "(" + item + ")"
+		}
+		return item + "[]"
+	case "object":
+		if s.AdditionalProperties != nil {
+			return "Record<string, " + tsType(s.AdditionalProperties) + ">"
+		}
+		if len(s.Properties) > 0 {
+			fields := make([]string, 0, len(s.Properties))
+			for _, prop := range propertyNames(s) {
+				optional := "?"
+				if slices.Contains(s.Required, prop) {
+					optional = ""
+				}
+				fields = append(fields, fmt.Sprintf("%s%s: %s", prop, optional, tsType(s.Properties[prop])))
+			}
+			return "{ " + strings.Join(fields, "; ") + " }"
+		}
+		return "Record<string, unknown>"
+	}
+	return "unknown"
+}
@@ -0,0 +1,15 @@
+// internal/repository/badges.go
+package repository
+
+import "time"
+
+// TeamHistoryEntry is one team result used to evaluate achievement rules
+type TeamHistoryEntry struct {
+	ResultID       int64
+	TournamentID   int64
+	TournamentDate time.Time
+	Place          int
+	// LeaderPlace is the place of the team that was #1 in the rating before
+	// this tournament (nil if it didn't play or this team was #1)
+	LeaderPlace *int
+}
@@ -0,0 +1,65 @@
+// internal/repository/bun/api_token.go
+package bunrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/uptrace/bun"
+)
+
+// lastUsedPrecision limits last_used_at writes to one per token per minute
+const lastUsedPrecision = time.Minute
+
+type APITokenRepo struct {
+	db *bun.DB
+}
+
+func NewAPITokenRepo(db *bun.DB) *APITokenRepo {
+	return &APITokenRepo{db: db}
+}
+
+func (r *APITokenRepo) Create(ctx context.Context, token *domain.APIToken) error {
+	_, err := r.db.NewInsert().Model(token).Returning("*").Exec(ctx)
+	return err
+}
+
+func (r *APITokenRepo) GetByID(ctx context.Context, id int64) (*domain.APIToken, error) {
+	token := new(domain.APIToken)
+	err := r.db.NewSelect().Model(token).Where("id = ?", id).Scan(ctx)
+	return token, err
+}
+
+func (r *APITokenRepo) GetByHash(ctx context.Context, hash string) (*domain.APIToken, error) {
+	token := new(domain.APIToken)
+	err := r.db.NewSelect().Model(token).Where("token_hash = ?", hash).Scan(ctx)
+	return token, err
+}
+
+func (r *APITokenRepo) List(ctx context.Context) ([]*domain.APIToken, error) {
+	var tokens []*domain.APIToken
+	err := r.db.NewSelect().Model(&tokens).Order("id DESC").Scan(ctx)
+	return tokens, err
+}
+
+func (r *APITokenRepo) Revoke(ctx context.Context, id int64, revokedBy int64) error {
+	_, err := r.db.NewUpdate().
+		Model((*domain.APIToken)(nil)).
+		Set("revoked_at = ?", time.Now()).
+		Set("revoked_by = ?", revokedBy).
+		Where("id = ?", id).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	return err
+}
+
+func (r *APITokenRepo) TouchLastUsed(ctx context.Context, id int64, at time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*domain.APIToken)(nil)).
+		Set("last_used_at = ?", at).
+		Where("id = ?", id).
+		Where("last_used_at IS NULL OR last_used_at < ?", at.Add(-lastUsedPrecision)).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,50 @@
+// internal/repository/bun/audit.go
+package bunrepo
+
+import (
+	"context"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+	"github.com/uptrace/bun"
+)
+
+type AuditRepo struct {
+	db *bun.DB
+}
+
+func NewAuditRepo(db *bun.DB) *AuditRepo {
+	return &AuditRepo{db: db}
+}
+
+func (r *AuditRepo) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	return insertAudit(ctx, r.db, entry)
+}
+
+func (r *AuditRepo) List(ctx context.Context, filter repository.AuditFilter) ([]*domain.AuditEntry, int, error) {
+	var entries []*domain.AuditEntry
+	q := r.db.NewSelect().Model(&entries)
+	if filter.EntityType != "" {
+		q = q.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != 0 {
+		q = q.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.ActorID != 0 {
+		q = q.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit).Offset(filter.Offset)
+	}
+	total, err := q.Order("created_at DESC", "id DESC").ScanAndCount(ctx)
+	return entries, total, err
+}
+
+// insertAudit writes audit entry using db or tx (so it commits together with the change)
+func insertAudit(ctx context.Context, db bun.IDB, entry *domain.AuditEntry) error {
+	if entry.Details == nil {
+		entry.Details = map[string]any{}
+	}
+	_, err := db.NewInsert().Model(entry).Returning("*").Exec(ctx)
+	return err
+}
@@ -0,0 +1,148 @@
+// internal/repository/bun/badge.go
+package bunrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+	"github.com/uptrace/bun"
+)
+
+type BadgeRepo struct {
+	db *bun.DB
+}
+
+func NewBadgeRepo(db *bun.DB) *BadgeRepo {
+	return &BadgeRepo{db: db}
+}
+
+func (r *BadgeRepo) GetTeamHistory(ctx context.Context, teamID int64) ([]repository.TeamHistoryEntry, error) {
+	var history []repository.TeamHistoryEntry
+	// The leader is taken from the rating over tournaments before this one,
+	// so a later change at the top doesn't rewrite history
+	err := r.db.NewRaw(`
+		SELECT
+			r.id as result_id,
+			r.tournament_id as tournament_id,
+			tr.date as tournament_date,
+			r.place as place,
+			lr.place as leader_place
+		FROM results r
+		JOIN tournaments tr ON tr.id = r.tournament_id AND tr.deleted_at IS NULL
+		LEFT JOIN LATERAL (
+			SELECT t.id
+			FROM teams t
+			JOIN results pr ON pr.team_id = t.id AND pr.deleted_at IS NULL
+			JOIN tournaments ptr ON ptr.id = pr.tournament_id AND ptr.deleted_at IS NULL
+			WHERE t.deleted_at IS NULL
+				AND (ptr.date < tr.date OR (ptr.date = tr.date AND ptr.id < tr.id))
+			GROUP BY t.id
+			ORDER BY COUNT(CASE WHEN pr.place = 1 THEN 1 END) DESC, AVG(pr.place) ASC, t.id ASC
+			LIMIT 1
+		) leader ON true
+		LEFT JOIN results lr ON lr.tournament_id = r.tournament_id
+			AND lr.team_id = leader.id
+			AND lr.team_id <> r.team_id
+			AND lr.deleted_at IS NULL
+		WHERE r.team_id = ? AND r.deleted_at IS NULL
+		ORDER BY tr.date ASC, tr.id ASC
+	`, teamID).Scan(ctx, &history)
+	return history, err
+}
+
+func (r *BadgeRepo) TeamIDsWithResults(ctx context.Context) ([]int64, error) {
+	var ids []int64
+	err := r.db.NewRaw(`
+		SELECT DISTINCT t.id
+		FROM teams t
+		JOIN results r ON r.team_id = t.id AND r.deleted_at IS NULL
+		WHERE t.deleted_at IS NULL
+		ORDER BY t.id
+	`).Scan(ctx, &ids)
+	return ids, err
+}
+
+func (r *BadgeRepo) Award(ctx context.Context, badge *domain.Badge) (bool, error) {
+	awarded := false
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var inserted []*domain.Badge
+		err := tx.NewRaw(`
+			INSERT INTO badges (team_id, code, result_id, announced_at)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT (team_id, code) WHERE member_id IS NULL DO NOTHING
+			RETURNING *
+		`, badge.TeamID, badge.Code, badge.ResultID, badge.AnnouncedAt).Scan(ctx, &inserted)
+		if err != nil {
+			return err
+		}
+		if len(inserted) == 0 {
+			return nil
+		}
+		*badge = *inserted[0]
+		awarded = true
+
+		// Members on the team at the moment get the badge too (announced with the team)
+		_, err = tx.NewRaw(`
+			INSERT INTO badges (team_id, member_id, code, result_id, awarded_at, announced_at)
+			SELECT ?, m.id, ?, ?, ?, ?
+			FROM members m
+			WHERE m.team_id = ? AND m.deleted_at IS NULL
+			ON CONFLICT (member_id, code) WHERE member_id IS NOT NULL DO NOTHING
+		`, badge.TeamID, badge.Code, badge.ResultID, badge.AwardedAt, badge.AwardedAt, badge.TeamID).Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return recordEvent(ctx, tx, domain.EventBadgeAwarded, domain.EntityBadge, badge.ID, 0, map[string]any{
+			"id":        badge.ID,
+			"team_id":   badge.TeamID,
+			"code":      badge.Code,
+			"result_id": badge.ResultID,
+		})
+	})
+	return awarded, err
+}
+
+func (r *BadgeRepo) GetByID(ctx context.Context, id int64) (*domain.Badge, error) {
+	badge := new(domain.Badge)
+	err := r.db.NewSelect().Model(badge).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return badge, nil
+}
+
+func (r *BadgeRepo) GetByTeamID(ctx context.Context, teamID int64) ([]*domain.Badge, error) {
+	var badges []*domain.Badge
+	err := r.db.NewSelect().
+		Model(&badges).
+		Where("team_id = ?", teamID).
+		Where("member_id IS NULL").
+		Order("awarded_at ASC").
+		Scan(ctx)
+	return badges, err
+}
+
+func (r *BadgeRepo) GetByMemberID(ctx context.Context, memberID int64) ([]*domain.Badge, error) {
+	var badges []*domain.Badge
+	err := r.db.NewSelect().
+		Model(&badges).
+		Where("member_id = ?", memberID).
+		Order("awarded_at ASC").
+		Scan(ctx)
+	return badges, err
+}
+
+func (r *BadgeRepo) MarkAnnounced(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := r.db.NewUpdate().
+		Model((*domain.Badge)(nil)).
+		Set("announced_at = ?", time.Now()).
+		Where("id IN (?)", bun.In(ids)).
+		Exec(ctx)
+	return err
+}
@@ -2,7 +2,9 @@
 package bunrepo
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
@@ -20,3 +22,16 @@ func NewDB(databaseURL string, debug bool) *bun.DB {
 
 	return db
 }
+
+// softDelete marks row id of model's table deleted by deletedBy; model is a
+// nil pointer to the domain type, e.g. (*domain.Team)(nil)
+func softDelete(ctx context.Context, tx bun.Tx, model any, id, deletedBy int64) error {
+	_, err := tx.NewUpdate().
+		Model(model).
+		Set("deleted_at = ?", time.Now()).
+		Set("deleted_by = ?", deletedBy).
+		Set("version = version + 1").
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,71 @@
+// internal/repository/bun/discipline.go
+package bunrepo
+
+import (
+	"context"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/uptrace/bun"
+)
+
+type DisciplineRepo struct {
+	db *bun.DB
+}
+
+func NewDisciplineRepo(db *bun.DB) *DisciplineRepo {
+	return &DisciplineRepo{db: db}
+}
+
+func (r *DisciplineRepo) Create(ctx context.Context, d *domain.Discipline) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(d).Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventDisciplineCreated, domain.EntityDiscipline, d.ID, d.CreatedBy, disciplinePayload(d))
+	})
+}
+
+func (r *DisciplineRepo) GetByID(ctx context.Context, id int64) (*domain.Discipline, error) {
+	d := new(domain.Discipline)
+	err := r.db.NewSelect().Model(d).Where("id = ?", id).Where("deleted_at IS NULL").Scan(ctx)
+	return d, err
+}
+
+func (r *DisciplineRepo) List(ctx context.Context) ([]*domain.Discipline, error) {
+	var disciplines []*domain.Discipline
+	err := r.db.NewSelect().Model(&disciplines).Where("deleted_at IS NULL").Order("id ASC").Scan(ctx)
+	return disciplines, err
+}
+
+func (r *DisciplineRepo) Update(ctx context.Context, d *domain.Discipline) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewUpdate().Model(d).WherePK().Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventDisciplineUpdated, domain.EntityDiscipline, d.ID,
+			actorOf(d.UpdatedBy, d.CreatedBy), disciplinePayload(d))
+	})
+}
+
+func (r *DisciplineRepo) Delete(ctx context.Context, id, deletedBy int64) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		d := new(domain.Discipline)
+		if err := tx.NewSelect().Model(d).Where("id = ?", id).For("UPDATE").Scan(ctx); err != nil {
+			return err
+		}
+		used, err := tx.NewSelect().
+			Model((*domain.Tournament)(nil)).
+			Where("discipline_id = ?", id).
+			Exists(ctx)
+		if err != nil {
+			return err
+		}
+		if used {
+			return domain.ErrDisciplineInUse
+		}
+		if err := softDelete(ctx, tx, (*domain.Discipline)(nil), id, deletedBy); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventDisciplineDeleted, domain.EntityDiscipline, id, deletedBy, disciplinePayload(d))
+	})
+}
@@ -0,0 +1,232 @@
+// internal/repository/bun/event.go
+package bunrepo
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/uptrace/bun"
+)
+
+type EventRepo struct {
+	db *bun.DB
+}
+
+func NewEventRepo(db *bun.DB) *EventRepo {
+	return &EventRepo{db: db}
+}
+
+// MaxEventAttempts - after this many failures an event stays in
+// event_failures and is no longer passed to the subscriber
+const MaxEventAttempts = 8
+
+func (r *EventRepo) Process(
+	ctx context.Context,
+	subscriber string,
+	types []string,
+	limit int,
+	since time.Time,
+	handle func(ctx context.Context, e *domain.Event) error,
+) (int, error) {
+	// Handlers call webhooks and Telegram: they run outside any transaction,
+	// the subscriber is held by a session lock on a dedicated connection
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	lockKey := "domain_events:" + subscriber
+	var locked bool
+	if err := conn.NewRaw("SELECT pg_try_advisory_lock(hashtext(?))", lockKey).Scan(ctx, &locked); err != nil || !locked {
+		// One process per subscriber at a time; others skip this round
+		conn.Close()
+		return 0, err
+	}
+	defer func() {
+		_, err := conn.NewRaw("SELECT pg_advisory_unlock(hashtext(?))", lockKey).Exec(context.WithoutCancel(ctx))
+		if err != nil {
+			// Never return a connection holding the lock to the pool
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		conn.Close()
+	}()
+
+	// A subscriber seen for the first time starts at the newest event
+	// instead of replaying the retention window
+	var startID int64
+	if _, err := conn.NewRaw(`
+		INSERT INTO event_subscribers (name, start_event_id)
+		VALUES (?, (SELECT COALESCE(MAX(id), 0) FROM domain_events))
+		ON CONFLICT DO NOTHING`, subscriber,
+	).Exec(ctx); err != nil {
+		return 0, err
+	}
+	if err := conn.NewRaw("SELECT start_event_id FROM event_subscribers WHERE name = ?", subscriber).Scan(ctx, &startID); err != nil {
+		return 0, err
+	}
+
+	var events []*domain.Event
+	q := conn.NewSelect().
+		Model(&events).
+		Where("event.id > ?", startID).
+		Where("event.occurred_at > ?", since).
+		Where("NOT EXISTS (SELECT 1 FROM event_receipts r WHERE r.event_id = event.id AND r.subscriber = ?)", subscriber).
+		Where(`NOT EXISTS (SELECT 1 FROM event_failures f WHERE f.event_id = event.id AND f.subscriber = ?
+			AND (f.attempts >= ? OR f.retry_at > NOW()))`, subscriber, MaxEventAttempts).
+		Order("event.id ASC").
+		Limit(limit)
+	if len(types) > 0 {
+		q = q.Where("event.type IN (?)", bun.In(types))
+	}
+	if err := q.Scan(ctx); err != nil {
+		return 0, err
+	}
+
+	var failures []error
+	for _, e := range events {
+		if handleErr := handle(ctx, e); handleErr != nil {
+			failures = append(failures, fmt.Errorf("event %d (%s): %w", e.ID, e.Type, handleErr))
+			if err := recordEventFailure(ctx, conn, e.ID, subscriber, handleErr); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		// Each receipt commits on its own: a later failure doesn't repeat this event
+		if err := conn.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			if _, err := tx.NewRaw(
+				"INSERT INTO event_receipts (event_id, subscriber) VALUES (?, ?) ON CONFLICT DO NOTHING",
+				e.ID, subscriber,
+			).Exec(ctx); err != nil {
+				return err
+			}
+			_, err := tx.NewRaw("DELETE FROM event_failures WHERE event_id = ? AND subscriber = ?", e.ID, subscriber).Exec(ctx)
+			return err
+		}); err != nil {
+			return 0, err
+		}
+	}
+	return len(events), errors.Join(failures...)
+}
+
+// recordEventFailure counts a failed attempt; retries back off from
+// 1 minute doubling up to 6 hours
+func recordEventFailure(ctx context.Context, db bun.IDB, eventID int64, subscriber string, handleErr error) error {
+	_, err := db.NewRaw(`
+		INSERT INTO event_failures (event_id, subscriber, attempts, last_error, retry_at)
+		VALUES (?, ?, 1, ?, NOW() + INTERVAL '1 minute')
+		ON CONFLICT (event_id, subscriber) DO UPDATE SET
+			attempts = event_failures.attempts + 1,
+			last_error = EXCLUDED.last_error,
+			failed_at = NOW(),
+			retry_at = NOW() + LEAST(POWER(2, event_failures.attempts), 360) * INTERVAL '1 minute'`,
+		eventID, subscriber, handleErr.Error(),
+	).Exec(ctx)
+	return err
+}
+
+func (r *EventRepo) DeleteBefore(ctx context.Context, before time.Time) error {
+	_, err := r.db.NewDelete().
+		Model((*domain.Event)(nil)).
+		Where("occurred_at < ?", before).
+		Exec(ctx)
+	return err
+}
+
+// recordEvent writes domain event using db or tx (so it commits together with the change)
+func recordEvent(ctx context.Context, db bun.IDB, eventType, aggregateType string, aggregateID, actorID int64, payload map[string]any) error {
+	if payload == nil {
+		payload = map[string]any{}
+	}
+	_, err := db.NewInsert().Model(&domain.Event{
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		ActorID:       actorID,
+		Payload:       payload,
+	}).Exec(ctx)
+	return err
+}
+
+// actorOf returns who made the change: the updater if set, otherwise creator
+func actorOf(updatedBy *int64, createdBy int64) int64 {
+	if updatedBy != nil {
+		return *updatedBy
+	}
+	return createdBy
+}
+
+func teamPayload(t *domain.Team) map[string]any {
+	return map[string]any{
+		"id":      t.ID,
+		"name":    t.Name,
+		"version": t.Version,
+	}
+}
+
+func memberPayload(m *domain.Member) map[string]any {
+	return map[string]any{
+		"id":      m.ID,
+		"team_id": m.TeamID,
+		"name":    m.Name,
+		"version": m.Version,
+	}
+}
+
+func tournamentPayload(t *domain.Tournament) map[string]any {
+	payload := map[string]any{
+		"id":               t.ID,
+		"name":             t.Name,
+		"date":             t.Date.Format("2006-01-02"),
+		"location":         t.Location,
+		"venue_id":         t.VenueID,
+		"discipline_id":    t.DisciplineID,
+		"participant_mode": t.ParticipantMode,
+		"duration_minutes": t.DurationMinutes,
+		"timezone":         t.Timezone,
+		"version":          t.Version,
+	}
+	if t.StartsAt != nil {
+		payload["starts_at"] = t.Start(time.UTC).Format(time.RFC3339)
+	}
+	return payload
+}
+
+func venuePayload(v *domain.Venue) map[string]any {
+	return map[string]any{
+		"id":      v.ID,
+		"name":    v.Name,
+		"address": v.Address,
+		"version": v.Version,
+	}
+}
+
+func disciplinePayload(d *domain.Discipline) map[string]any {
+	return map[string]any{
+		"id":      d.ID,
+		"name":    d.Name,
+		"version": d.Version,
+	}
+}
+
+func playerPayload(p *domain.Player) map[string]any {
+	return map[string]any{
+		"id":        p.ID,
+		"name":      p.Name,
+		"member_id": p.MemberID,
+		"version":   p.Version,
+	}
+}
+
+func resultPayload(r *domain.Result) map[string]any {
+	return map[string]any{
+		"id":            r.ID,
+		"tournament_id": r.TournamentID,
+		"team_id":       r.TeamID,
+		"player_id":     r.PlayerID,
+		"place":         r.Place,
+		"version":       r.Version,
+	}
+}
@@ -0,0 +1,129 @@
+// internal/repository/bun/invite.go
+package bunrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/uptrace/bun"
+)
+
+type InviteRepo struct {
+	db *bun.DB
+}
+
+func NewInviteRepo(db *bun.DB) *InviteRepo {
+	return &InviteRepo{db: db}
+}
+
+func (r *InviteRepo) Create(ctx context.Context, invite *domain.Invite) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(invite).Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		return insertAudit(ctx, tx, &domain.AuditEntry{
+			ActorID:    invite.CreatedBy,
+			Action:     domain.AuditInviteCreate,
+			EntityType: domain.EntityInvite,
+			EntityID:   invite.ID,
+			Details: map[string]any{
+				"role":       invite.Role,
+				"max_uses":   invite.MaxUses,
+				"expires_at": invite.ExpiresAt,
+			},
+		})
+	})
+}
+
+func (r *InviteRepo) GetByID(ctx context.Context, id int64) (*domain.Invite, error) {
+	invite := new(domain.Invite)
+	err := r.db.NewSelect().Model(invite).Where("id = ?", id).Scan(ctx)
+	return invite, err
+}
+
+func (r *InviteRepo) List(ctx context.Context, limit, offset int) ([]*domain.Invite, int, error) {
+	var invites []*domain.Invite
+	total, err := r.db.NewSelect().
+		Model(&invites).
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+	return invites, total, err
+}
+
+func (r *InviteRepo) Revoke(ctx context.Context, id int64, revokedBy int64) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewUpdate().
+			Model((*domain.Invite)(nil)).
+			Set("revoked_at = ?", time.Now()).
+			Set("revoked_by = ?", revokedBy).
+			Where("id = ?", id).
+			Where("revoked_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return nil
+		}
+		return insertAudit(ctx, tx, &domain.AuditEntry{
+			ActorID:    revokedBy,
+			Action:     domain.AuditInviteRevoke,
+			EntityType: domain.EntityInvite,
+			EntityID:   id,
+		})
+	})
+}
+
+func (r *InviteRepo) Redeem(ctx context.Context, hash string, telegramID int64, now time.Time) (*domain.Invite, error) {
+	invite := new(domain.Invite)
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().Model(invite).Where("token_hash = ?", hash).For("UPDATE").Scan(ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrInviteInvalid
+		}
+		if err != nil {
+			return err
+		}
+		if err := invite.Check(now); err != nil {
+			return err
+		}
+
+		// Hold the role lock from the check to the change
+		if err := lockRoles(ctx, tx); err != nil {
+			return err
+		}
+		user := new(domain.User)
+		if err := tx.NewSelect().Model(user).Where("telegram_id = ?", telegramID).Scan(ctx); err != nil {
+			return err
+		}
+		// Roles are not ordered (custom ones included), so an invite only
+		// lifts a viewer and never replaces a role granted otherwise
+		if user.Role != domain.RoleViewer || invite.Role == domain.RoleViewer {
+			return domain.ErrInviteNotNeeded
+		}
+
+		if err := updateRole(ctx, tx, telegramID, invite.Role, telegramID); err != nil {
+			return err
+		}
+		invite.Uses++
+		if _, err := tx.NewUpdate().Model(invite).Column("uses").WherePK().Exec(ctx); err != nil {
+			return err
+		}
+		return insertAudit(ctx, tx, &domain.AuditEntry{
+			ActorID:    telegramID,
+			Action:     domain.AuditInviteRedeem,
+			EntityType: domain.EntityInvite,
+			EntityID:   invite.ID,
+			Details: map[string]any{
+				"role":          invite.Role,
+				"previous_role": user.Role,
+			},
+		})
+	})
+	return invite, err
+}
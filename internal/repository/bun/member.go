@@ -17,8 +17,12 @@ func NewMemberRepo(db *bun.DB) *MemberRepo {
 }
 
 func (r *MemberRepo) Create(ctx context.Context, member *domain.Member) error {
-	_, err := r.db.NewInsert().Model(member).Returning("*").Exec(ctx)
-	return err
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(member).Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventMemberCreated, domain.EntityMember, member.ID, member.CreatedBy, memberPayload(member))
+	})
 }
 
 func (r *MemberRepo) GetByID(ctx context.Context, id int64) (*domain.Member, error) {
@@ -39,11 +43,24 @@ func (r *MemberRepo) GetByTeamID(ctx context.Context, teamID int64) ([]*domain.M
 }
 
 func (r *MemberRepo) Update(ctx context.Context, member *domain.Member) error {
-	_, err := r.db.NewUpdate().Model(member).WherePK().Returning("*").Exec(ctx)
-	return err
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewUpdate().Model(member).WherePK().Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventMemberUpdated, domain.EntityMember, member.ID,
+			actorOf(member.UpdatedBy, member.CreatedBy), memberPayload(member))
+	})
 }
 
-func (r *MemberRepo) Delete(ctx context.Context, id int64) error {
-	_, err := r.db.NewDelete().Model((*domain.Member)(nil)).Where("id = ?", id).Exec(ctx)
-	return err
+func (r *MemberRepo) Delete(ctx context.Context, id, deletedBy int64) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		member := new(domain.Member)
+		if err := tx.NewSelect().Model(member).Where("id = ?", id).Scan(ctx); err != nil {
+			return err
+		}
+		if err := softDelete(ctx, tx, (*domain.Member)(nil), id, deletedBy); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventMemberDeleted, domain.EntityMember, id, deletedBy, memberPayload(member))
+	})
 }
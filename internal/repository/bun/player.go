@@ -0,0 +1,61 @@
+// internal/repository/bun/player.go
+package bunrepo
+
+import (
+	"context"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/uptrace/bun"
+)
+
+type PlayerRepo struct {
+	db *bun.DB
+}
+
+func NewPlayerRepo(db *bun.DB) *PlayerRepo {
+	return &PlayerRepo{db: db}
+}
+
+func (r *PlayerRepo) Create(ctx context.Context, p *domain.Player) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(p).Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventPlayerCreated, domain.EntityPlayer, p.ID, p.CreatedBy, playerPayload(p))
+	})
+}
+
+func (r *PlayerRepo) GetByID(ctx context.Context, id int64) (*domain.Player, error) {
+	p := new(domain.Player)
+	err := r.db.NewSelect().Model(p).Where("id = ?", id).Where("deleted_at IS NULL").Scan(ctx)
+	return p, err
+}
+
+func (r *PlayerRepo) List(ctx context.Context) ([]*domain.Player, error) {
+	var players []*domain.Player
+	err := r.db.NewSelect().Model(&players).Where("deleted_at IS NULL").Order("name ASC").Scan(ctx)
+	return players, err
+}
+
+func (r *PlayerRepo) Update(ctx context.Context, p *domain.Player) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewUpdate().Model(p).WherePK().Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventPlayerUpdated, domain.EntityPlayer, p.ID,
+			actorOf(p.UpdatedBy, p.CreatedBy), playerPayload(p))
+	})
+}
+
+func (r *PlayerRepo) Delete(ctx context.Context, id, deletedBy int64) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		p := new(domain.Player)
+		if err := tx.NewSelect().Model(p).Where("id = ?", id).Scan(ctx); err != nil {
+			return err
+		}
+		if err := softDelete(ctx, tx, (*domain.Player)(nil), id, deletedBy); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventPlayerDeleted, domain.EntityPlayer, id, deletedBy, playerPayload(p))
+	})
+}
@@ -9,30 +9,81 @@ import (
 	"github.com/uptrace/bun"
 )
 
+// teamNameAtDateExpr selects the name the team used on the tournament date
+// (NULL when it is the current name). Rename bounds are instants, they are
+// compared as dates in the tournament timezone (? - default timezone).
+const teamNameAtDateExpr = `(
+	SELECT a.name
+	FROM team_aliases a
+	JOIN tournaments tr ON tr.id = result.tournament_id
+	WHERE a.team_id = result.team_id
+		AND a.kind = 'rename'
+		AND (a.valid_from AT TIME ZONE COALESCE(NULLIF(tr.timezone, ''), ?0))::date <= tr.date
+		AND (a.valid_to AT TIME ZONE COALESCE(NULLIF(tr.timezone, ''), ?0))::date > tr.date
+	ORDER BY a.valid_to DESC
+	LIMIT 1
+) AS team_name_at_date`
+
 type ResultRepo struct {
-	db *bun.DB
+	db       *bun.DB
+	timezone string // default tournament timezone (IANA name)
 }
 
-func NewResultRepo(db *bun.DB) *ResultRepo {
-	return &ResultRepo{db: db}
+func NewResultRepo(db *bun.DB, timezone string) *ResultRepo {
+	return &ResultRepo{db: db, timezone: timezone}
 }
 
+// Create records a team or player result; recording it again updates the place.
+// Starting a planned tournament is recorded as tournament.updated.
 func (r *ResultRepo) Create(ctx context.Context, res *domain.Result) error {
-	_, err := r.db.NewInsert().
-		Model(res).
-		On("CONFLICT (tournament_id, team_id) DO UPDATE").
-		Set("place = EXCLUDED.place").
-		Set("recorded_by = EXCLUDED.recorded_by").
-		Set("recorded_at = CURRENT_TIMESTAMP").
-		Returning("*").
-		Exec(ctx)
-	return err
+	conflict := "CONFLICT (tournament_id, team_id) WHERE deleted_at IS NULL DO UPDATE"
+	if res.PlayerID != 0 {
+		conflict = "CONFLICT (tournament_id, player_id) WHERE deleted_at IS NULL DO UPDATE"
+	}
+
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().
+			Model(res).
+			On(conflict).
+			Set("place = EXCLUDED.place").
+			Set("recorded_by = EXCLUDED.recorded_by").
+			Set("recorded_at = CURRENT_TIMESTAMP").
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		// The first result starts a planned tournament
+		var started []*domain.Tournament
+		_, err = tx.NewUpdate().
+			Model((*domain.Tournament)(nil)).
+			Set("status = ?", domain.TournamentInProgress).
+			Set("version = version + 1").
+			Where("id = ?", res.TournamentID).
+			Where("status = ?", domain.TournamentPlanned).
+			Returning("*").
+			Exec(ctx, &started)
+		if err != nil {
+			return err
+		}
+		for _, t := range started {
+			err := recordEvent(ctx, tx, domain.EventTournamentUpdated, domain.EntityTournament, t.ID, res.RecordedBy, tournamentPayload(t))
+			if err != nil {
+				return err
+			}
+		}
+
+		return recordEvent(ctx, tx, domain.EventResultCreated, domain.EntityResult, res.ID, res.RecordedBy, resultPayload(res))
+	})
 }
 
 func (r *ResultRepo) GetByTeamID(ctx context.Context, teamID int64) ([]*domain.Result, error) {
 	var results []*domain.Result
 	err := r.db.NewSelect().
 		Model(&results).
+		ColumnExpr("result.*").
+		ColumnExpr(teamNameAtDateExpr, r.timezone).
 		Relation("Tournament").
 		Where("result.team_id = ?", teamID).
 		Where("result.deleted_at IS NULL").
@@ -45,7 +96,10 @@ func (r *ResultRepo) GetByTournamentID(ctx context.Context, tournamentID int64)
 	var results []*domain.Result
 	err := r.db.NewSelect().
 		Model(&results).
+		ColumnExpr("result.*").
+		ColumnExpr(teamNameAtDateExpr, r.timezone).
 		Relation("Team").
+		Relation("Player").
 		Where("result.tournament_id = ?", tournamentID).
 		Where("result.deleted_at IS NULL").
 		Order("place ASC").
@@ -53,13 +107,25 @@ func (r *ResultRepo) GetByTournamentID(ctx context.Context, tournamentID int64)
 	return results, err
 }
 
+func (r *ResultRepo) GetByPlayerID(ctx context.Context, playerID int64) ([]*domain.Result, error) {
+	var results []*domain.Result
+	err := r.db.NewSelect().
+		Model(&results).
+		Relation("Tournament").
+		Where("result.player_id = ?", playerID).
+		Where("result.deleted_at IS NULL").
+		Order("recorded_at DESC").
+		Scan(ctx)
+	return results, err
+}
+
 func (r *ResultRepo) GetByID(ctx context.Context, id int64) (*domain.Result, error) {
 	result := new(domain.Result)
 	err := r.db.NewSelect().Model(result).Where("id = ?", id).Where("deleted_at IS NULL").Scan(ctx)
 	return result, err
 }
 
-func (r *ResultRepo) GetTeamRating(ctx context.Context) ([]repository.TeamRating, error) {
+func (r *ResultRepo) GetTeamRating(ctx context.Context, disciplineID int64) ([]repository.TeamRating, error) {
 	var ratings []repository.TeamRating
 	err := r.db.NewRaw(`
 		SELECT
@@ -69,46 +135,119 @@ func (r *ResultRepo) GetTeamRating(ctx context.Context) ([]repository.TeamRating
 			COUNT(r.id) as total_games,
 			COALESCE(AVG(r.place), 0) as avg_place
 		FROM teams t
-		LEFT JOIN results r ON t.id = r.team_id AND r.deleted_at IS NULL
+		JOIN results r ON t.id = r.team_id AND r.deleted_at IS NULL
+		JOIN tournaments tr ON tr.id = r.tournament_id AND tr.deleted_at IS NULL
 		WHERE t.deleted_at IS NULL
+			AND (? = 0 OR tr.discipline_id = ?)
 		GROUP BY t.id, t.name
-		HAVING COUNT(r.id) > 0
 		ORDER BY wins DESC, avg_place ASC
-	`).Scan(ctx, &ratings)
+	`, disciplineID, disciplineID).Scan(ctx, &ratings)
+	return ratings, err
+}
+
+func (r *ResultRepo) GetPlayerRating(ctx context.Context, disciplineID int64) ([]repository.PlayerRating, error) {
+	var ratings []repository.PlayerRating
+	err := r.db.NewRaw(`
+		SELECT
+			p.id as player_id,
+			p.name as player_name,
+			COUNT(CASE WHEN r.place = 1 THEN 1 END) as wins,
+			COUNT(r.id) as total_games,
+			COALESCE(AVG(r.place), 0) as avg_place
+		FROM players p
+		JOIN results r ON p.id = r.player_id AND r.deleted_at IS NULL
+		JOIN tournaments tr ON tr.id = r.tournament_id AND tr.deleted_at IS NULL
+		WHERE p.deleted_at IS NULL
+			AND (? = 0 OR tr.discipline_id = ?)
+		GROUP BY p.id, p.name
+		ORDER BY wins DESC, avg_place ASC
+	`, disciplineID, disciplineID).Scan(ctx, &ratings)
 	return ratings, err
 }
 
+func (r *ResultRepo) GetHeadToHead(ctx context.Context, teamID, otherID int64) ([]repository.HeadToHeadGame, error) {
+	var games []repository.HeadToHeadGame
+	err := r.db.NewRaw(`
+		SELECT
+			tr.id as tournament_id,
+			tr.name as tournament_name,
+			tr.date as tournament_date,
+			a.place as place,
+			b.place as other_place
+		FROM results a
+		JOIN results b ON b.tournament_id = a.tournament_id AND b.team_id = ? AND b.deleted_at IS NULL
+		JOIN tournaments tr ON tr.id = a.tournament_id AND tr.deleted_at IS NULL
+		WHERE a.team_id = ? AND a.deleted_at IS NULL
+		ORDER BY tr.date DESC, tr.id DESC
+	`, otherID, teamID).Scan(ctx, &games)
+	return games, err
+}
+
 func (r *ResultRepo) Update(ctx context.Context, res *domain.Result) error {
-	_, err := r.db.NewUpdate().Model(res).WherePK().Returning("*").Exec(ctx)
-	return err
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewUpdate().Model(res).WherePK().Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventResultUpdated, domain.EntityResult, res.ID,
+			actorOf(res.UpdatedBy, res.RecordedBy), resultPayload(res))
+	})
 }
 
-func (r *ResultRepo) Delete(ctx context.Context, id int64) error {
-	_, err := r.db.NewDelete().Model((*domain.Result)(nil)).Where("id = ?", id).Exec(ctx)
-	return err
+func (r *ResultRepo) Delete(ctx context.Context, id, deletedBy int64) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := deleteResult(ctx, tx, id, deletedBy)
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventResultDeleted, domain.EntityResult, id, deletedBy, resultPayload(res))
+	})
 }
 
-func (r *ResultRepo) DeleteWithShift(ctx context.Context, id int64, tournamentID int64, deletedPlace int) error {
+func (r *ResultRepo) DeleteWithShift(ctx context.Context, id, tournamentID int64, deletedPlace int, deletedBy int64) error {
 	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
 		// Delete the result
-		_, err := tx.NewDelete().Model((*domain.Result)(nil)).Where("id = ?", id).Exec(ctx)
+		res, err := deleteResult(ctx, tx, id, deletedBy)
 		if err != nil {
 			return err
 		}
 
+		// Teams whose place moved up (their stats change too); 0 - player result
+		var shifted []int64
+
 		// Safety check: only shift if deletedPlace >= 1
-		if deletedPlace < 1 {
-			return nil
+		if deletedPlace >= 1 {
+			// Shift places down to fill the gap
+			_, err = tx.NewUpdate().
+				Model((*domain.Result)(nil)).
+				Set("place = place - 1").
+				Where("tournament_id = ?", tournamentID).
+				Where("place > ?", deletedPlace).
+				Where("place > 1"). // Ensure we never go below 1
+				Returning("COALESCE(team_id, 0)").
+				Exec(ctx, &shifted)
+			if err != nil {
+				return err
+			}
 		}
 
-		// Shift places down to fill the gap
-		_, err = tx.NewUpdate().
-			Model((*domain.Result)(nil)).
-			Set("place = place - 1").
-			Where("tournament_id = ?", tournamentID).
-			Where("place > ?", deletedPlace).
-			Where("place > 1"). // Ensure we never go below 1
-			Exec(ctx)
-		return err
+		shiftedTeamIDs := []int64{}
+		for _, teamID := range shifted {
+			if teamID != 0 {
+				shiftedTeamIDs = append(shiftedTeamIDs, teamID)
+			}
+		}
+
+		payload := resultPayload(res)
+		payload["shifted_team_ids"] = shiftedTeamIDs
+		return recordEvent(ctx, tx, domain.EventResultDeleted, domain.EntityResult, id, deletedBy, payload)
 	})
 }
+
+// deleteResult soft-deletes result and returns it as it was
+func deleteResult(ctx context.Context, tx bun.Tx, id, deletedBy int64) (*domain.Result, error) {
+	res := new(domain.Result)
+	if err := tx.NewSelect().Model(res).Where("id = ?", id).Scan(ctx); err != nil {
+		return nil, err
+	}
+	return res, softDelete(ctx, tx, (*domain.Result)(nil), id, deletedBy)
+}
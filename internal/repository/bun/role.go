@@ -0,0 +1,104 @@
+// internal/repository/bun/role.go
+package bunrepo
+
+import (
+	"context"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/uptrace/bun"
+)
+
+type RoleRepo struct {
+	db *bun.DB
+}
+
+func NewRoleRepo(db *bun.DB) *RoleRepo {
+	return &RoleRepo{db: db}
+}
+
+func (r *RoleRepo) Create(ctx context.Context, role *domain.RoleDef) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(role).Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		return insertAudit(ctx, tx, &domain.AuditEntry{
+			ActorID:    role.CreatedBy,
+			Action:     domain.AuditRoleCreate,
+			EntityType: domain.EntityRole,
+			EntityID:   role.ID,
+			Details:    rolePayload(role),
+		})
+	})
+}
+
+func (r *RoleRepo) GetByID(ctx context.Context, id int64) (*domain.RoleDef, error) {
+	role := new(domain.RoleDef)
+	err := r.db.NewSelect().Model(role).Where("id = ?", id).Scan(ctx)
+	return role, err
+}
+
+func (r *RoleRepo) GetByName(ctx context.Context, name string) (*domain.RoleDef, error) {
+	role := new(domain.RoleDef)
+	err := r.db.NewSelect().Model(role).Where("name = ?", name).Scan(ctx)
+	return role, err
+}
+
+func (r *RoleRepo) List(ctx context.Context) ([]*domain.RoleDef, error) {
+	var roles []*domain.RoleDef
+	err := r.db.NewSelect().Model(&roles).OrderExpr("builtin DESC, id ASC").Scan(ctx)
+	return roles, err
+}
+
+func (r *RoleRepo) Update(ctx context.Context, role *domain.RoleDef) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewUpdate().Model(role).WherePK().Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		return insertAudit(ctx, tx, &domain.AuditEntry{
+			ActorID:    actorOf(role.UpdatedBy, role.CreatedBy),
+			Action:     domain.AuditRoleUpdate,
+			EntityType: domain.EntityRole,
+			EntityID:   role.ID,
+			Details:    rolePayload(role),
+		})
+	})
+}
+
+func (r *RoleRepo) Delete(ctx context.Context, id int64, deletedBy int64) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		role := new(domain.RoleDef)
+		if err := tx.NewSelect().Model(role).Where("id = ?", id).For("UPDATE").Scan(ctx); err != nil {
+			return err
+		}
+		if role.Builtin {
+			return domain.ErrRoleBuiltin
+		}
+		used, err := tx.NewSelect().
+			Model((*domain.User)(nil)).
+			Where("role = ?", role.Name).
+			Exists(ctx)
+		if err != nil {
+			return err
+		}
+		if used {
+			return domain.ErrRoleInUse
+		}
+		if _, err := tx.NewDelete().Model((*domain.RoleDef)(nil)).Where("id = ?", id).Exec(ctx); err != nil {
+			return err
+		}
+		return insertAudit(ctx, tx, &domain.AuditEntry{
+			ActorID:    deletedBy,
+			Action:     domain.AuditRoleDelete,
+			EntityType: domain.EntityRole,
+			EntityID:   id,
+			Details:    rolePayload(role),
+		})
+	})
+}
+
+func rolePayload(role *domain.RoleDef) map[string]any {
+	return map[string]any{
+		"name":        role.Name,
+		"permissions": role.Permissions,
+	}
+}
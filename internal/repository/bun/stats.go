@@ -0,0 +1,221 @@
+// internal/repository/bun/stats.go
+package bunrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/eugene-twix/amber-bot/internal/repository"
+	"github.com/uptrace/bun"
+)
+
+const (
+	// frequentOpponentsLimit - how many teams to return in TeamStats.FrequentOpponents
+	frequentOpponentsLimit = 5
+	// frequentTeammatesLimit - how many members to return in TeamStats.FrequentTeammates
+	frequentTeammatesLimit = 5
+)
+
+type StatsRepo struct {
+	db *bun.DB
+}
+
+func NewStatsRepo(db *bun.DB) *StatsRepo {
+	return &StatsRepo{db: db}
+}
+
+func (r *StatsRepo) GetTeamStats(ctx context.Context, teamID, disciplineID int64) (*repository.TeamStats, error) {
+	stats := &repository.TeamStats{TeamID: teamID}
+
+	// Totals and podiums
+	var totals struct {
+		TotalGames int
+		First      int
+		Second     int
+		Third      int
+		BestPlace  sql.NullInt64
+		WorstPlace sql.NullInt64
+		AvgPlace   float64
+	}
+	err := r.db.NewRaw(`
+		SELECT
+			COUNT(r.id) as total_games,
+			COUNT(CASE WHEN r.place = 1 THEN 1 END) as first,
+			COUNT(CASE WHEN r.place = 2 THEN 1 END) as second,
+			COUNT(CASE WHEN r.place = 3 THEN 1 END) as third,
+			MIN(r.place) as best_place,
+			MAX(r.place) as worst_place,
+			COALESCE(AVG(r.place), 0) as avg_place
+		FROM results r
+		JOIN tournaments tr ON tr.id = r.tournament_id AND tr.deleted_at IS NULL
+		WHERE r.team_id = ? AND r.deleted_at IS NULL
+			AND (? = 0 OR tr.discipline_id = ?)
+	`, teamID, disciplineID, disciplineID).Scan(ctx, &totals)
+	if err != nil {
+		return nil, err
+	}
+	stats.TotalGames = totals.TotalGames
+	stats.Podiums = repository.PodiumCounts{First: totals.First, Second: totals.Second, Third: totals.Third}
+	stats.BestPlace = int(totals.BestPlace.Int64)
+	stats.WorstPlace = int(totals.WorstPlace.Int64)
+	stats.AvgPlace = totals.AvgPlace
+
+	// Monthly trend
+	err = r.db.NewRaw(`
+		SELECT
+			date_trunc('month', tr.date) as month,
+			COUNT(r.id) as games,
+			AVG(r.place) as avg_place
+		FROM results r
+		JOIN tournaments tr ON tr.id = r.tournament_id AND tr.deleted_at IS NULL
+		WHERE r.team_id = ? AND r.deleted_at IS NULL
+			AND (? = 0 OR tr.discipline_id = ?)
+		GROUP BY month
+		ORDER BY month ASC
+	`, teamID, disciplineID, disciplineID).Scan(ctx, &stats.MonthlyTrend)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attendance over all tournaments that have results
+	var attendance []struct {
+		Attended bool
+	}
+	err = r.db.NewRaw(`
+		SELECT EXISTS (
+			SELECT 1 FROM results r
+			WHERE r.tournament_id = tr.id AND r.team_id = ? AND r.deleted_at IS NULL
+		) as attended
+		FROM tournaments tr
+		WHERE tr.deleted_at IS NULL
+			AND tr.participant_mode = 'team'
+			AND (? = 0 OR tr.discipline_id = ?)
+			AND EXISTS (SELECT 1 FROM results r WHERE r.tournament_id = tr.id AND r.deleted_at IS NULL)
+		ORDER BY tr.date ASC, tr.id ASC
+	`, teamID, disciplineID, disciplineID).Scan(ctx, &attendance)
+	if err != nil {
+		return nil, err
+	}
+	attended := make([]bool, len(attendance))
+	for i, a := range attendance {
+		attended[i] = a.Attended
+	}
+	stats.CurrentStreak, stats.LongestStreak = repository.AttendanceStreaks(attended)
+
+	// Rank among rated teams, same order as GetTeamRating
+	var rank struct {
+		Rank  int
+		Total int
+	}
+	err = r.db.NewRaw(`
+		WITH rating AS (
+			SELECT
+				t.id,
+				COUNT(CASE WHEN r.place = 1 THEN 1 END) as wins,
+				AVG(r.place) as avg_place
+			FROM teams t
+			JOIN results r ON t.id = r.team_id AND r.deleted_at IS NULL
+			JOIN tournaments tr ON tr.id = r.tournament_id AND tr.deleted_at IS NULL
+			WHERE t.deleted_at IS NULL
+				AND (? = 0 OR tr.discipline_id = ?)
+			GROUP BY t.id
+		), ranked AS (
+			SELECT
+				id,
+				RANK() OVER (ORDER BY wins DESC, avg_place ASC) as rank,
+				COUNT(*) OVER () as total
+			FROM rating
+		)
+		SELECT rank, total FROM ranked WHERE id = ?
+	`, disciplineID, disciplineID, teamID).Scan(ctx, &rank)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if rank.Total > 0 {
+		stats.Rank = rank.Rank
+		stats.RatedTeams = rank.Total
+		stats.Percentile = float64(rank.Total-rank.Rank+1) / float64(rank.Total) * 100
+	}
+
+	// Frequent opponents
+	err = r.db.NewRaw(`
+		SELECT
+			o.team_id as team_id,
+			t.name as team_name,
+			COUNT(*) as games
+		FROM results me
+		JOIN results o ON o.tournament_id = me.tournament_id
+			AND o.team_id <> me.team_id
+			AND o.deleted_at IS NULL
+		JOIN teams t ON t.id = o.team_id AND t.deleted_at IS NULL
+		JOIN tournaments tr ON tr.id = me.tournament_id AND tr.deleted_at IS NULL
+		WHERE me.team_id = ? AND me.deleted_at IS NULL
+			AND (? = 0 OR tr.discipline_id = ?)
+		GROUP BY o.team_id, t.name
+		ORDER BY games DESC, t.name ASC
+		LIMIT ?
+	`, teamID, disciplineID, disciplineID, frequentOpponentsLimit).Scan(ctx, &stats.FrequentOpponents)
+	if err != nil {
+		return nil, err
+	}
+
+	// Frequent teammates: members on the roster at the team's tournaments,
+	// removed members included
+	err = r.db.NewRaw(`
+		SELECT
+			m.id as member_id,
+			m.name as name,
+			COUNT(*) as games
+		FROM members m
+		JOIN results res ON res.team_id = m.team_id AND res.deleted_at IS NULL
+		JOIN tournaments tr ON tr.id = res.tournament_id AND tr.deleted_at IS NULL
+		WHERE m.team_id = ?
+			AND tr.date >= m.joined_at::date
+			AND (m.deleted_at IS NULL OR tr.date < m.deleted_at::date)
+			AND (? = 0 OR tr.discipline_id = ?)
+		GROUP BY m.id, m.name
+		ORDER BY games DESC, m.name ASC
+		LIMIT ?
+	`, teamID, disciplineID, disciplineID, frequentTeammatesLimit).Scan(ctx, &stats.FrequentTeammates)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (r *StatsRepo) GetVenueStats(ctx context.Context, venueID, disciplineID int64) (*repository.VenueStats, error) {
+	var row struct {
+		TournamentsHosted int
+		AvgAttendance     float64
+		LastTournament    sql.NullTime
+	}
+	err := r.db.NewRaw(`
+		SELECT
+			COUNT(*) as tournaments_hosted,
+			COALESCE(AVG(NULLIF(att.teams, 0)), 0) as avg_attendance,
+			MAX(tr.date) as last_tournament
+		FROM tournaments tr
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) as teams
+			FROM results r
+			WHERE r.tournament_id = tr.id AND r.deleted_at IS NULL
+		) att ON true
+		WHERE tr.venue_id = ? AND tr.deleted_at IS NULL
+			AND (? = 0 OR tr.discipline_id = ?)
+	`, venueID, disciplineID, disciplineID).Scan(ctx, &row)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &repository.VenueStats{
+		VenueID:           venueID,
+		TournamentsHosted: row.TournamentsHosted,
+		AvgAttendance:     row.AvgAttendance,
+	}
+	if row.LastTournament.Valid {
+		stats.LastTournament = &row.LastTournament.Time
+	}
+	return stats, nil
+}
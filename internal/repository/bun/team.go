@@ -3,11 +3,35 @@ package bunrepo
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
 	"github.com/uptrace/bun"
 )
 
+// resolveAliasSQL finds the team an alias points to, following merges
+// (alias of A, A merged into B, B merged into C → C)
+const resolveAliasSQL = `
+	WITH RECURSIVE chain(team_id, depth) AS (
+		(
+			SELECT team_id, 0
+			FROM team_aliases
+			WHERE name = ?
+			ORDER BY valid_to DESC NULLS FIRST
+			LIMIT 1
+		)
+		UNION ALL
+		SELECT m.target_team_id, c.depth + 1
+		FROM chain c
+		JOIN team_merges m ON m.source_team_id = c.team_id AND m.reverted_at IS NULL
+		WHERE c.depth < 10
+	)
+	SELECT team_id FROM chain ORDER BY depth DESC LIMIT 1`
+
 type TeamRepo struct {
 	db *bun.DB
 }
@@ -17,8 +41,12 @@ func NewTeamRepo(db *bun.DB) *TeamRepo {
 }
 
 func (r *TeamRepo) Create(ctx context.Context, team *domain.Team) error {
-	_, err := r.db.NewInsert().Model(team).Returning("*").Exec(ctx)
-	return err
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(team).Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventTeamCreated, domain.EntityTeam, team.ID, team.CreatedBy, teamPayload(team))
+	})
 }
 
 func (r *TeamRepo) GetByID(ctx context.Context, id int64) (*domain.Team, error) {
@@ -30,6 +58,17 @@ func (r *TeamRepo) GetByID(ctx context.Context, id int64) (*domain.Team, error)
 func (r *TeamRepo) GetByName(ctx context.Context, name string) (*domain.Team, error) {
 	team := new(domain.Team)
 	err := r.db.NewSelect().Model(team).Where("name = ?", name).Where("deleted_at IS NULL").Scan(ctx)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return team, err
+	}
+
+	// Fall back to aliases and previous names
+	team = new(domain.Team)
+	err = r.db.NewSelect().
+		Model(team).
+		Where("id = ("+resolveAliasSQL+")", name).
+		Where("deleted_at IS NULL").
+		Scan(ctx)
 	return team, err
 }
 
@@ -39,12 +78,109 @@ func (r *TeamRepo) List(ctx context.Context) ([]*domain.Team, error) {
 	return teams, err
 }
 
+func (r *TeamRepo) Search(ctx context.Context, query string) ([]*domain.Team, error) {
+	pattern := "%" + escapeLike(query) + "%"
+
+	var teams []*domain.Team
+	err := r.db.NewSelect().
+		Model(&teams).
+		Where("deleted_at IS NULL").
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("name ILIKE ?", pattern).
+				WhereOr("id IN (SELECT team_id FROM team_aliases WHERE name ILIKE ?)", pattern)
+		}).
+		Order("name ASC").
+		Scan(ctx)
+	return teams, err
+}
+
 func (r *TeamRepo) Update(ctx context.Context, team *domain.Team) error {
-	_, err := r.db.NewUpdate().Model(team).WherePK().Returning("*").Exec(ctx)
-	return err
+	return r.Rename(ctx, team, time.Now())
+}
+
+func (r *TeamRepo) Rename(ctx context.Context, team *domain.Team, effectiveFrom time.Time) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		current := new(domain.Team)
+		if err := tx.NewSelect().Model(current).Where("id = ?", team.ID).For("UPDATE").Scan(ctx); err != nil {
+			return err
+		}
+
+		if current.Name != team.Name {
+			// Old name was in use since the previous rename (or since the team was created)
+			var lastRename sql.NullTime
+			err := tx.NewSelect().
+				Model((*domain.TeamAlias)(nil)).
+				ColumnExpr("MAX(valid_to)").
+				Where("team_id = ?", team.ID).
+				Where("kind = ?", domain.AliasKindRename).
+				Scan(ctx, &lastRename)
+			if err != nil {
+				return err
+			}
+
+			validFrom := current.CreatedAt
+			if lastRename.Valid {
+				validFrom = lastRename.Time
+			}
+			if !effectiveFrom.After(validFrom) {
+				return repository.ErrInvalidEffectiveDate
+			}
+
+			var createdBy int64
+			if team.UpdatedBy != nil {
+				createdBy = *team.UpdatedBy
+			}
+
+			alias := &domain.TeamAlias{
+				TeamID:    team.ID,
+				Name:      current.Name,
+				Kind:      domain.AliasKindRename,
+				ValidFrom: &validFrom,
+				ValidTo:   &effectiveFrom,
+				CreatedBy: createdBy,
+			}
+			if _, err := tx.NewInsert().Model(alias).Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.NewUpdate().Model(team).WherePK().Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+
+		payload := teamPayload(team)
+		payload["previous_name"] = current.Name
+		return recordEvent(ctx, tx, domain.EventTeamUpdated, domain.EntityTeam, team.ID,
+			actorOf(team.UpdatedBy, team.CreatedBy), payload)
+	})
+}
+
+func (r *TeamRepo) GetNameHistory(ctx context.Context, teamID int64) ([]*domain.TeamAlias, error) {
+	var aliases []*domain.TeamAlias
+	err := r.db.NewSelect().
+		Model(&aliases).
+		Where("team_id = ?", teamID).
+		Where("kind = ?", domain.AliasKindRename).
+		Order("valid_from ASC").
+		Scan(ctx)
+	return aliases, err
+}
+
+func (r *TeamRepo) Delete(ctx context.Context, id, deletedBy int64) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		team := new(domain.Team)
+		if err := tx.NewSelect().Model(team).Where("id = ?", id).Scan(ctx); err != nil {
+			return err
+		}
+		if err := softDelete(ctx, tx, (*domain.Team)(nil), id, deletedBy); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventTeamDeleted, domain.EntityTeam, id, deletedBy, teamPayload(team))
+	})
 }
 
-func (r *TeamRepo) Delete(ctx context.Context, id int64) error {
-	_, err := r.db.NewDelete().Model((*domain.Team)(nil)).Where("id = ?", id).Exec(ctx)
-	return err
+// escapeLike escapes LIKE wildcards in user input
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
 }
@@ -0,0 +1,381 @@
+// internal/repository/bun/team_merge.go
+package bunrepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+	"github.com/uptrace/bun"
+)
+
+func (r *TeamRepo) Merge(ctx context.Context, sourceID, targetID, mergedBy int64) (*domain.TeamMerge, error) {
+	if sourceID == targetID {
+		return nil, repository.ErrSameTeam
+	}
+
+	merge := &domain.TeamMerge{
+		SourceTeamID:   sourceID,
+		TargetTeamID:   targetID,
+		MergedBy:       mergedBy,
+		MovedMemberIDs: []int64{},
+		MovedResultIDs: []int64{},
+		MovedAliasIDs:  []int64{},
+		Conflicts:      []domain.MergeConflict{},
+	}
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Lock both teams so concurrent merges/updates can't interleave
+		source := new(domain.Team)
+		if err := tx.NewSelect().Model(source).Where("id = ?", sourceID).For("UPDATE").Scan(ctx); err != nil {
+			return err
+		}
+		target := new(domain.Team)
+		if err := tx.NewSelect().Model(target).Where("id = ?", targetID).For("UPDATE").Scan(ctx); err != nil {
+			return err
+		}
+		merge.SourceName = source.Name
+
+		now := time.Now()
+
+		// Resolve conflicts: both teams played the same tournament.
+		// Keep the best place on the target result, soft-delete the source one.
+		err := tx.NewRaw(`
+			SELECT
+				s.tournament_id,
+				s.id AS source_result_id,
+				t.id AS target_result_id,
+				s.place AS source_place,
+				t.place AS target_place
+			FROM results s
+			JOIN results t ON t.tournament_id = s.tournament_id
+				AND t.team_id = ?
+				AND t.deleted_at IS NULL
+			WHERE s.team_id = ? AND s.deleted_at IS NULL
+		`, targetID, sourceID).Scan(ctx, &merge.Conflicts)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range merge.Conflicts {
+			if c.SourcePlace < c.TargetPlace {
+				_, err := tx.NewUpdate().
+					Model((*domain.Result)(nil)).
+					Set("place = ?", c.SourcePlace).
+					Set("updated_at = ?", now).
+					Set("updated_by = ?", mergedBy).
+					Set("version = version + 1").
+					Where("id = ?", c.TargetResultID).
+					Exec(ctx)
+				if err != nil {
+					return err
+				}
+			}
+
+			_, err := tx.NewUpdate().
+				Model((*domain.Result)(nil)).
+				Set("deleted_at = ?", now).
+				Set("deleted_by = ?", mergedBy).
+				Set("version = version + 1").
+				Where("id = ?", c.SourceResultID).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Move remaining results
+		_, err = tx.NewUpdate().
+			Model((*domain.Result)(nil)).
+			Set("team_id = ?", targetID).
+			Set("updated_at = ?", now).
+			Set("updated_by = ?", mergedBy).
+			Set("version = version + 1").
+			Where("team_id = ?", sourceID).
+			Where("deleted_at IS NULL").
+			Returning("id").
+			Exec(ctx, &merge.MovedResultIDs)
+		if err != nil {
+			return err
+		}
+
+		// Move members
+		_, err = tx.NewUpdate().
+			Model((*domain.Member)(nil)).
+			Set("team_id = ?", targetID).
+			Set("updated_at = ?", now).
+			Set("updated_by = ?", mergedBy).
+			Set("version = version + 1").
+			Where("team_id = ?", sourceID).
+			Where("deleted_at IS NULL").
+			Returning("id").
+			Exec(ctx, &merge.MovedMemberIDs)
+		if err != nil {
+			return err
+		}
+
+		// Merge aliases of the source (from earlier merges) now point to target.
+		// Previous names stay with the source: they describe its own history
+		// and GetByName follows the merge chain for them.
+		_, err = tx.NewUpdate().
+			Model((*domain.TeamAlias)(nil)).
+			Set("team_id = ?", targetID).
+			Where("team_id = ?", sourceID).
+			Where("kind = ?", domain.AliasKindMerge).
+			Returning("id").
+			Exec(ctx, &merge.MovedAliasIDs)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.NewInsert().Model(merge).Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+
+		// Old name keeps resolving via GetByName
+		alias := &domain.TeamAlias{
+			TeamID:    targetID,
+			Name:      source.Name,
+			Kind:      domain.AliasKindMerge,
+			MergeID:   &merge.ID,
+			CreatedBy: mergedBy,
+		}
+		if _, err := tx.NewInsert().Model(alias).Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		merge.AliasID = &alias.ID
+
+		_, err = tx.NewUpdate().
+			Model(merge).
+			Column("alias_id").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Soft-delete source
+		_, err = tx.NewUpdate().
+			Model((*domain.Team)(nil)).
+			Set("deleted_at = ?", now).
+			Set("deleted_by = ?", mergedBy).
+			Set("version = version + 1").
+			Where("id = ?", sourceID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = recordEvent(ctx, tx, domain.EventTeamMerged, domain.EntityTeam, targetID, mergedBy, map[string]any{
+			"merge_id":       merge.ID,
+			"source_team_id": sourceID,
+			"target_team_id": targetID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return insertAudit(ctx, tx, &domain.AuditEntry{
+			ActorID:    mergedBy,
+			Action:     domain.AuditTeamMerge,
+			EntityType: domain.EntityTeam,
+			EntityID:   targetID,
+			Details: map[string]any{
+				"merge_id":       merge.ID,
+				"source_team_id": sourceID,
+				"source_name":    source.Name,
+				"target_name":    target.Name,
+				"members":        len(merge.MovedMemberIDs),
+				"results":        len(merge.MovedResultIDs),
+				"conflicts":      len(merge.Conflicts),
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merge, nil
+}
+
+func (r *TeamRepo) RevertMerge(ctx context.Context, mergeID, revertedBy int64) (*domain.TeamMerge, error) {
+	merge := new(domain.TeamMerge)
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := tx.NewSelect().Model(merge).Where("id = ?", mergeID).For("UPDATE").Scan(ctx); err != nil {
+			return err
+		}
+		if merge.IsReverted() {
+			return repository.ErrMergeAlreadyReverted
+		}
+
+		// A later merge of the target moved our rows on; it has to be reverted first
+		chained, err := tx.NewSelect().
+			Model((*domain.TeamMerge)(nil)).
+			Where("source_team_id = ?", merge.TargetTeamID).
+			Where("id > ?", merge.ID).
+			Where("reverted_at IS NULL").
+			Exists(ctx)
+		if err != nil {
+			return err
+		}
+		if chained {
+			return repository.ErrMergeChained
+		}
+
+		now := time.Now()
+
+		// Restore source team
+		_, err = tx.NewUpdate().
+			Model((*domain.Team)(nil)).
+			Set("deleted_at = NULL").
+			Set("deleted_by = NULL").
+			Set("updated_at = ?", now).
+			Set("updated_by = ?", revertedBy).
+			Set("version = version + 1").
+			Where("id = ?", merge.SourceTeamID).
+			WhereAllWithDeleted().
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Rows deleted on the target since the merge go back too: they were
+		// the source's. A row that is gone or on another team fails the revert.
+		if len(merge.MovedMemberIDs) > 0 {
+			res, err := tx.NewUpdate().
+				Model((*domain.Member)(nil)).
+				Set("team_id = ?", merge.SourceTeamID).
+				Set("updated_at = ?", now).
+				Set("updated_by = ?", revertedBy).
+				Set("version = version + 1").
+				Where("id IN (?)", bun.In(merge.MovedMemberIDs)).
+				Where("team_id = ?", merge.TargetTeamID).
+				WhereAllWithDeleted().
+				Exec(ctx)
+			if err := expectRows(res, err, len(merge.MovedMemberIDs)); err != nil {
+				return err
+			}
+		}
+
+		if len(merge.MovedResultIDs) > 0 {
+			res, err := tx.NewUpdate().
+				Model((*domain.Result)(nil)).
+				Set("team_id = ?", merge.SourceTeamID).
+				Set("updated_at = ?", now).
+				Set("updated_by = ?", revertedBy).
+				Set("version = version + 1").
+				Where("id IN (?)", bun.In(merge.MovedResultIDs)).
+				Where("team_id = ?", merge.TargetTeamID).
+				WhereAllWithDeleted().
+				Exec(ctx)
+			if err := expectRows(res, err, len(merge.MovedResultIDs)); err != nil {
+				return err
+			}
+		}
+
+		for _, c := range merge.Conflicts {
+			res, err := tx.NewUpdate().
+				Model((*domain.Result)(nil)).
+				Set("deleted_at = NULL").
+				Set("deleted_by = NULL").
+				Set("updated_at = ?", now).
+				Set("updated_by = ?", revertedBy).
+				Set("version = version + 1").
+				Where("id = ?", c.SourceResultID).
+				Where("team_id = ?", merge.SourceTeamID).
+				WhereAllWithDeleted().
+				Exec(ctx)
+			if err := expectRows(res, err, 1); err != nil {
+				return err
+			}
+
+			res, err = tx.NewUpdate().
+				Model((*domain.Result)(nil)).
+				Set("place = ?", c.TargetPlace).
+				Set("updated_at = ?", now).
+				Set("updated_by = ?", revertedBy).
+				Set("version = version + 1").
+				Where("id = ?", c.TargetResultID).
+				Where("team_id = ?", merge.TargetTeamID).
+				WhereAllWithDeleted().
+				Exec(ctx)
+			if err := expectRows(res, err, 1); err != nil {
+				return err
+			}
+		}
+
+		if len(merge.MovedAliasIDs) > 0 {
+			_, err = tx.NewUpdate().
+				Model((*domain.TeamAlias)(nil)).
+				Set("team_id = ?", merge.SourceTeamID).
+				Where("id IN (?)", bun.In(merge.MovedAliasIDs)).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		if merge.AliasID != nil {
+			_, err = tx.NewDelete().
+				Model((*domain.TeamAlias)(nil)).
+				Where("id = ?", *merge.AliasID).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		merge.RevertedAt = &now
+		merge.RevertedBy = &revertedBy
+		_, err = tx.NewUpdate().
+			Model(merge).
+			Column("reverted_at", "reverted_by").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = recordEvent(ctx, tx, domain.EventTeamMergeReverted, domain.EntityTeam, merge.SourceTeamID, revertedBy, map[string]any{
+			"merge_id":       merge.ID,
+			"source_team_id": merge.SourceTeamID,
+			"target_team_id": merge.TargetTeamID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return insertAudit(ctx, tx, &domain.AuditEntry{
+			ActorID:    revertedBy,
+			Action:     domain.AuditTeamMergeRevert,
+			EntityType: domain.EntityTeam,
+			EntityID:   merge.SourceTeamID,
+			Details: map[string]any{
+				"merge_id":       merge.ID,
+				"target_team_id": merge.TargetTeamID,
+				"source_name":    merge.SourceName,
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merge, nil
+}
+
+// expectRows turns an update that touched other than want rows into ErrMergeChanged
+func expectRows(res sql.Result, err error, want int) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n != int64(want) {
+		return repository.ErrMergeChanged
+	}
+	return nil
+}
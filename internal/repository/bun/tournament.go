@@ -3,8 +3,10 @@ package bunrepo
 
 import (
 	"context"
+	"time"
 
 	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
 	"github.com/uptrace/bun"
 )
 
@@ -16,9 +18,23 @@ func NewTournamentRepo(db *bun.DB) *TournamentRepo {
 	return &TournamentRepo{db: db}
 }
 
+// Create saves tournament; DisciplineID 0 means the default discipline
 func (r *TournamentRepo) Create(ctx context.Context, t *domain.Tournament) error {
-	_, err := r.db.NewInsert().Model(t).Returning("*").Exec(ctx)
-	return err
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if t.DisciplineID == 0 {
+			err := tx.NewSelect().
+				Model((*domain.Discipline)(nil)).
+				ColumnExpr("MIN(id)").
+				Scan(ctx, &t.DisciplineID)
+			if err != nil {
+				return err
+			}
+		}
+		if _, err := tx.NewInsert().Model(t).Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventTournamentCreated, domain.EntityTournament, t.ID, t.CreatedBy, tournamentPayload(t))
+	})
 }
 
 func (r *TournamentRepo) GetByID(ctx context.Context, id int64) (*domain.Tournament, error) {
@@ -33,23 +49,134 @@ func (r *TournamentRepo) List(ctx context.Context) ([]*domain.Tournament, error)
 	return tournaments, err
 }
 
-func (r *TournamentRepo) ListRecent(ctx context.Context, limit int) ([]*domain.Tournament, error) {
+// Start and expected end of a tournament in SQL; see domain.Tournament.Start/End.
+// Both take the default timezone, end also the default duration in minutes.
+const (
+	tournamentStartSQL = `COALESCE(starts_at, date::timestamp AT TIME ZONE COALESCE(NULLIF(timezone, ''), ?))`
+	tournamentEndSQL   = `CASE WHEN starts_at IS NULL
+		THEN (date + 1)::timestamp AT TIME ZONE COALESCE(NULLIF(timezone, ''), ?)
+		ELSE starts_at + make_interval(mins => CASE WHEN duration_minutes > 0 THEN duration_minutes ELSE ? END)
+	END`
+)
+
+func (r *TournamentRepo) ListByPhase(ctx context.Context, f repository.TournamentFilter) ([]*domain.Tournament, error) {
+	tz := f.DefaultTimezone
+	defMinutes := int(domain.DefaultTournamentDuration.Minutes())
+
 	var tournaments []*domain.Tournament
-	err := r.db.NewSelect().
+	q := r.db.NewSelect().Model(&tournaments).Where("deleted_at IS NULL")
+
+	asc := true
+	switch f.Phase {
+	case domain.PhaseUpcoming:
+		q = q.Where("status = ? AND "+tournamentStartSQL+" > ?", domain.TournamentPlanned, tz, f.Now)
+	case domain.PhaseOngoing:
+		q = q.Where("(status = ? OR (status = ? AND "+tournamentStartSQL+" <= ? AND "+tournamentEndSQL+" > ?))",
+			domain.TournamentInProgress, domain.TournamentPlanned, tz, f.Now, tz, defMinutes, f.Now)
+	case domain.PhaseFinished:
+		q = q.Where("(status = ? OR (status = ? AND "+tournamentEndSQL+" <= ?))",
+			domain.TournamentFinalized, domain.TournamentPlanned, tz, defMinutes, f.Now)
+		asc = false
+	case repository.PhaseStarted:
+		q = q.Where("(status <> ? OR "+tournamentStartSQL+" <= ?)", domain.TournamentPlanned, tz, f.Now)
+		asc = false
+	}
+
+	if len(f.Statuses) > 0 {
+		q = q.Where("status IN (?)", bun.In(f.Statuses))
+	}
+	if f.ParticipantMode != "" {
+		q = q.Where("participant_mode = ?", f.ParticipantMode)
+	}
+	if f.DisciplineID > 0 {
+		q = q.Where("discipline_id = ?", f.DisciplineID)
+	}
+	if f.VenueID > 0 {
+		q = q.Where("venue_id = ?", f.VenueID)
+	}
+	if f.TeamID > 0 {
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("id IN (SELECT tournament_id FROM tournament_registrations WHERE team_id = ?)", f.TeamID).
+				WhereOr("id IN (SELECT tournament_id FROM results WHERE team_id = ? AND deleted_at IS NULL)", f.TeamID)
+		})
+	}
+
+	if asc {
+		q = q.OrderExpr(tournamentStartSQL+" ASC, id ASC", tz)
+	} else {
+		q = q.OrderExpr(tournamentStartSQL+" DESC, id DESC", tz)
+	}
+	if f.Limit > 0 {
+		q = q.Limit(f.Limit)
+	}
+
+	err := q.Scan(ctx)
+	return tournaments, err
+}
+
+func (r *TournamentRepo) ListSince(ctx context.Context, from time.Time, teamID int64) ([]*domain.Tournament, error) {
+	var tournaments []*domain.Tournament
+	q := r.db.NewSelect().
 		Model(&tournaments).
 		Where("deleted_at IS NULL").
-		Order("date DESC").
-		Limit(limit).
-		Scan(ctx)
+		Where("date >= ?", from.Format("2006-01-02")).
+		Order("date ASC", "id ASC")
+	if teamID > 0 {
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("id IN (SELECT tournament_id FROM tournament_registrations WHERE team_id = ?)", teamID).
+				WhereOr("id IN (SELECT tournament_id FROM results WHERE team_id = ? AND deleted_at IS NULL)", teamID)
+		})
+	}
+	err := q.Scan(ctx)
 	return tournaments, err
 }
 
 func (r *TournamentRepo) Update(ctx context.Context, t *domain.Tournament) error {
-	_, err := r.db.NewUpdate().Model(t).WherePK().Returning("*").Exec(ctx)
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewUpdate().Model(t).WherePK().Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventTournamentUpdated, domain.EntityTournament, t.ID,
+			actorOf(t.UpdatedBy, t.CreatedBy), tournamentPayload(t))
+	})
+}
+
+func (r *TournamentRepo) Delete(ctx context.Context, id, deletedBy int64) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		t := new(domain.Tournament)
+		if err := tx.NewSelect().Model(t).Where("id = ?", id).Scan(ctx); err != nil {
+			return err
+		}
+		if err := softDelete(ctx, tx, (*domain.Tournament)(nil), id, deletedBy); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventTournamentDeleted, domain.EntityTournament, id, deletedBy, tournamentPayload(t))
+	})
+}
+
+func (r *TournamentRepo) Register(ctx context.Context, reg *domain.Registration) error {
+	_, err := r.db.NewInsert().Model(reg).On("CONFLICT DO NOTHING").Exec(ctx)
 	return err
 }
 
-func (r *TournamentRepo) Delete(ctx context.Context, id int64) error {
-	_, err := r.db.NewDelete().Model((*domain.Tournament)(nil)).Where("id = ?", id).Exec(ctx)
+func (r *TournamentRepo) Unregister(ctx context.Context, tournamentID, teamID int64) error {
+	_, err := r.db.NewDelete().
+		Model((*domain.Registration)(nil)).
+		Where("tournament_id = ?", tournamentID).
+		Where("team_id = ?", teamID).
+		Exec(ctx)
 	return err
 }
+
+func (r *TournamentRepo) ListRegistrations(ctx context.Context, tournamentID int64) ([]*domain.Registration, error) {
+	var regs []*domain.Registration
+	err := r.db.NewSelect().
+		Model(&regs).
+		Relation("Team").
+		Where("reg.tournament_id = ?", tournamentID).
+		Order("reg.registered_at ASC").
+		Scan(ctx)
+	return regs, err
+}
@@ -3,11 +3,18 @@ package bunrepo
 
 import (
 	"context"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
 	"github.com/uptrace/bun"
 )
 
+// lastSeenPrecision limits last_seen_at writes of session requests to one a minute
+const lastSeenPrecision = time.Minute
+
 type UserRepo struct {
 	db *bun.DB
 }
@@ -16,23 +23,40 @@ func NewUserRepo(db *bun.DB) *UserRepo {
 	return &UserRepo{db: db}
 }
 
-func (r *UserRepo) GetOrCreate(ctx context.Context, telegramID int64, username string) (*domain.User, error) {
+func (r *UserRepo) GetOrCreate(ctx context.Context, telegramID int64, username, firstName, lastName string) (*domain.User, error) {
+	now := time.Now()
 	user := &domain.User{
 		TelegramID: telegramID,
 		Username:   username,
+		FirstName:  firstName,
+		LastName:   lastName,
 		Role:       domain.RoleViewer,
+		LastSeenAt: &now,
 	}
 
 	_, err := r.db.NewInsert().
 		Model(user).
 		On("CONFLICT (telegram_id) DO UPDATE").
 		Set("username = EXCLUDED.username").
+		Set("first_name = EXCLUDED.first_name").
+		Set("last_name = EXCLUDED.last_name").
+		Set("last_seen_at = EXCLUDED.last_seen_at").
 		Returning("*").
 		Exec(ctx)
 
 	return user, err
 }
 
+func (r *UserRepo) TouchLastSeen(ctx context.Context, telegramID int64, at time.Time) error {
+	_, err := r.db.NewUpdate().
+		Model((*domain.User)(nil)).
+		Set("last_seen_at = ?", at).
+		Where("telegram_id = ?", telegramID).
+		Where("last_seen_at IS NULL OR last_seen_at < ?", at.Add(-lastSeenPrecision)).
+		Exec(ctx)
+	return err
+}
+
 func (r *UserRepo) GetByTelegramID(ctx context.Context, telegramID int64) (*domain.User, error) {
 	user := new(domain.User)
 	err := r.db.NewSelect().
@@ -42,20 +66,175 @@ func (r *UserRepo) GetByTelegramID(ctx context.Context, telegramID int64) (*doma
 	return user, err
 }
 
-func (r *UserRepo) UpdateRole(ctx context.Context, telegramID int64, role domain.Role) error {
-	_, err := r.db.NewUpdate().
+// UpdateRole changes the role unless that leaves no unblocked admins (domain.ErrLastAdmin)
+func (r *UserRepo) UpdateRole(ctx context.Context, telegramID int64, role domain.Role, changedBy int64) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return updateRole(ctx, tx, telegramID, role, changedBy)
+	})
+}
+
+// lockRoles serializes role changes: two admins demoting each other must
+// not both pass the last admin check
+func lockRoles(ctx context.Context, tx bun.Tx) error {
+	_, err := tx.NewRaw("SELECT pg_advisory_xact_lock(hashtext(?))", "users:roles").Exec(ctx)
+	return err
+}
+
+// updateRole is UpdateRole inside tx; every role change goes through it
+// to get the lock, the last admin check, audit and the event
+func updateRole(ctx context.Context, tx bun.Tx, telegramID int64, role domain.Role, changedBy int64) error {
+	if err := lockRoles(ctx, tx); err != nil {
+		return err
+	}
+
+	user := new(domain.User)
+	if err := tx.NewSelect().Model(user).Where("telegram_id = ?", telegramID).Scan(ctx); err != nil {
+		return err
+	}
+	if user.Role == role {
+		return nil
+	}
+
+	if user.Role == domain.RoleAdmin {
+		// Only admins able to sign in count: blocked ones can't fix anything
+		others, err := tx.NewSelect().
+			Model((*domain.User)(nil)).
+			Where("role = ?", domain.RoleAdmin).
+			Where("telegram_id <> ?", telegramID).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("blocked_at IS NULL").
+					WhereOr("blocked_until <= ?", time.Now())
+			}).
+			Count(ctx)
+		if err != nil {
+			return err
+		}
+		if others == 0 {
+			return domain.ErrLastAdmin
+		}
+	}
+
+	if _, err := tx.NewUpdate().
 		Model((*domain.User)(nil)).
 		Set("role = ?", role).
 		Where("telegram_id = ?", telegramID).
-		Exec(ctx)
-	return err
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	details := map[string]any{"old_role": user.Role, "role": role}
+	if err := insertAudit(ctx, tx, &domain.AuditEntry{
+		ActorID:    changedBy,
+		Action:     domain.AuditUserRoleChange,
+		EntityType: domain.EntityUser,
+		EntityID:   telegramID,
+		Details:    details,
+	}); err != nil {
+		return err
+	}
+	return recordEvent(ctx, tx, domain.EventUserRoleChanged, domain.EntityUser, telegramID, changedBy, details)
 }
 
-func (r *UserRepo) List(ctx context.Context) ([]*domain.User, error) {
+func (r *UserRepo) List(ctx context.Context, filter repository.UserFilter) ([]*domain.User, int, error) {
 	var users []*domain.User
-	err := r.db.NewSelect().
+	q := r.db.NewSelect().
 		Model(&users).
-		Order("created_at DESC").
-		Scan(ctx)
-	return users, err
+		Order("created_at DESC")
+
+	if query := strings.TrimPrefix(strings.TrimSpace(filter.Query), "@"); query != "" {
+		pattern := "%" + escapeLike(query) + "%"
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			q = q.
+				Where("username ILIKE ?", pattern).
+				WhereOr("(first_name || ' ' || last_name) ILIKE ?", pattern)
+			if id, err := strconv.ParseInt(query, 10, 64); err == nil {
+				q = q.WhereOr("telegram_id = ?", id)
+			}
+			return q
+		})
+	}
+	if filter.Role != "" {
+		q = q.Where("role = ?", filter.Role)
+	}
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit).Offset(filter.Offset)
+	}
+
+	total, err := q.ScanAndCount(ctx)
+	return users, total, err
+}
+
+func (r *UserRepo) GetActivity(ctx context.Context, telegramID int64) (*repository.UserActivity, error) {
+	activity := new(repository.UserActivity)
+	counts := []struct {
+		dest   *int
+		model  any
+		column string
+	}{
+		{&activity.ResultsRecorded, (*domain.Result)(nil), "recorded_by"},
+		{&activity.TeamsCreated, (*domain.Team)(nil), "created_by"},
+		{&activity.MembersCreated, (*domain.Member)(nil), "created_by"},
+		{&activity.TournamentsCreated, (*domain.Tournament)(nil), "created_by"},
+		{&activity.VenuesCreated, (*domain.Venue)(nil), "created_by"},
+		{&activity.PlayersCreated, (*domain.Player)(nil), "created_by"},
+	}
+	for _, c := range counts {
+		n, err := r.db.NewSelect().
+			Model(c.model).
+			Where("? = ?", bun.Ident(c.column), telegramID).
+			WhereAllWithDeleted().
+			Count(ctx)
+		if err != nil {
+			return nil, err
+		}
+		*c.dest = n
+	}
+	return activity, nil
+}
+
+func (r *UserRepo) Block(ctx context.Context, telegramID int64, reason string, until *time.Time, blockedBy int64) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewUpdate().
+			Model((*domain.User)(nil)).
+			Set("blocked_at = ?", time.Now()).
+			Set("blocked_until = ?", until).
+			Set("blocked_reason = ?", reason).
+			Set("blocked_by = ?", blockedBy).
+			Where("telegram_id = ?", telegramID).
+			Exec(ctx); err != nil {
+			return err
+		}
+		return insertAudit(ctx, tx, &domain.AuditEntry{
+			ActorID:    blockedBy,
+			Action:     domain.AuditUserBlock,
+			EntityType: domain.EntityUser,
+			EntityID:   telegramID,
+			Details: map[string]any{
+				"reason": reason,
+				"until":  until,
+			},
+		})
+	})
+}
+
+func (r *UserRepo) Unblock(ctx context.Context, telegramID int64, unblockedBy int64) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewUpdate().
+			Model((*domain.User)(nil)).
+			Set("blocked_at = NULL").
+			Set("blocked_until = NULL").
+			Set("blocked_reason = ''").
+			Set("blocked_by = NULL").
+			Where("telegram_id = ?", telegramID).
+			Exec(ctx); err != nil {
+			return err
+		}
+		return insertAudit(ctx, tx, &domain.AuditEntry{
+			ActorID:    unblockedBy,
+			Action:     domain.AuditUserUnblock,
+			EntityType: domain.EntityUser,
+			EntityID:   telegramID,
+		})
+	})
 }
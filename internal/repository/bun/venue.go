@@ -0,0 +1,151 @@
+// internal/repository/bun/venue.go
+package bunrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+	"github.com/uptrace/bun"
+)
+
+type VenueRepo struct {
+	db *bun.DB
+}
+
+func NewVenueRepo(db *bun.DB) *VenueRepo {
+	return &VenueRepo{db: db}
+}
+
+func (r *VenueRepo) Create(ctx context.Context, v *domain.Venue) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(v).Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventVenueCreated, domain.EntityVenue, v.ID, v.CreatedBy, venuePayload(v))
+	})
+}
+
+func (r *VenueRepo) GetByID(ctx context.Context, id int64) (*domain.Venue, error) {
+	v := new(domain.Venue)
+	err := r.db.NewSelect().Model(v).Where("id = ?", id).Where("deleted_at IS NULL").Scan(ctx)
+	return v, err
+}
+
+func (r *VenueRepo) List(ctx context.Context) ([]*domain.Venue, error) {
+	var venues []*domain.Venue
+	err := r.db.NewSelect().Model(&venues).Where("deleted_at IS NULL").Order("name ASC").Scan(ctx)
+	return venues, err
+}
+
+// Update saves venue and renames location of its tournaments
+func (r *VenueRepo) Update(ctx context.Context, v *domain.Venue) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewUpdate().Model(v).WherePK().Returning("*").Exec(ctx); err != nil {
+			return err
+		}
+		actorID := actorOf(v.UpdatedBy, v.CreatedBy)
+		err := updateVenueTournaments(ctx, tx, actorID, func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.Set("location = ?", v.Name).Where("venue_id = ?", v.ID).Where("location <> ?", v.Name)
+		})
+		if err != nil {
+			return err
+		}
+		return recordEvent(ctx, tx, domain.EventVenueUpdated, domain.EntityVenue, v.ID, actorID, venuePayload(v))
+	})
+}
+
+func (r *VenueRepo) Delete(ctx context.Context, id, deletedBy int64) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		v := new(domain.Venue)
+		if err := tx.NewSelect().Model(v).Where("id = ?", id).For("UPDATE").Scan(ctx); err != nil {
+			return err
+		}
+		err := updateVenueTournaments(ctx, tx, deletedBy, func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.Set("venue_id = NULL").Where("venue_id = ?", id)
+		})
+		if err != nil {
+			return err
+		}
+		if err := softDelete(ctx, tx, (*domain.Venue)(nil), id, deletedBy); err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, tx, domain.EventVenueDeleted, domain.EntityVenue, id, deletedBy, venuePayload(v)); err != nil {
+			return err
+		}
+		return insertAudit(ctx, tx, &domain.AuditEntry{
+			ActorID:    deletedBy,
+			Action:     domain.AuditVenueDelete,
+			EntityType: domain.EntityVenue,
+			EntityID:   id,
+			Details:    venuePayload(v),
+		})
+	})
+}
+
+// Merge moves tournaments of a duplicate venue (source) to target and deletes source
+func (r *VenueRepo) Merge(ctx context.Context, sourceID, targetID, mergedBy int64) (*domain.Venue, error) {
+	if sourceID == targetID {
+		return nil, repository.ErrSameVenue
+	}
+
+	target := new(domain.Venue)
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		source := new(domain.Venue)
+		if err := tx.NewSelect().Model(source).Where("id = ?", sourceID).For("UPDATE").Scan(ctx); err != nil {
+			return err
+		}
+		if err := tx.NewSelect().Model(target).Where("id = ?", targetID).For("UPDATE").Scan(ctx); err != nil {
+			return err
+		}
+
+		err := updateVenueTournaments(ctx, tx, mergedBy, func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.Set("venue_id = ?", targetID).Set("location = ?", target.Name).Where("venue_id = ?", sourceID)
+		})
+		if err != nil {
+			return err
+		}
+		if err := softDelete(ctx, tx, (*domain.Venue)(nil), sourceID, mergedBy); err != nil {
+			return err
+		}
+		if err := recordEvent(ctx, tx, domain.EventVenueDeleted, domain.EntityVenue, sourceID, mergedBy, venuePayload(source)); err != nil {
+			return err
+		}
+		return insertAudit(ctx, tx, &domain.AuditEntry{
+			ActorID:    mergedBy,
+			Action:     domain.AuditVenueMerge,
+			EntityType: domain.EntityVenue,
+			EntityID:   targetID,
+			Details: map[string]any{
+				"source_venue_id": sourceID,
+				"source_name":     source.Name,
+				"target_name":     target.Name,
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// updateVenueTournaments rewrites tournaments selected by apply as a regular
+// tournament change: version bump and a tournament.updated event each
+func updateVenueTournaments(ctx context.Context, tx bun.Tx, actorID int64, apply func(*bun.UpdateQuery) *bun.UpdateQuery) error {
+	var changed []*domain.Tournament
+	q := tx.NewUpdate().
+		Model((*domain.Tournament)(nil)).
+		Set("updated_at = ?", time.Now()).
+		Set("updated_by = ?", actorID).
+		Set("version = version + 1")
+	if _, err := apply(q).Returning("*").Exec(ctx, &changed); err != nil {
+		return err
+	}
+	for _, t := range changed {
+		if err := recordEvent(ctx, tx, domain.EventTournamentUpdated, domain.EntityTournament, t.ID, actorID, tournamentPayload(t)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// internal/repository/bun/webhook.go
+package bunrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/uptrace/bun"
+)
+
+type WebhookRepo struct {
+	db *bun.DB
+}
+
+func NewWebhookRepo(db *bun.DB) *WebhookRepo {
+	return &WebhookRepo{db: db}
+}
+
+func (r *WebhookRepo) Create(ctx context.Context, webhook *domain.Webhook) error {
+	_, err := r.db.NewInsert().Model(webhook).Returning("*").Exec(ctx)
+	return err
+}
+
+func (r *WebhookRepo) GetByID(ctx context.Context, id int64) (*domain.Webhook, error) {
+	webhook := new(domain.Webhook)
+	err := r.db.NewSelect().Model(webhook).Where("id = ?", id).Scan(ctx)
+	return webhook, err
+}
+
+func (r *WebhookRepo) List(ctx context.Context) ([]*domain.Webhook, error) {
+	var webhooks []*domain.Webhook
+	err := r.db.NewSelect().Model(&webhooks).Order("id ASC").Scan(ctx)
+	return webhooks, err
+}
+
+func (r *WebhookRepo) Update(ctx context.Context, webhook *domain.Webhook) error {
+	_, err := r.db.NewUpdate().Model(webhook).WherePK().Returning("*").Exec(ctx)
+	return err
+}
+
+func (r *WebhookRepo) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.NewDelete().Model((*domain.Webhook)(nil)).Where("id = ?", id).Exec(ctx)
+	return err
+}
+
+func (r *WebhookRepo) Enqueue(ctx context.Context, event string, payload map[string]any) error {
+	if payload == nil {
+		payload = map[string]any{}
+	}
+	_, err := r.db.NewRaw(`
+		INSERT INTO webhook_outbox (webhook_id, event, payload)
+		SELECT w.id, ?, ?
+		FROM webhooks w
+		WHERE w.active AND w.deleted_at IS NULL AND ? = ANY(w.events)
+	`, event, payload, event).Exec(ctx)
+	return err
+}
+
+func (r *WebhookRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookJob, error) {
+	// Bump next_attempt_at as a lease: if the worker dies, the job becomes due again
+	var ids []int64
+	err := r.db.NewRaw(`
+		UPDATE webhook_outbox
+		SET attempts = attempts + 1, next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM webhook_outbox
+			WHERE status = ? AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at ASC
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id
+	`, time.Now().Add(lease), domain.WebhookJobPending, limit).Scan(ctx, &ids)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var jobs []*domain.WebhookJob
+	err = r.db.NewSelect().
+		Model(&jobs).
+		Relation("Webhook").
+		Where("job.id IN (?)", bun.In(ids)).
+		Order("job.id ASC").
+		Scan(ctx)
+	return jobs, err
+}
+
+func (r *WebhookRepo) FinishAttempt(ctx context.Context, job *domain.WebhookJob, delivery *domain.WebhookDelivery) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().
+			Model(job).
+			Column("status", "next_attempt_at", "last_error", "delivered_at").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = tx.NewInsert().Model(delivery).Returning("*").Exec(ctx)
+		return err
+	})
+}
+
+func (r *WebhookRepo) ListDeliveries(ctx context.Context, webhookID int64, limit, offset int) ([]*domain.WebhookDelivery, int, error) {
+	var deliveries []*domain.WebhookDelivery
+	total, err := r.db.NewSelect().
+		Model(&deliveries).
+		Where("webhook_id = ?", webhookID).
+		Order("created_at DESC", "id DESC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+	return deliveries, total, err
+}
@@ -0,0 +1,40 @@
+// internal/repository/cached/cached.go
+package cached
+
+import (
+	"context"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/cache"
+)
+
+const (
+	listTTL   = 5 * time.Minute
+	ratingTTL = 5 * time.Minute
+	statsTTL  = 5 * time.Minute
+)
+
+// fetch returns cached value of key or loads and caches it under tags.
+// Cache errors fall through to load.
+func fetch[T any](ctx context.Context, c *cache.Cache, key string, ttl time.Duration, tags []string, load func() (T, error)) (T, error) {
+	var v T
+	if err := c.Get(ctx, key, &v); err == nil {
+		return v, nil
+	}
+
+	v, err := load()
+	if err != nil {
+		return v, err
+	}
+	_ = c.SetTagged(ctx, key, v, ttl, tags...)
+	return v, nil
+}
+
+// invalidate drops keys tagged with tags after a successful write.
+// A failure leaves stale data until TTL, the write itself has succeeded.
+func invalidate(ctx context.Context, c *cache.Cache, err error, tags ...string) error {
+	if err == nil {
+		_ = c.InvalidateTags(ctx, tags...)
+	}
+	return err
+}
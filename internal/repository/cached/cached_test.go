@@ -0,0 +1,122 @@
+// internal/repository/cached/cached_test.go
+package cached
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// readPrefixes mark interface methods that don't change data
+var readPrefixes = []string{"Get", "List", "Search"}
+
+// TestDecoratorsDeclareWrites fails when a decorator leaves a write method
+// to the embedded repository: a promoted write would skip invalidation
+func TestDecoratorsDeclareWrites(t *testing.T) {
+	fset := token.NewFileSet()
+
+	ifaces := map[string][]string{} // repository interface -> methods
+	ifacePaths, err := filepath.Glob(filepath.Join("..", "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range ifacePaths {
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			if it, ok := spec.Type.(*ast.InterfaceType); ok {
+				for _, m := range it.Methods.List {
+					for _, name := range m.Names {
+						ifaces[spec.Name.Name] = append(ifaces[spec.Name.Name], name.Name)
+					}
+				}
+			}
+			return false
+		})
+	}
+
+	paths, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	embeds := map[string]string{}            // decorator -> embedded interface
+	declared := map[string]map[string]bool{} // decorator -> methods
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, s := range d.Specs {
+					spec, ok := s.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					st, ok := spec.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					for _, f := range st.Fields.List {
+						if sel, ok := f.Type.(*ast.SelectorExpr); ok && len(f.Names) == 0 {
+							embeds[spec.Name.Name] = sel.Sel.Name
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					continue
+				}
+				star, ok := d.Recv.List[0].Type.(*ast.StarExpr)
+				if !ok {
+					continue
+				}
+				recv := star.X.(*ast.Ident).Name
+				if declared[recv] == nil {
+					declared[recv] = map[string]bool{}
+				}
+				declared[recv][d.Name.Name] = true
+			}
+		}
+	}
+
+	if len(embeds) == 0 {
+		t.Fatal("no decorators found")
+	}
+	for decorator, iface := range embeds {
+		methods, ok := ifaces[iface]
+		if !ok {
+			t.Errorf("%s embeds unknown interface %s", decorator, iface)
+			continue
+		}
+		for _, m := range methods {
+			if isRead(m) || declared[decorator][m] {
+				continue
+			}
+			t.Errorf("%s does not declare write method %s.%s", decorator, iface, m)
+		}
+	}
+}
+
+func isRead(method string) bool {
+	for _, p := range readPrefixes {
+		if strings.HasPrefix(method, p) {
+			return true
+		}
+	}
+	return false
+}
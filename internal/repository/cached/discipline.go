@@ -0,0 +1,40 @@
+// internal/repository/cached/discipline.go
+package cached
+
+import (
+	"context"
+
+	"github.com/eugene-twix/amber-bot/internal/cache"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+// DisciplineRepo caches discipline list
+type DisciplineRepo struct {
+	repository.DisciplineRepository
+	cache *cache.Cache
+}
+
+func NewDisciplineRepo(repo repository.DisciplineRepository, c *cache.Cache) *DisciplineRepo {
+	return &DisciplineRepo{DisciplineRepository: repo, cache: c}
+}
+
+var listDisciplinesTags = []string{cache.TagDisciplines}
+
+func (r *DisciplineRepo) List(ctx context.Context) ([]*domain.Discipline, error) {
+	return fetch(ctx, r.cache, cache.DisciplinesListKey, listTTL, listDisciplinesTags, func() ([]*domain.Discipline, error) {
+		return r.DisciplineRepository.List(ctx)
+	})
+}
+
+func (r *DisciplineRepo) Create(ctx context.Context, discipline *domain.Discipline) error {
+	return invalidate(ctx, r.cache, r.DisciplineRepository.Create(ctx, discipline), cache.TagDisciplines)
+}
+
+func (r *DisciplineRepo) Update(ctx context.Context, discipline *domain.Discipline) error {
+	return invalidate(ctx, r.cache, r.DisciplineRepository.Update(ctx, discipline), cache.TagDisciplines)
+}
+
+func (r *DisciplineRepo) Delete(ctx context.Context, id, deletedBy int64) error {
+	return invalidate(ctx, r.cache, r.DisciplineRepository.Delete(ctx, id, deletedBy), cache.TagDisciplines)
+}
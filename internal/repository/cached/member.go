@@ -0,0 +1,32 @@
+// internal/repository/cached/member.go
+package cached
+
+import (
+	"context"
+
+	"github.com/eugene-twix/amber-bot/internal/cache"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+// MemberRepo caches nothing itself; the roster feeds frequent teammates in stats
+type MemberRepo struct {
+	repository.MemberRepository
+	cache *cache.Cache
+}
+
+func NewMemberRepo(repo repository.MemberRepository, c *cache.Cache) *MemberRepo {
+	return &MemberRepo{MemberRepository: repo, cache: c}
+}
+
+func (r *MemberRepo) Create(ctx context.Context, member *domain.Member) error {
+	return invalidate(ctx, r.cache, r.MemberRepository.Create(ctx, member), cache.TagMembers)
+}
+
+func (r *MemberRepo) Update(ctx context.Context, member *domain.Member) error {
+	return invalidate(ctx, r.cache, r.MemberRepository.Update(ctx, member), cache.TagMembers)
+}
+
+func (r *MemberRepo) Delete(ctx context.Context, id, deletedBy int64) error {
+	return invalidate(ctx, r.cache, r.MemberRepository.Delete(ctx, id, deletedBy), cache.TagMembers)
+}
@@ -0,0 +1,40 @@
+// internal/repository/cached/player.go
+package cached
+
+import (
+	"context"
+
+	"github.com/eugene-twix/amber-bot/internal/cache"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+// PlayerRepo caches player list; names also appear in player rating
+type PlayerRepo struct {
+	repository.PlayerRepository
+	cache *cache.Cache
+}
+
+func NewPlayerRepo(repo repository.PlayerRepository, c *cache.Cache) *PlayerRepo {
+	return &PlayerRepo{PlayerRepository: repo, cache: c}
+}
+
+var listPlayersTags = []string{cache.TagPlayers}
+
+func (r *PlayerRepo) List(ctx context.Context) ([]*domain.Player, error) {
+	return fetch(ctx, r.cache, cache.PlayersListKey, listTTL, listPlayersTags, func() ([]*domain.Player, error) {
+		return r.PlayerRepository.List(ctx)
+	})
+}
+
+func (r *PlayerRepo) Create(ctx context.Context, player *domain.Player) error {
+	return invalidate(ctx, r.cache, r.PlayerRepository.Create(ctx, player), cache.TagPlayers)
+}
+
+func (r *PlayerRepo) Update(ctx context.Context, player *domain.Player) error {
+	return invalidate(ctx, r.cache, r.PlayerRepository.Update(ctx, player), cache.TagPlayers)
+}
+
+func (r *PlayerRepo) Delete(ctx context.Context, id, deletedBy int64) error {
+	return invalidate(ctx, r.cache, r.PlayerRepository.Delete(ctx, id, deletedBy), cache.TagPlayers)
+}
@@ -0,0 +1,55 @@
+// internal/repository/cached/result.go
+package cached
+
+import (
+	"context"
+
+	"github.com/eugene-twix/amber-bot/internal/cache"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+// ResultRepo caches team and player rating
+type ResultRepo struct {
+	repository.ResultRepository
+	cache *cache.Cache
+}
+
+func NewResultRepo(repo repository.ResultRepository, c *cache.Cache) *ResultRepo {
+	return &ResultRepo{ResultRepository: repo, cache: c}
+}
+
+// Rating depends on results, team names and which tournaments are deleted
+var ratingTags = []string{cache.TagResults, cache.TagTeams, cache.TagTournaments}
+
+func (r *ResultRepo) GetTeamRating(ctx context.Context, disciplineID int64) ([]repository.TeamRating, error) {
+	return fetch(ctx, r.cache, cache.RatingKey(disciplineID), ratingTTL, ratingTags, func() ([]repository.TeamRating, error) {
+		return r.ResultRepository.GetTeamRating(ctx, disciplineID)
+	})
+}
+
+// Player rating depends on player names instead of team names
+var playerRatingTags = []string{cache.TagResults, cache.TagPlayers, cache.TagTournaments}
+
+func (r *ResultRepo) GetPlayerRating(ctx context.Context, disciplineID int64) ([]repository.PlayerRating, error) {
+	return fetch(ctx, r.cache, cache.PlayerRatingKey(disciplineID), ratingTTL, playerRatingTags, func() ([]repository.PlayerRating, error) {
+		return r.ResultRepository.GetPlayerRating(ctx, disciplineID)
+	})
+}
+
+// Create may also start the tournament
+func (r *ResultRepo) Create(ctx context.Context, result *domain.Result) error {
+	return invalidate(ctx, r.cache, r.ResultRepository.Create(ctx, result), cache.TagResults, cache.TagTournaments)
+}
+
+func (r *ResultRepo) Update(ctx context.Context, result *domain.Result) error {
+	return invalidate(ctx, r.cache, r.ResultRepository.Update(ctx, result), cache.TagResults)
+}
+
+func (r *ResultRepo) Delete(ctx context.Context, id, deletedBy int64) error {
+	return invalidate(ctx, r.cache, r.ResultRepository.Delete(ctx, id, deletedBy), cache.TagResults)
+}
+
+func (r *ResultRepo) DeleteWithShift(ctx context.Context, id, tournamentID int64, deletedPlace int, deletedBy int64) error {
+	return invalidate(ctx, r.cache, r.ResultRepository.DeleteWithShift(ctx, id, tournamentID, deletedPlace, deletedBy), cache.TagResults)
+}
@@ -0,0 +1,46 @@
+// internal/repository/cached/role.go
+package cached
+
+import (
+	"context"
+
+	"github.com/eugene-twix/amber-bot/internal/cache"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+// RoleRepo caches roles; GetByName runs on every authenticated request
+type RoleRepo struct {
+	repository.RoleRepository
+	cache *cache.Cache
+}
+
+func NewRoleRepo(repo repository.RoleRepository, c *cache.Cache) *RoleRepo {
+	return &RoleRepo{RoleRepository: repo, cache: c}
+}
+
+var rolesTags = []string{cache.TagRoles}
+
+func (r *RoleRepo) GetByName(ctx context.Context, name string) (*domain.RoleDef, error) {
+	return fetch(ctx, r.cache, cache.RoleKey(name), listTTL, rolesTags, func() (*domain.RoleDef, error) {
+		return r.RoleRepository.GetByName(ctx, name)
+	})
+}
+
+func (r *RoleRepo) List(ctx context.Context) ([]*domain.RoleDef, error) {
+	return fetch(ctx, r.cache, cache.RolesListKey, listTTL, rolesTags, func() ([]*domain.RoleDef, error) {
+		return r.RoleRepository.List(ctx)
+	})
+}
+
+func (r *RoleRepo) Create(ctx context.Context, role *domain.RoleDef) error {
+	return invalidate(ctx, r.cache, r.RoleRepository.Create(ctx, role), cache.TagRoles)
+}
+
+func (r *RoleRepo) Update(ctx context.Context, role *domain.RoleDef) error {
+	return invalidate(ctx, r.cache, r.RoleRepository.Update(ctx, role), cache.TagRoles)
+}
+
+func (r *RoleRepo) Delete(ctx context.Context, id int64, deletedBy int64) error {
+	return invalidate(ctx, r.cache, r.RoleRepository.Delete(ctx, id, deletedBy), cache.TagRoles)
+}
@@ -0,0 +1,36 @@
+// internal/repository/cached/stats.go
+package cached
+
+import (
+	"context"
+
+	"github.com/eugene-twix/amber-bot/internal/cache"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+// StatsRepo caches team and venue stats
+type StatsRepo struct {
+	repository.StatsRepository
+	cache *cache.Cache
+}
+
+func NewStatsRepo(repo repository.StatsRepository, c *cache.Cache) *StatsRepo {
+	return &StatsRepo{StatsRepository: repo, cache: c}
+}
+
+// Rank and opponents depend on other teams' results too, teammates on the roster
+var statsTags = []string{cache.TagResults, cache.TagTeams, cache.TagTournaments, cache.TagMembers}
+
+func (r *StatsRepo) GetTeamStats(ctx context.Context, teamID, disciplineID int64) (*repository.TeamStats, error) {
+	return fetch(ctx, r.cache, cache.TeamStatsKey(teamID, disciplineID), statsTTL, statsTags, func() (*repository.TeamStats, error) {
+		return r.StatsRepository.GetTeamStats(ctx, teamID, disciplineID)
+	})
+}
+
+var venueStatsTags = []string{cache.TagResults, cache.TagTournaments, cache.TagVenues}
+
+func (r *StatsRepo) GetVenueStats(ctx context.Context, venueID, disciplineID int64) (*repository.VenueStats, error) {
+	return fetch(ctx, r.cache, cache.VenueStatsKey(venueID, disciplineID), statsTTL, venueStatsTags, func() (*repository.VenueStats, error) {
+		return r.StatsRepository.GetVenueStats(ctx, venueID, disciplineID)
+	})
+}
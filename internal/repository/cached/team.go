@@ -0,0 +1,56 @@
+// internal/repository/cached/team.go
+package cached
+
+import (
+	"context"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/cache"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+// TeamRepo caches team list; team names also appear in rating and stats
+type TeamRepo struct {
+	repository.TeamRepository
+	cache *cache.Cache
+}
+
+func NewTeamRepo(repo repository.TeamRepository, c *cache.Cache) *TeamRepo {
+	return &TeamRepo{TeamRepository: repo, cache: c}
+}
+
+var listTeamsTags = []string{cache.TagTeams}
+
+func (r *TeamRepo) List(ctx context.Context) ([]*domain.Team, error) {
+	return fetch(ctx, r.cache, cache.TeamsListKey, listTTL, listTeamsTags, func() ([]*domain.Team, error) {
+		return r.TeamRepository.List(ctx)
+	})
+}
+
+func (r *TeamRepo) Create(ctx context.Context, team *domain.Team) error {
+	return invalidate(ctx, r.cache, r.TeamRepository.Create(ctx, team), cache.TagTeams)
+}
+
+func (r *TeamRepo) Update(ctx context.Context, team *domain.Team) error {
+	return invalidate(ctx, r.cache, r.TeamRepository.Update(ctx, team), cache.TagTeams)
+}
+
+func (r *TeamRepo) Rename(ctx context.Context, team *domain.Team, effectiveFrom time.Time) error {
+	return invalidate(ctx, r.cache, r.TeamRepository.Rename(ctx, team, effectiveFrom), cache.TagTeams)
+}
+
+func (r *TeamRepo) Delete(ctx context.Context, id, deletedBy int64) error {
+	return invalidate(ctx, r.cache, r.TeamRepository.Delete(ctx, id, deletedBy), cache.TagTeams)
+}
+
+// Merge and RevertMerge move results between teams
+func (r *TeamRepo) Merge(ctx context.Context, sourceID, targetID, mergedBy int64) (*domain.TeamMerge, error) {
+	merge, err := r.TeamRepository.Merge(ctx, sourceID, targetID, mergedBy)
+	return merge, invalidate(ctx, r.cache, err, cache.TagTeams, cache.TagResults)
+}
+
+func (r *TeamRepo) RevertMerge(ctx context.Context, mergeID, revertedBy int64) (*domain.TeamMerge, error) {
+	merge, err := r.TeamRepository.RevertMerge(ctx, mergeID, revertedBy)
+	return merge, invalidate(ctx, r.cache, err, cache.TagTeams, cache.TagResults)
+}
@@ -0,0 +1,50 @@
+// internal/repository/cached/tournament.go
+package cached
+
+import (
+	"context"
+
+	"github.com/eugene-twix/amber-bot/internal/cache"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+// TournamentRepo caches tournament list; deleted tournaments drop out of rating and stats
+type TournamentRepo struct {
+	repository.TournamentRepository
+	cache *cache.Cache
+}
+
+func NewTournamentRepo(repo repository.TournamentRepository, c *cache.Cache) *TournamentRepo {
+	return &TournamentRepo{TournamentRepository: repo, cache: c}
+}
+
+var listTournamentsTags = []string{cache.TagTournaments}
+
+func (r *TournamentRepo) List(ctx context.Context) ([]*domain.Tournament, error) {
+	return fetch(ctx, r.cache, cache.TournamentsListKey, listTTL, listTournamentsTags, func() ([]*domain.Tournament, error) {
+		return r.TournamentRepository.List(ctx)
+	})
+}
+
+func (r *TournamentRepo) Create(ctx context.Context, tournament *domain.Tournament) error {
+	return invalidate(ctx, r.cache, r.TournamentRepository.Create(ctx, tournament), cache.TagTournaments)
+}
+
+func (r *TournamentRepo) Update(ctx context.Context, tournament *domain.Tournament) error {
+	return invalidate(ctx, r.cache, r.TournamentRepository.Update(ctx, tournament), cache.TagTournaments)
+}
+
+func (r *TournamentRepo) Delete(ctx context.Context, id, deletedBy int64) error {
+	return invalidate(ctx, r.cache, r.TournamentRepository.Delete(ctx, id, deletedBy), cache.TagTournaments)
+}
+
+// Registrations are not part of any cached value; declared so every write
+// of the interface goes through the decorator explicitly
+func (r *TournamentRepo) Register(ctx context.Context, reg *domain.Registration) error {
+	return r.TournamentRepository.Register(ctx, reg)
+}
+
+func (r *TournamentRepo) Unregister(ctx context.Context, tournamentID, teamID int64) error {
+	return r.TournamentRepository.Unregister(ctx, tournamentID, teamID)
+}
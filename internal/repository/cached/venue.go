@@ -0,0 +1,45 @@
+// internal/repository/cached/venue.go
+package cached
+
+import (
+	"context"
+
+	"github.com/eugene-twix/amber-bot/internal/cache"
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+// VenueRepo caches venue list; update, delete and merge also rewrite tournaments
+type VenueRepo struct {
+	repository.VenueRepository
+	cache *cache.Cache
+}
+
+func NewVenueRepo(repo repository.VenueRepository, c *cache.Cache) *VenueRepo {
+	return &VenueRepo{VenueRepository: repo, cache: c}
+}
+
+var listVenuesTags = []string{cache.TagVenues}
+
+func (r *VenueRepo) List(ctx context.Context) ([]*domain.Venue, error) {
+	return fetch(ctx, r.cache, cache.VenuesListKey, listTTL, listVenuesTags, func() ([]*domain.Venue, error) {
+		return r.VenueRepository.List(ctx)
+	})
+}
+
+func (r *VenueRepo) Create(ctx context.Context, venue *domain.Venue) error {
+	return invalidate(ctx, r.cache, r.VenueRepository.Create(ctx, venue), cache.TagVenues)
+}
+
+func (r *VenueRepo) Update(ctx context.Context, venue *domain.Venue) error {
+	return invalidate(ctx, r.cache, r.VenueRepository.Update(ctx, venue), cache.TagVenues, cache.TagTournaments)
+}
+
+func (r *VenueRepo) Delete(ctx context.Context, id, deletedBy int64) error {
+	return invalidate(ctx, r.cache, r.VenueRepository.Delete(ctx, id, deletedBy), cache.TagVenues, cache.TagTournaments)
+}
+
+func (r *VenueRepo) Merge(ctx context.Context, sourceID, targetID, mergedBy int64) (*domain.Venue, error) {
+	target, err := r.VenueRepository.Merge(ctx, sourceID, targetID, mergedBy)
+	return target, invalidate(ctx, r.cache, err, cache.TagVenues, cache.TagTournaments)
+}
@@ -0,0 +1,52 @@
+// internal/repository/headtohead.go
+package repository
+
+import "time"
+
+// HeadToHeadGame - tournament where both teams have a result
+type HeadToHeadGame struct {
+	TournamentID   int64
+	TournamentName string
+	TournamentDate time.Time
+	Place          int // place of the team
+	OtherPlace     int // place of the opponent
+}
+
+// HeadToHead - comparison of two teams over their common tournaments
+type HeadToHead struct {
+	TeamID  int64
+	OtherID int64
+	Games   []HeadToHeadGame
+	Wins    int // team finished higher
+	Losses  int // opponent finished higher
+	Draws   int // same place
+	// AvgPlaceDiff = avg(other place - team place); positive means the team usually finishes higher
+	AvgPlaceDiff float64
+}
+
+// NewHeadToHead computes win/loss record from common games
+func NewHeadToHead(teamID, otherID int64, games []HeadToHeadGame) *HeadToHead {
+	h := &HeadToHead{
+		TeamID:  teamID,
+		OtherID: otherID,
+		Games:   games,
+	}
+	if len(games) == 0 {
+		return h
+	}
+
+	totalDiff := 0
+	for _, g := range games {
+		switch {
+		case g.Place < g.OtherPlace:
+			h.Wins++
+		case g.Place > g.OtherPlace:
+			h.Losses++
+		default:
+			h.Draws++
+		}
+		totalDiff += g.OtherPlace - g.Place
+	}
+	h.AvgPlaceDiff = float64(totalDiff) / float64(len(games))
+	return h
+}
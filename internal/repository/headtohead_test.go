@@ -0,0 +1,49 @@
+// internal/repository/headtohead_test.go
+package repository
+
+import "testing"
+
+func games(places ...[2]int) []HeadToHeadGame {
+	result := make([]HeadToHeadGame, len(places))
+	for i, p := range places {
+		result[i] = HeadToHeadGame{TournamentID: int64(i + 1), Place: p[0], OtherPlace: p[1]}
+	}
+	return result
+}
+
+func TestNewHeadToHead(t *testing.T) {
+	tests := []struct {
+		name       string
+		games      []HeadToHeadGame
+		wantWins   int
+		wantLosses int
+		wantDraws  int
+		wantDiff   float64
+	}{
+		{"no common games", nil, 0, 0, 0, 0},
+		{"single win", games([2]int{1, 3}), 1, 0, 0, 2},
+		{"single loss", games([2]int{4, 2}), 0, 1, 0, -2},
+		{"draw", games([2]int{2, 2}), 0, 0, 1, 0},
+		{"mixed", games([2]int{1, 2}, [2]int{5, 2}, [2]int{3, 3}, [2]int{2, 6}), 2, 1, 1, 0.5},
+		{"mostly behind", games([2]int{3, 1}, [2]int{4, 1}, [2]int{2, 3}), 1, 2, 0, -4.0 / 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHeadToHead(1, 2, tt.games)
+			if h.TeamID != 1 || h.OtherID != 2 {
+				t.Errorf("teams = %d, %d, want 1, 2", h.TeamID, h.OtherID)
+			}
+			if len(h.Games) != len(tt.games) {
+				t.Errorf("games = %d, want %d", len(h.Games), len(tt.games))
+			}
+			if h.Wins != tt.wantWins || h.Losses != tt.wantLosses || h.Draws != tt.wantDraws {
+				t.Errorf("record = %d-%d-%d, want %d-%d-%d",
+					h.Wins, h.Losses, h.Draws, tt.wantWins, tt.wantLosses, tt.wantDraws)
+			}
+			if diff := h.AvgPlaceDiff - tt.wantDiff; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("AvgPlaceDiff = %v, want %v", h.AvgPlaceDiff, tt.wantDiff)
+			}
+		})
+	}
+}
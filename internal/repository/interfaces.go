@@ -3,24 +3,60 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/eugene-twix/amber-bot/internal/domain"
 )
 
+var (
+	ErrSameTeam             = errors.New("source and target team are the same")
+	ErrSameVenue            = errors.New("source and target venue are the same")
+	ErrMergeAlreadyReverted = errors.New("merge already reverted")
+	ErrMergeChained         = errors.New("target team was merged again later")
+	ErrMergeChanged         = errors.New("merged rows were changed after the merge")
+	ErrInvalidEffectiveDate = errors.New("effective date is before the current name was adopted")
+)
+
 type UserRepository interface {
-	GetOrCreate(ctx context.Context, telegramID int64, username string) (*domain.User, error)
+	// GetOrCreate registers the user or refreshes the Telegram profile and last_seen_at
+	GetOrCreate(ctx context.Context, telegramID int64, username, firstName, lastName string) (*domain.User, error)
 	GetByTelegramID(ctx context.Context, telegramID int64) (*domain.User, error)
-	UpdateRole(ctx context.Context, telegramID int64, role domain.Role) error
-	List(ctx context.Context) ([]*domain.User, error)
+	// UpdateRole writes the change to the audit trail and a user.role_changed event;
+	// domain.ErrLastAdmin if no admins would remain
+	UpdateRole(ctx context.Context, telegramID int64, role domain.Role, changedBy int64) error
+	// List returns users newest first and the total matching filter
+	List(ctx context.Context, filter UserFilter) ([]*domain.User, int, error)
+	// GetActivity counts results recorded and entities created by the user
+	GetActivity(ctx context.Context, telegramID int64) (*UserActivity, error)
+	// TouchLastSeen updates last_seen_at, at most once a minute
+	TouchLastSeen(ctx context.Context, telegramID int64, at time.Time) error
+	// Block blocks user until (nil - until unblocked), Unblock lifts it;
+	// both are written to the audit trail
+	Block(ctx context.Context, telegramID int64, reason string, until *time.Time, blockedBy int64) error
+	Unblock(ctx context.Context, telegramID int64, unblockedBy int64) error
 }
 
 type TeamRepository interface {
 	Create(ctx context.Context, team *domain.Team) error
 	GetByID(ctx context.Context, id int64) (*domain.Team, error)
+	// GetByName finds team by current name, then by alias or previous name
 	GetByName(ctx context.Context, name string) (*domain.Team, error)
 	List(ctx context.Context) ([]*domain.Team, error)
+	// Search matches current names and aliases
+	Search(ctx context.Context, query string) ([]*domain.Team, error)
+	// Update saves team; a changed name is recorded in name history as of now
 	Update(ctx context.Context, team *domain.Team) error
-	Delete(ctx context.Context, id int64) error
+	// Rename saves team with new name effective from the given date
+	Rename(ctx context.Context, team *domain.Team, effectiveFrom time.Time) error
+	GetNameHistory(ctx context.Context, teamID int64) ([]*domain.TeamAlias, error)
+	Delete(ctx context.Context, id, deletedBy int64) error
+	// Merge moves members and results from source into target, records the
+	// source name as alias and soft-deletes source in a transaction
+	Merge(ctx context.Context, sourceID, targetID, mergedBy int64) (*domain.TeamMerge, error)
+	// RevertMerge undoes a previous Merge; ErrMergeChained if the target was
+	// merged into another team since, ErrMergeChanged if moved rows were edited away
+	RevertMerge(ctx context.Context, mergeID, revertedBy int64) (*domain.TeamMerge, error)
 }
 
 type MemberRepository interface {
@@ -28,16 +64,72 @@ type MemberRepository interface {
 	GetByID(ctx context.Context, id int64) (*domain.Member, error)
 	GetByTeamID(ctx context.Context, teamID int64) ([]*domain.Member, error)
 	Update(ctx context.Context, member *domain.Member) error
-	Delete(ctx context.Context, id int64) error
+	Delete(ctx context.Context, id, deletedBy int64) error
 }
 
 type TournamentRepository interface {
 	Create(ctx context.Context, tournament *domain.Tournament) error
 	GetByID(ctx context.Context, id int64) (*domain.Tournament, error)
 	List(ctx context.Context) ([]*domain.Tournament, error)
-	ListRecent(ctx context.Context, limit int) ([]*domain.Tournament, error)
+	// ListByPhase returns tournaments in a phase: upcoming and ongoing soonest
+	// first, finished and started latest first
+	ListByPhase(ctx context.Context, filter TournamentFilter) ([]*domain.Tournament, error)
+	// ListSince returns tournaments dated from the given day, oldest first.
+	// teamID > 0 limits to tournaments the team is registered for or played.
+	ListSince(ctx context.Context, from time.Time, teamID int64) ([]*domain.Tournament, error)
 	Update(ctx context.Context, tournament *domain.Tournament) error
-	Delete(ctx context.Context, id int64) error
+	Delete(ctx context.Context, id, deletedBy int64) error
+	// Register signs team up for tournament; registering twice is a no-op
+	Register(ctx context.Context, reg *domain.Registration) error
+	Unregister(ctx context.Context, tournamentID, teamID int64) error
+	ListRegistrations(ctx context.Context, tournamentID int64) ([]*domain.Registration, error)
+}
+
+// PhaseStarted - ongoing or finished (start time passed or status moved on)
+const PhaseStarted = "started"
+
+// TournamentFilter selects tournaments for ListByPhase. Phases follow
+// domain.Tournament.Phase: status wins, otherwise start and end time.
+type TournamentFilter struct {
+	Phase           string // domain.Phase* or PhaseStarted; empty - any
+	Statuses        []domain.TournamentStatus
+	TeamID          int64                  // > 0 - tournaments the team is registered for or played
+	VenueID         int64                  // > 0 - tournaments held at the venue
+	DisciplineID    int64                  // > 0 - tournaments of the discipline
+	ParticipantMode domain.ParticipantMode // "" - any
+	Now             time.Time
+	DefaultTimezone string // for tournaments without timezone
+	Limit           int
+}
+
+type VenueRepository interface {
+	Create(ctx context.Context, venue *domain.Venue) error
+	GetByID(ctx context.Context, id int64) (*domain.Venue, error)
+	List(ctx context.Context) ([]*domain.Venue, error)
+	// Update renames location of the venue's tournaments as tournament updates
+	Update(ctx context.Context, venue *domain.Venue) error
+	// Delete removes venue; its tournaments keep the location text
+	Delete(ctx context.Context, id, deletedBy int64) error
+	// Merge moves tournaments of source to target, deletes source and returns target
+	Merge(ctx context.Context, sourceID, targetID, mergedBy int64) (*domain.Venue, error)
+}
+
+type PlayerRepository interface {
+	Create(ctx context.Context, player *domain.Player) error
+	GetByID(ctx context.Context, id int64) (*domain.Player, error)
+	List(ctx context.Context) ([]*domain.Player, error)
+	Update(ctx context.Context, player *domain.Player) error
+	Delete(ctx context.Context, id, deletedBy int64) error
+}
+
+type DisciplineRepository interface {
+	Create(ctx context.Context, discipline *domain.Discipline) error
+	GetByID(ctx context.Context, id int64) (*domain.Discipline, error)
+	// List returns disciplines, the default (first created) first
+	List(ctx context.Context) ([]*domain.Discipline, error)
+	Update(ctx context.Context, discipline *domain.Discipline) error
+	// Delete fails with domain.ErrDisciplineInUse if it has tournaments
+	Delete(ctx context.Context, id, deletedBy int64) error
 }
 
 type ResultRepository interface {
@@ -45,11 +137,124 @@ type ResultRepository interface {
 	GetByID(ctx context.Context, id int64) (*domain.Result, error)
 	GetByTeamID(ctx context.Context, teamID int64) ([]*domain.Result, error)
 	GetByTournamentID(ctx context.Context, tournamentID int64) ([]*domain.Result, error)
-	GetTeamRating(ctx context.Context) ([]TeamRating, error)
+	GetByPlayerID(ctx context.Context, playerID int64) ([]*domain.Result, error)
+	// GetTeamRating ranks teams by results in a discipline (0 - all disciplines)
+	GetTeamRating(ctx context.Context, disciplineID int64) ([]TeamRating, error)
+	// GetPlayerRating ranks players by individual results, like GetTeamRating
+	GetPlayerRating(ctx context.Context, disciplineID int64) ([]PlayerRating, error)
+	// GetHeadToHead returns tournaments where both teams have a result, newest first
+	GetHeadToHead(ctx context.Context, teamID, otherID int64) ([]HeadToHeadGame, error)
 	Update(ctx context.Context, result *domain.Result) error
-	Delete(ctx context.Context, id int64) error
+	Delete(ctx context.Context, id, deletedBy int64) error
 	// DeleteWithShift deletes result and shifts higher places down in a transaction
-	DeleteWithShift(ctx context.Context, id int64, tournamentID int64, deletedPlace int) error
+	DeleteWithShift(ctx context.Context, id, tournamentID int64, deletedPlace int, deletedBy int64) error
+}
+
+type BadgeRepository interface {
+	// GetTeamHistory returns team results oldest first
+	GetTeamHistory(ctx context.Context, teamID int64) ([]TeamHistoryEntry, error)
+	// TeamIDsWithResults returns teams that have at least one result
+	TeamIDsWithResults(ctx context.Context) ([]int64, error)
+	// Award stores a team badge and copies it to current team members;
+	// returns false if the team already has it. A badge with AnnouncedAt
+	// set is stored as already announced.
+	Award(ctx context.Context, badge *domain.Badge) (bool, error)
+	GetByID(ctx context.Context, id int64) (*domain.Badge, error)
+	GetByTeamID(ctx context.Context, teamID int64) ([]*domain.Badge, error)
+	GetByMemberID(ctx context.Context, memberID int64) ([]*domain.Badge, error)
+	MarkAnnounced(ctx context.Context, ids []int64) error
+}
+
+type APITokenRepository interface {
+	Create(ctx context.Context, token *domain.APIToken) error
+	GetByID(ctx context.Context, id int64) (*domain.APIToken, error)
+	// GetByHash finds a token by SHA-256 hash, including revoked and expired ones
+	GetByHash(ctx context.Context, hash string) (*domain.APIToken, error)
+	List(ctx context.Context) ([]*domain.APIToken, error)
+	Revoke(ctx context.Context, id int64, revokedBy int64) error
+	// TouchLastUsed updates last_used_at, at most once a minute
+	TouchLastUsed(ctx context.Context, id int64, at time.Time) error
+}
+
+type RoleRepository interface {
+	Create(ctx context.Context, role *domain.RoleDef) error
+	GetByID(ctx context.Context, id int64) (*domain.RoleDef, error)
+	GetByName(ctx context.Context, name string) (*domain.RoleDef, error)
+	// List returns built-in roles first, then custom ones by name
+	List(ctx context.Context) ([]*domain.RoleDef, error)
+	Update(ctx context.Context, role *domain.RoleDef) error
+	// Delete fails with domain.ErrRoleBuiltin or domain.ErrRoleInUse
+	Delete(ctx context.Context, id int64, deletedBy int64) error
+}
+
+type InviteRepository interface {
+	Create(ctx context.Context, invite *domain.Invite) error
+	GetByID(ctx context.Context, id int64) (*domain.Invite, error)
+	// List returns a page of invites newest first, including revoked and
+	// expired ones, and the total count
+	List(ctx context.Context, limit, offset int) ([]*domain.Invite, int, error)
+	Revoke(ctx context.Context, id int64, revokedBy int64) error
+	// Redeem checks the invite by token hash, counts the use and sets the
+	// user's role in a transaction; fails with domain.ErrInvite* errors
+	Redeem(ctx context.Context, hash string, telegramID int64, now time.Time) (*domain.Invite, error)
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *domain.Webhook) error
+	GetByID(ctx context.Context, id int64) (*domain.Webhook, error)
+	List(ctx context.Context) ([]*domain.Webhook, error)
+	Update(ctx context.Context, webhook *domain.Webhook) error
+	Delete(ctx context.Context, id int64) error
+	// Enqueue adds an outbox job for every active webhook subscribed to the event
+	Enqueue(ctx context.Context, event string, payload map[string]any) error
+	// ClaimDue locks up to limit due jobs for lease and returns them with webhooks
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookJob, error)
+	// FinishAttempt saves job state and the delivery log entry
+	FinishAttempt(ctx context.Context, job *domain.WebhookJob, delivery *domain.WebhookDelivery) error
+	// ListDeliveries returns a page of the delivery log, newest first, and the total
+	ListDeliveries(ctx context.Context, webhookID int64, limit, offset int) ([]*domain.WebhookDelivery, int, error)
+}
+
+type EventRepository interface {
+	// Process passes pending events of the given types (all if empty) that
+	// occurred after since to handle, in order, and records each handled one
+	// for subscriber right away. A subscriber seen for the first time starts
+	// after the newest existing event. Only one process handles a subscriber at a
+	// time. A failed event is set aside and retried later with backoff, the
+	// rest go on; returns the number of events taken and handler errors joined.
+	Process(ctx context.Context, subscriber string, types []string, limit int, since time.Time,
+		handle func(ctx context.Context, e *domain.Event) error) (int, error)
+	DeleteBefore(ctx context.Context, before time.Time) error
+}
+
+type AuditRepository interface {
+	Create(ctx context.Context, entry *domain.AuditEntry) error
+	// List returns entries newest first and the total matching filter
+	List(ctx context.Context, filter AuditFilter) ([]*domain.AuditEntry, int, error)
+}
+
+type UserFilter struct {
+	Query  string      // telegram ID, username (with or without @) or part of name
+	Role   domain.Role // "" - any
+	Limit  int         // 0 - all
+	Offset int
+}
+
+type UserActivity struct {
+	ResultsRecorded    int
+	TeamsCreated       int
+	MembersCreated     int
+	TournamentsCreated int
+	VenuesCreated      int
+	PlayersCreated     int
+}
+
+type AuditFilter struct {
+	EntityType string
+	EntityID   int64
+	ActorID    int64
+	Limit      int
+	Offset     int
 }
 
 type TeamRating struct {
@@ -59,3 +264,11 @@ type TeamRating struct {
 	TotalGames int
 	AvgPlace   float64
 }
+
+type PlayerRating struct {
+	PlayerID   int64
+	PlayerName string
+	Wins       int // 1 места
+	TotalGames int
+	AvgPlace   float64
+}
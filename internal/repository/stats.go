@@ -0,0 +1,92 @@
+// internal/repository/stats.go
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// StatsRepository - read-only aggregate queries for dashboards
+type StatsRepository interface {
+	// Stats over tournaments of a discipline, 0 - all disciplines
+	GetTeamStats(ctx context.Context, teamID, disciplineID int64) (*TeamStats, error)
+	GetVenueStats(ctx context.Context, venueID, disciplineID int64) (*VenueStats, error)
+}
+
+type VenueStats struct {
+	VenueID           int64
+	TournamentsHosted int
+	// Teams per tournament, over tournaments with results
+	AvgAttendance  float64
+	LastTournament *time.Time // date of the latest tournament
+}
+
+type TeamStats struct {
+	TeamID     int64
+	TotalGames int
+	Podiums    PodiumCounts
+	BestPlace  int
+	WorstPlace int
+	AvgPlace   float64
+
+	// Average place per month, oldest first
+	MonthlyTrend []MonthlyPlace
+
+	// Consecutive tournaments attended (tournaments with at least one result)
+	CurrentStreak int
+	LongestStreak int
+
+	// Position in rating (wins DESC, avg_place ASC) among teams with results
+	Rank       int
+	RatedTeams int
+	Percentile float64 // share of rated teams ranked at or below this team, 0-100
+
+	// Teams met most often at the same tournaments
+	FrequentOpponents []TeamMeetings
+
+	// Members with the most games for the team. Lineups are not recorded,
+	// so a member counts for results of tournaments held while on the
+	// roster: from joined_at until removal.
+	FrequentTeammates []Teammate
+}
+
+type PodiumCounts struct {
+	First  int
+	Second int
+	Third  int
+}
+
+type MonthlyPlace struct {
+	Month    time.Time
+	Games    int
+	AvgPlace float64
+}
+
+type TeamMeetings struct {
+	TeamID   int64
+	TeamName string
+	Games    int
+}
+
+type Teammate struct {
+	MemberID int64
+	Name     string
+	Games    int
+}
+
+// AttendanceStreaks returns current (ending at the last tournament) and longest
+// run of attended tournaments. attended is ordered oldest first.
+func AttendanceStreaks(attended []bool) (current, longest int) {
+	run := 0
+	for _, a := range attended {
+		if a {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	return run, longest
+}
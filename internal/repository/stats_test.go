@@ -0,0 +1,35 @@
+// internal/repository/stats_test.go
+package repository
+
+import "testing"
+
+func TestAttendanceStreaks(t *testing.T) {
+	const (
+		y = true
+		n = false
+	)
+	tests := []struct {
+		name        string
+		attended    []bool
+		wantCurrent int
+		wantLongest int
+	}{
+		{"no tournaments", nil, 0, 0},
+		{"never attended", []bool{n, n, n}, 0, 0},
+		{"attended all", []bool{y, y, y, y}, 4, 4},
+		{"missed the last one", []bool{y, y, y, n}, 0, 3},
+		{"current is longest", []bool{y, n, y, y, y}, 3, 3},
+		{"longest in the past", []bool{y, y, y, y, n, y, y}, 2, 4},
+		{"only the last one", []bool{n, n, y}, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, longest := AttendanceStreaks(tt.attended)
+			if current != tt.wantCurrent || longest != tt.wantLongest {
+				t.Errorf("AttendanceStreaks() = %d, %d, want %d, %d",
+					current, longest, tt.wantCurrent, tt.wantLongest)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// internal/schedule/schedule.go
+package schedule
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrInvalidFormat = errors.New("invalid date format")
+
+// Accepted inputs, most specific first. Layouts without offset are read in
+// the tournament timezone.
+var (
+	dateTimeLayouts = []string{
+		"2006-01-02T15:04",
+		"2006-01-02 15:04",
+		"02.01.2006 15:04",
+		"2006/01/02 15:04",
+		"02/01/2006 15:04",
+	}
+	dateLayouts = []string{
+		"2006-01-02",
+		"02.01.2006",
+		"2006/01/02",
+		"02/01/2006",
+	}
+)
+
+// ParseStart parses tournament start: RFC3339, a local date-time or a date.
+// hasTime is false for a date, start is then midnight in loc.
+func ParseStart(input string, loc *time.Location) (start time.Time, hasTime bool, err error) {
+	input = strings.Join(strings.Fields(input), " ")
+
+	if t, err := time.Parse(time.RFC3339, input); err == nil {
+		return t.In(loc), true, nil
+	}
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, input, loc); err == nil {
+			return t, true, nil
+		}
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, input, loc); err == nil {
+			return t, false, nil
+		}
+	}
+	return time.Time{}, false, ErrInvalidFormat
+}
+
+// LoadLocation returns timezone by IANA name, def for an empty name
+func LoadLocation(name string, def *time.Location) (*time.Location, error) {
+	if name == "" {
+		return def, nil
+	}
+	return time.LoadLocation(name)
+}
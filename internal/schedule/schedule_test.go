@@ -0,0 +1,53 @@
+// internal/schedule/schedule_test.go
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseStart(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatalf("failed to load timezone: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		want     string // RFC3339
+		wantTime bool
+		wantErr  bool
+	}{
+		{"rfc3339 converted to tournament timezone", "2026-01-15T16:30:00Z", "2026-01-15T19:30:00+03:00", true, false},
+		{"iso local", "2026-01-15T19:30", "2026-01-15T19:30:00+03:00", true, false},
+		{"dotted date-time", "15.01.2026 19:30", "2026-01-15T19:30:00+03:00", true, false},
+		{"extra spaces", "  15.01.2026   19:30 ", "2026-01-15T19:30:00+03:00", true, false},
+		{"iso date", "2026-01-15", "2026-01-15T00:00:00+03:00", false, false},
+		{"dotted date", "15.01.2026", "2026-01-15T00:00:00+03:00", false, false},
+		{"slashed date", "15/01/2026", "2026-01-15T00:00:00+03:00", false, false},
+		{"time only", "19:30", "", false, true},
+		{"garbage", "в четверг", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, hasTime, err := ParseStart(tt.input, moscow)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %v", start)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := start.Format(time.RFC3339); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+			if hasTime != tt.wantTime {
+				t.Errorf("expected hasTime %v, got %v", tt.wantTime, hasTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// internal/webhooks/dispatcher.go
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+const (
+	// MaxAttempts before a job is marked failed
+	MaxAttempts = 10
+
+	pollInterval   = 5 * time.Second
+	batchSize      = 20
+	requestTimeout = 10 * time.Second
+	// lease must exceed requestTimeout so a job isn't claimed twice
+	claimLease = 1 * time.Minute
+
+	backoffBase = 30 * time.Second
+	backoffMax  = 6 * time.Hour
+
+	maxErrorLength = 500
+)
+
+// Backoff returns delay before the next attempt after the given attempt
+// number (1-based): 30s, 1m, 2m, 4m, ... capped at 6h
+func Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := backoffBase
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= backoffMax {
+			return backoffMax
+		}
+	}
+	return d
+}
+
+// Dispatcher delivers outbox jobs to webhook URLs
+type Dispatcher struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+}
+
+// NewDispatcher creates a dispatcher; nil client - the default one, which
+// only connects to public addresses
+func NewDispatcher(repo repository.WebhookRepository, client *http.Client) *Dispatcher {
+	if client == nil {
+		client = newClient()
+	}
+	return &Dispatcher{repo: repo, client: client}
+}
+
+// Run polls the outbox until ctx is done
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("ERROR: webhook delivery failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue delivers one batch of due jobs
+func (d *Dispatcher) DeliverDue(ctx context.Context) error {
+	jobs, err := d.repo.ClaimDue(ctx, batchSize, claimLease)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		delivery := d.deliver(ctx, job)
+		if err := d.repo.FinishAttempt(ctx, job, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliver sends job and updates its state; returns the delivery log entry
+func (d *Dispatcher) deliver(ctx context.Context, job *domain.WebhookJob) *domain.WebhookDelivery {
+	delivery := &domain.WebhookDelivery{
+		JobID:     job.ID,
+		WebhookID: job.WebhookID,
+		Attempt:   job.Attempts,
+	}
+
+	if job.Webhook == nil || !job.Webhook.Active {
+		fail(job, delivery, "webhook deleted or disabled", true)
+		return delivery
+	}
+
+	start := time.Now()
+	status, err := d.Send(ctx, job.Webhook, job)
+	delivery.DurationMs = time.Since(start).Milliseconds()
+	if status != 0 {
+		delivery.StatusCode = &status
+	}
+
+	switch {
+	case err != nil:
+		fail(job, delivery, err.Error(), job.Attempts >= MaxAttempts)
+	case status < 200 || status >= 300:
+		fail(job, delivery, fmt.Sprintf("unexpected status %d", status), job.Attempts >= MaxAttempts)
+	default:
+		now := time.Now()
+		job.Status = domain.WebhookJobDelivered
+		job.DeliveredAt = &now
+		job.LastError = nil
+	}
+	return delivery
+}
+
+func fail(job *domain.WebhookJob, delivery *domain.WebhookDelivery, msg string, final bool) {
+	if len(msg) > maxErrorLength {
+		msg = msg[:maxErrorLength]
+	}
+	job.LastError = &msg
+	delivery.Error = &msg
+	if final {
+		job.Status = domain.WebhookJobFailed
+		return
+	}
+	job.NextAttemptAt = time.Now().Add(Backoff(job.Attempts))
+}
+
+// Send posts signed job payload to webhook URL and returns response status
+func (d *Dispatcher) Send(ctx context.Context, webhook *domain.Webhook, job *domain.WebhookJob) (int, error) {
+	body, err := json.Marshal(map[string]any{
+		"id":         job.ID,
+		"event":      job.Event,
+		"created_at": job.CreatedAt.Format(time.RFC3339),
+		"data":       job.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "amber-bot-webhooks")
+	req.Header.Set(HeaderEvent, job.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(job.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, now, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,281 @@
+// internal/webhooks/dispatcher_test.go
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+// fakeRepo keeps outbox jobs in memory
+type fakeRepo struct {
+	repository.WebhookRepository
+
+	jobs       []*domain.WebhookJob
+	deliveries []*domain.WebhookDelivery
+}
+
+func (r *fakeRepo) ClaimDue(_ context.Context, limit int, lease time.Duration) ([]*domain.WebhookJob, error) {
+	var due []*domain.WebhookJob
+	for _, j := range r.jobs {
+		if j.Status == domain.WebhookJobPending && !j.NextAttemptAt.After(time.Now()) && len(due) < limit {
+			j.Attempts++
+			j.NextAttemptAt = time.Now().Add(lease)
+			due = append(due, j)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeRepo) FinishAttempt(_ context.Context, _ *domain.WebhookJob, delivery *domain.WebhookDelivery) error {
+	r.deliveries = append(r.deliveries, delivery)
+	return nil
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{5, 8 * time.Minute},
+		{20, 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.attempt), func(t *testing.T) {
+			if got := Backoff(tt.attempt); got != tt.want {
+				t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendSignsPayload(t *testing.T) {
+	const secret = "test_secret"
+
+	var gotEvent string
+	var gotData map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		ts, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		if err != nil {
+			t.Errorf("invalid timestamp header: %v", err)
+		}
+		if !Verify(secret, time.Unix(ts, 0), body, r.Header.Get(HeaderSignature)) {
+			t.Error("signature doesn't match body")
+		}
+		if r.Header.Get(HeaderDelivery) != "7" {
+			t.Errorf("expected delivery 7, got %s", r.Header.Get(HeaderDelivery))
+		}
+
+		var envelope struct {
+			Event string         `json:"event"`
+			Data  map[string]any `json:"data"`
+		}
+		_ = json.Unmarshal(body, &envelope)
+		gotEvent = envelope.Event
+		gotData = envelope.Data
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(nil, server.Client())
+	status, err := d.Send(context.Background(),
+		&domain.Webhook{URL: server.URL, Secret: secret},
+		&domain.WebhookJob{ID: 7, Event: domain.EventResultCreated, Payload: map[string]any{"place": 2}},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", status)
+	}
+	if gotEvent != domain.EventResultCreated {
+		t.Errorf("expected event %s, got %s", domain.EventResultCreated, gotEvent)
+	}
+	if gotData["place"] != float64(2) {
+		t.Errorf("expected place 2 in data, got %v", gotData["place"])
+	}
+}
+
+func TestVerifyRejectsTamperedBody(t *testing.T) {
+	now := time.Now()
+	sig := Sign("secret", now, []byte(`{"place":1}`))
+
+	if Verify("secret", now, []byte(`{"place":2}`), sig) {
+		t.Error("expected tampered body to fail verification")
+	}
+	if Verify("other", now, []byte(`{"place":1}`), sig) {
+		t.Error("expected wrong secret to fail verification")
+	}
+}
+
+func TestDeliverDueRetriesWithBackoff(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	webhook := &domain.Webhook{ID: 1, URL: server.URL, Secret: "s", Active: true}
+	job := &domain.WebhookJob{
+		ID:            1,
+		WebhookID:     1,
+		Event:         domain.EventTournamentCreated,
+		Status:        domain.WebhookJobPending,
+		NextAttemptAt: time.Now(),
+		Webhook:       webhook,
+	}
+	repo := &fakeRepo{jobs: []*domain.WebhookJob{job}}
+	d := NewDispatcher(repo, server.Client())
+
+	// First attempt fails: job stays pending with backoff
+	if err := d.DeliverDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Status != domain.WebhookJobPending {
+		t.Fatalf("expected pending after failure, got %s", job.Status)
+	}
+	if job.LastError == nil {
+		t.Error("expected last error to be set")
+	}
+	if wait := time.Until(job.NextAttemptAt); wait < 25*time.Second || wait > Backoff(1) {
+		t.Errorf("expected retry in ~%v, got %v", Backoff(1), wait)
+	}
+
+	// Not due yet
+	if err := d.DeliverDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected no delivery before backoff, got %d calls", calls)
+	}
+
+	// Second attempt succeeds
+	job.NextAttemptAt = time.Now()
+	if err := d.DeliverDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Status != domain.WebhookJobDelivered {
+		t.Errorf("expected delivered, got %s", job.Status)
+	}
+
+	if len(repo.deliveries) != 2 {
+		t.Fatalf("expected 2 delivery log entries, got %d", len(repo.deliveries))
+	}
+	if code := repo.deliveries[0].StatusCode; code == nil || *code != http.StatusInternalServerError {
+		t.Errorf("expected first delivery status 500, got %v", code)
+	}
+	if repo.deliveries[1].Attempt != 2 {
+		t.Errorf("expected second delivery attempt 2, got %d", repo.deliveries[1].Attempt)
+	}
+}
+
+func TestDeliverMarksFailedAfterMaxAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	job := &domain.WebhookJob{
+		ID:            1,
+		Status:        domain.WebhookJobPending,
+		Attempts:      MaxAttempts - 1,
+		NextAttemptAt: time.Now(),
+		Webhook:       &domain.Webhook{URL: server.URL, Active: true},
+	}
+	repo := &fakeRepo{jobs: []*domain.WebhookJob{job}}
+
+	if err := NewDispatcher(repo, server.Client()).DeliverDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Status != domain.WebhookJobFailed {
+		t.Errorf("expected failed after %d attempts, got %s", MaxAttempts, job.Status)
+	}
+}
+
+func TestDeliverSkipsDeletedWebhook(t *testing.T) {
+	job := &domain.WebhookJob{ID: 1, Status: domain.WebhookJobPending, NextAttemptAt: time.Now()}
+	repo := &fakeRepo{jobs: []*domain.WebhookJob{job}}
+
+	if err := NewDispatcher(repo, nil).DeliverDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Status != domain.WebhookJobFailed {
+		t.Errorf("expected failed for deleted webhook, got %s", job.Status)
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1::", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"224.0.0.1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := IsPublicIP(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("IsPublicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultClientRefusesLoopback(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	job := &domain.WebhookJob{
+		ID:            1,
+		Status:        domain.WebhookJobPending,
+		NextAttemptAt: time.Now(),
+		Webhook:       &domain.Webhook{URL: server.URL, Active: true},
+	}
+	repo := &fakeRepo{jobs: []*domain.WebhookJob{job}}
+
+	if err := NewDispatcher(repo, nil).DeliverDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if called {
+		t.Fatal("expected no request to a loopback address")
+	}
+	if job.LastError == nil || !strings.Contains(*job.LastError, ErrForbiddenAddress.Error()) {
+		t.Errorf("expected forbidden address error, got %v", job.LastError)
+	}
+}
@@ -0,0 +1,73 @@
+// internal/webhooks/webhooks.go
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/eugene-twix/amber-bot/internal/domain"
+	"github.com/eugene-twix/amber-bot/internal/repository"
+)
+
+// Events lists domain event types webhooks can subscribe to
+var Events = []string{
+	domain.EventTeamCreated,
+	domain.EventTournamentCreated,
+	domain.EventTournamentUpdated,
+	domain.EventTournamentDeleted,
+	domain.EventResultCreated,
+	domain.EventResultUpdated,
+	domain.EventResultDeleted,
+}
+
+// Request headers
+const (
+	HeaderEvent     = "X-Amber-Event"
+	HeaderDelivery  = "X-Amber-Delivery"
+	HeaderTimestamp = "X-Amber-Timestamp"
+	HeaderSignature = "X-Amber-Signature"
+)
+
+// IsKnownEvent reports whether event can be subscribed to
+func IsKnownEvent(event string) bool {
+	for _, e := range Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Emit queues event for all subscribed webhooks
+func Emit(ctx context.Context, repo repository.WebhookRepository, event string, payload map[string]any) error {
+	return repo.Enqueue(ctx, event, payload)
+}
+
+// NewSecret generates a signing secret for a webhook
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Sign returns "sha256=<hex>" of HMAC_SHA256(secret, "<timestamp>.<body>").
+// Receivers should recompute it and reject old timestamps.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	h.Write([]byte("."))
+	h.Write(body)
+	return "sha256=" + hex.EncodeToString(h.Sum(nil))
+}
+
+// Verify checks signature made by Sign
+func Verify(secret string, timestamp time.Time, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}